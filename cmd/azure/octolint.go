@@ -14,6 +14,16 @@ import (
 	"path/filepath"
 )
 
+// contentTypes maps the report formats to the content type returned by the function
+var contentTypes = map[string]string{
+	reporters.PlainFormat:    "text/plain; charset=utf-8",
+	reporters.JsonFormat:     "application/json; charset=utf-8",
+	reporters.SarifFormat:    "application/sarif+json; charset=utf-8",
+	reporters.JUnitFormat:    "application/xml; charset=utf-8",
+	reporters.MarkdownFormat: "text/markdown; charset=utf-8",
+	reporters.HtmlFormat:     "text/html; charset=utf-8",
+}
+
 type AzureFunctionRequestDataReq struct {
 	Body string `json:"Body"`
 }
@@ -101,7 +111,25 @@ func octoterraHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	reporter := reporters.NewOctopusWebCheckReporter(checks.Warning)
+	minSeverity, err := checks.StringToSeverity(webArgs.MinSeverity)
+
+	if err != nil {
+		handleError(err, w)
+		return
+	}
+
+	// The web reporter is used in place of the plain reporter to return plain text to the browser
+	var reporter reporters.OctopusCheckReporter = reporters.NewOctopusWebCheckReporter(minSeverity)
+
+	if webArgs.Format != reporters.PlainFormat {
+		reporter, err = reporters.NewOctopusCheckReporter(webArgs.Format, minSeverity)
+
+		if err != nil {
+			handleError(err, w)
+			return
+		}
+	}
+
 	report, err := reporter.Generate(results)
 
 	if err != nil {
@@ -109,7 +137,7 @@ func octoterraHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header()["Content-Type"] = []string{"text/plain; charset=utf-8"}
+	w.Header()["Content-Type"] = []string{contentTypes[webArgs.Format]}
 	w.WriteHeader(200)
 	if _, err := w.Write([]byte(report)); err != nil {
 		zap.L().Error(err.Error())
@@ -1,34 +1,9 @@
 package main
 
 import (
-	"fmt"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/args"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/entry"
-	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
-	"os"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/pkg/cli"
 )
 
 func main() {
-	octolintConfig, err := args.ParseArgs(os.Args[1:])
-
-	if err != nil {
-		entry.ErrorExit(err.Error())
-		return
-	}
-
-	results, err := entry.Entry(octolintConfig)
-
-	if err != nil {
-		entry.ErrorExit(err.Error())
-	}
-
-	reporter := reporters.NewOctopusPlainCheckReporter(checks.Warning)
-	report, err := reporter.Generate(results)
-
-	if err != nil {
-		entry.ErrorExit("Failed to generate the report")
-	}
-
-	fmt.Println(report)
+	cli.Main()
 }
@@ -0,0 +1,83 @@
+// Package disabledprojects is an example of a check that is compiled into octolint without changing the octolint
+// source code. Importing the package registers the check.
+package disabledprojects
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/pkg/checks"
+	"github.com/samber/lo"
+)
+
+const ExampleDisabledProjects = "ExampleDisabledProjects"
+
+func init() {
+	checks.MustRegisterCheck(ExampleDisabledProjects, func(dependencies checks.OctopusCheckDependencies) checks.OctopusCheck {
+		return NewExampleDisabledProjectsCheck(dependencies)
+	})
+}
+
+// ExampleDisabledProjectsCheck reports projects that are disabled, which are often projects that are no longer used
+// and can be deleted.
+type ExampleDisabledProjectsCheck struct {
+	dependencies checks.OctopusCheckDependencies
+}
+
+func NewExampleDisabledProjectsCheck(dependencies checks.OctopusCheckDependencies) ExampleDisabledProjectsCheck {
+	return ExampleDisabledProjectsCheck{dependencies: dependencies}
+}
+
+func (o ExampleDisabledProjectsCheck) Id() string {
+	return ExampleDisabledProjects
+}
+
+func (o ExampleDisabledProjectsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.dependencies.Client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	// Parameters can be set in the checks section of the config file, like any built-in check
+	maxProjects := o.dependencies.Config.CheckInt(o.Id(), "maxProjects", 100)
+
+	allProjects, err := o.dependencies.Cache.GetProjectsWithFilter(
+		ctx,
+		o.dependencies.Config.ExcludeProjectsExcept,
+		o.dependencies.Config.ExcludeProjects,
+		maxProjects)
+
+	if err != nil {
+		return o.dependencies.ErrorHandler.HandleError(o.Id(), checks.Organization, err)
+	}
+
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allProjects))
+	defer progress.Complete(len(allProjects))
+
+	disabledProjects := lo.FilterMap(allProjects, func(item *projects.Project, index int) (checks.OctopusCheckFinding, bool) {
+		return checks.OctopusCheckFinding{
+			ResourceType: checks.ProjectResource,
+			ResourceId:   item.ID,
+			ResourceName: item.Name,
+			SpaceId:      o.dependencies.Client.GetSpaceID(),
+			Link:         o.dependencies.UrlBuilder.ProjectSettingsUrl(item.ID),
+		}, item.IsDisabled
+	})
+
+	if len(disabledProjects) > 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following projects are disabled and may no longer be used:",
+			o.Id(),
+			"",
+			checks.Info,
+			checks.Organization,
+			disabledProjects), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are no disabled projects",
+		o.Id(),
+		"",
+		checks.Ok,
+		checks.Organization), nil
+}
@@ -0,0 +1,13 @@
+// octolint-custom is octolint built with the example checks compiled in. Build it with:
+//
+//	go build -o octolint-custom ./examples/octolint-custom
+package main
+
+import (
+	_ "github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/examples/checks/disabledprojects"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/pkg/cli"
+)
+
+func main() {
+	cli.Main()
+}
@@ -5,18 +5,28 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/instance"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/rules"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/policy"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/types"
 	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
 	"os"
 	"strings"
 )
 
+// failOnSeverityNames lists the severities that can be used to fail a run
+var failOnSeverityNames = []string{checks.ErrorSeverityName, checks.WarningSeverityName, checks.InfoSeverityName}
+
 func ParseArgs(args []string) (*config.OctolintConfig, error) {
 	flags := flag.NewFlagSet("octolint", flag.ContinueOnError)
 	var buf bytes.Buffer
@@ -27,18 +37,41 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 	flags.BoolVar(&octolintConfig.Help, "help", false, "Print usage")
 
 	flags.StringVar(&octolintConfig.Url, "url", "", "The Octopus URL e.g. https://myinstance.octopus.app")
-	flags.StringVar(&octolintConfig.Space, "space", "", "The Octopus space name or ID")
+	flags.StringVar(&octolintConfig.Space, "space", "", "A comma separated list of Octopus space names or IDs, or \"all\" to check every space")
 	flags.StringVar(&octolintConfig.ApiKey, "apiKey", "", "The Octopus api key")
+	flags.StringVar(&octolintConfig.GitRef, "gitRef", "", "The Git branch, tag, or commit to read the deployment processes, runbooks, and variables of version controlled projects from. Defaults to the default branch of each project")
 	flags.StringVar(&octolintConfig.SkipTests, "skipTests", "", "A comma separated list of tests to skip")
 	flags.StringVar(&octolintConfig.OnlyTests, "onlyTests", "", "A comma separated list of tests to include")
+	flags.BoolVar(&octolintConfig.SkipInstanceChecks, "skipInstanceChecks", false, "Skip the checks that inspect the server rather than a space. Use this with an API key that can only read a space")
 	flags.StringVar(&octolintConfig.ConfigFile, "configFile", "octolint", "The name of the configuration file to use. Do not include the extension. Defaults to octolint")
 	flags.StringVar(&octolintConfig.ConfigPath, "configPath", ".", "The path of the configuration file to use. Defaults to the current directory")
 	flags.BoolVar(&octolintConfig.Verbose, "verbose", false, "Print verbose logs")
 	flags.BoolVar(&octolintConfig.VerboseErrors, "verboseErrors", false, "Print error details as verbose logs in Octopus")
 	flags.BoolVar(&octolintConfig.Version, "version", false, "Print the version")
-	flags.BoolVar(&octolintConfig.Spinner, "spinner", true, "Display the spinner")
+	flags.BoolVar(&octolintConfig.Spinner, "spinner", true, "Display a progress bar while the checks run")
+	flags.BoolVar(&octolintConfig.ProgressJson, "progressJson", false, "Write the progress of the checks to stderr as JSON lines, for tools that wrap octolint. This replaces the progress bar")
+	flags.StringVar(&octolintConfig.Format, "format", reporters.PlainFormat, "The format of the report. Supported values are "+strings.Join(reporters.Formats, ", "))
+	flags.StringVar(&octolintConfig.MinSeverity, "minSeverity", checks.WarningSeverityName, "The minimum severity of the results included in the report. Supported values are "+strings.Join(checks.SeverityNames, ", "))
+	flags.StringVar(&octolintConfig.FailOnSeverity, "failOnSeverity", "", "Exit with a non-zero exit code if any results are at or above this severity. Supported values are "+strings.Join(failOnSeverityNames, ", "))
+	flags.BoolVar(&octolintConfig.OctopusServiceMessages, "octopusServiceMessages", false, "Emit Octopus service messages that capture the finding counts as output variables and attach the report as an artifact. Use this when running octolint in an Octopus step")
+	flags.StringVar(&octolintConfig.ReportFile, "reportFile", "", "The file the report is saved to when octopusServiceMessages is enabled. Defaults to octolint-report with an extension matching the format")
+	flags.StringVar(&octolintConfig.Baseline, "baseline", "", "The path to a baseline file. Findings in the baseline are not reported, and findings in the baseline that are no longer reported are listed as resolved")
+	flags.StringVar(&octolintConfig.WriteBaseline, "writeBaseline", "", "The path to save a baseline file capturing all the findings from this run")
+	flags.StringVar(&octolintConfig.Snapshot, "snapshot", "", "The path to a snapshot saved with writeSnapshot. The checks are run against the snapshot rather than an Octopus server, so the url and apiKey are not required")
+	flags.StringVar(&octolintConfig.WriteSnapshot, "writeSnapshot", "", "The path to save a snapshot of every resource read by the checks in this run. Paths ending in .zip are saved as a zip file, otherwise a directory is created")
+	flags.StringVar(&octolintConfig.Ocl, "ocl", "", "The path to the OCL files of a version controlled project, or to a Git repository with a .octopus directory. The checks that inspect deployment processes, runbooks, and variables are run against the files rather than an Octopus server")
+	flags.DurationVar(&octolintConfig.Timeout, "timeout", 0, "The maximum time to run all the checks for, like 10m. Checks that have not completed are reported as timed out. Defaults to no timeout")
+	flags.DurationVar(&octolintConfig.CheckTimeout, "checkTimeout", 0, "The maximum time to run each check for, like 2m. Defaults to no timeout")
+	flags.IntVar(&octolintConfig.MaxRetries, "maxRetries", defaults.MaxRetries, "The number of times an API request is retried after a transient error, like a rate limit or an unavailable server. Set to 0 to disable retries")
+	flags.DurationVar(&octolintConfig.RetryBackoff, "retryBackoff", defaults.RetryBackoff, "The delay before the first retry of an API request. The delay doubles with each retry")
+	flags.DurationVar(&octolintConfig.RetryMaxBackoff, "retryMaxBackoff", defaults.RetryMaxBackoff, "The maximum delay between retries of an API request, unless the server requests a longer delay with a Retry-After header")
+	flags.Float64Var(&octolintConfig.MaxRequestsPerSecond, "maxRequestsPerSecond", 0, "The maximum number of API requests sent to Octopus each second by all the checks. Set to 0 for no limit")
+	flags.IntVar(&octolintConfig.MaxConcurrentRequests, "maxConcurrentRequests", defaults.MaxConcurrentRequests, "The maximum number of API requests that can be in flight at any time")
+	flags.IntVar(&octolintConfig.MaxParallelSpaces, "maxParallelSpaces", defaults.MaxParallelSpaces, "The maximum number of spaces that are checked at the same time when checking multiple spaces")
+	flags.IntVar(&octolintConfig.RequestBudget, "requestBudget", 0, "The total number of API requests that can be sent to Octopus. Checks that run out of requests report partial results. Set to 0 for no limit")
 	flags.IntVar(&octolintConfig.MaxEnvironments, "maxEnvironments", defaults.MaxEnvironments, "Maximum number of environments for the "+organization.OctopusEnvironmentCountCheckName+" check")
 	flags.IntVar(&octolintConfig.MaxDaysSinceLastTask, "maxDaysSinceLastTask", defaults.MaxTimeSinceLastTask, "Maximum number of days since the last project task for the "+organization.OctopusUnusedProjectsCheckName+" check")
+	flags.IntVar(&octolintConfig.MaxDaysSinceLastLogin, "maxDaysSinceLastLogin", defaults.MaxTimeSinceLastLogin, "Maximum number of days since a user last logged in for the "+instance.OctoLintInactiveUsers+" check")
 	flags.IntVar(&octolintConfig.MaxDuplicateVariables, "maxDuplicateVariables", defaults.MaxDuplicateVariables, "Maximum number of duplicate variables to report on for the "+organization.OctoLintDuplicatedVariables+" check. Set to 0 to report all duplicate variables.")
 	flags.IntVar(&octolintConfig.MaxDuplicateVariableProjects, "maxDuplicateVariableProjects", defaults.MaxDuplicateVariableProjects, "Maximum number of projects to check for duplicate variables for the "+organization.OctoLintDuplicatedVariables+" check. Set to 0 to check all projects.")
 	flags.IntVar(&octolintConfig.MaxDeploymentsByAdminProjects, "maxDeploymentsByAdminProjects", defaults.MaxDeploymentsByAdminProjects, "Maximum number of projects to check for admin deployments for the "+security.OctoLintDeploymentQueuedByAdmin+" check. Set to 0 to check all projects.")
@@ -71,9 +104,14 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 	flags.StringVar(&octolintConfig.ProjectStepWorkerPoolRegex, "projectStepWorkerPoolRegex", "", "The regular expression used to validate step worker pools for the  "+naming.OctoLintProjectReleaseTemplate+" check")
 	flags.StringVar(&octolintConfig.LifecycleNameRegex, "lifecycleNameRegex", "", "The regular expression used to validate lifecycle names for the  "+naming.OctoLintInvalidLifecycleNames+" check")
 
+	flags.Var(&octolintConfig.Policies, "policy", "The built-in name, path, or URL of a policy pack. Pass the argument multiple times to merge packs, with later packs overriding earlier ones. The built-in packs are "+strings.Join(policy.BuiltInPackNames(), ", ")+".")
+	flags.Var(&octolintConfig.Rules, "rules", "The path of a YAML file that defines custom rules. Pass the argument multiple times to load multiple files.")
+	flags.Var(&octolintConfig.CheckTimeouts, "checkTimeouts", "Override the checkTimeout for an individual check, in the format CheckId=duration, like OctoLintDeploymentQueuedTime=5m.")
 	flags.Var(&octolintConfig.ExcludeProjects, "excludeProjects", "Exclude a project from being scanned.")
-	flags.Var(&octolintConfig.ExcludeProjectsRegex, "excludeProjectsRegex", "Exclude a project from being scanned.")
+	flags.Var(&octolintConfig.ExcludeProjectsRegex, "excludeProjectsRegex", "Exclude the projects with names matching the regular expression from being scanned.")
 	flags.Var(&octolintConfig.ExcludeProjectsExcept, "excludeProjectsExcept", "All projects except those defined with excludeProjectsExcept are scanned.")
+	flags.Var(&octolintConfig.Include, "include", "Only scan the resources matching the filter, in the format resource:kind=value, like target:regex=^Production or tenant:tag=Region/US. The resource can be project, target, tenant, environment, lifecycle, feed, account, or workerpool, and the kind can be name, regex, tag, or projectGroup. Pass the argument multiple times to include resources matching any filter.")
+	flags.Var(&octolintConfig.Exclude, "exclude", "Exclude the resources matching the filter from being scanned, in the same format as the include argument, like project:projectGroup=Sandbox. Pass the argument multiple times to add filters.")
 
 	err := flags.Parse(args)
 
@@ -88,12 +126,75 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 		return nil, err
 	}
 
-	err = overrideArgs(flags, octolintConfig.ConfigPath, octolintConfig.ConfigFile)
+	// Capture the arguments passed on the command line before the config file and environment variables are applied
+	commandLineFlags := []*flag.Flag{}
+	flags.Visit(func(definedFlag *flag.Flag) {
+		commandLineFlags = append(commandLineFlags, definedFlag)
+	})
+
+	v, err := overrideArgs(flags, octolintConfig.ConfigPath, octolintConfig.ConfigFile)
 
 	if err != nil {
 		return nil, err
 	}
 
+	policyCheckConfigs, err := readPolicyPacks(octolintConfig.Policies)
+
+	if err != nil {
+		return nil, errors.New("The policy packs are not valid: " + err.Error())
+	}
+
+	configFileCheckConfigs, err := readCheckConfigs(v)
+
+	if err != nil {
+		return nil, errors.New("The checks section of the config file is not valid: " + err.Error())
+	}
+
+	// The config file can override the settings of the policy packs
+	octolintConfig.Checks = mergeCheckConfigs(policyCheckConfigs, configFileCheckConfigs)
+
+	applyCheckAliases(&octolintConfig, commandLineFlags)
+
+	if slices.Index(reporters.Formats, octolintConfig.Format) == -1 {
+		return nil, errors.New("The format \"" + octolintConfig.Format + "\" is not supported. Supported values are " + strings.Join(reporters.Formats, ", "))
+	}
+
+	if _, err := checks.StringToSeverity(octolintConfig.MinSeverity); err != nil {
+		return nil, errors.New("The minimum severity \"" + octolintConfig.MinSeverity + "\" is not supported. Supported values are " + strings.Join(checks.SeverityNames, ", "))
+	}
+
+	if octolintConfig.FailOnSeverity != "" && slices.Index(failOnSeverityNames, strings.ToLower(octolintConfig.FailOnSeverity)) == -1 {
+		return nil, errors.New("The fail on severity \"" + octolintConfig.FailOnSeverity + "\" is not supported. Supported values are " + strings.Join(failOnSeverityNames, ", "))
+	}
+
+	if _, err := client_wrapper.NewResourceFilters(&octolintConfig); err != nil {
+		return nil, errors.New("The resource filters are not valid: " + err.Error())
+	}
+
+	if _, err := rules.LoadOctopusRules(octolintConfig.Rules); err != nil {
+		return nil, errors.New("The rules are not valid: " + err.Error())
+	}
+
+	if octolintConfig.MaxRequestsPerSecond < 0 || octolintConfig.RequestBudget < 0 {
+		return nil, errors.New("The maximum requests per second and the request budget can not be negative")
+	}
+
+	if octolintConfig.MaxConcurrentRequests < 1 {
+		return nil, errors.New("The maximum number of concurrent requests must be at least 1")
+	}
+
+	if octolintConfig.MaxParallelSpaces < 1 {
+		return nil, errors.New("The maximum number of parallel spaces must be at least 1")
+	}
+
+	if octolintConfig.MaxRetries < 0 {
+		return nil, errors.New("The maximum number of retries can not be negative")
+	}
+
+	if _, err := config.ParseCheckTimeouts(octolintConfig.CheckTimeouts); err != nil {
+		return nil, errors.New("The check timeouts are not valid: " + err.Error())
+	}
+
 	if octolintConfig.Url == "" {
 		octolintConfig.Url = os.Getenv("OCTOPUS_CLI_SERVER")
 	}
@@ -107,7 +208,7 @@ func ParseArgs(args []string) (*config.OctolintConfig, error) {
 
 // Inspired by https://github.com/carolynvs/stingoftheviper
 // Viper needs manual handling to implement reading settings from env vars, config files, and from the command line
-func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) error {
+func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) (*viper.Viper, error) {
 	v := viper.New()
 
 	// Set the base name of the config file, without the file extension.
@@ -123,7 +224,7 @@ func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) err
 	if err := v.ReadInConfig(); err != nil {
 		// It's okay if there isn't a config file
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return err
+			return nil, err
 		}
 	}
 
@@ -143,7 +244,7 @@ func overrideArgs(flags *flag.FlagSet, configPath string, configFile string) err
 	v.AutomaticEnv()
 
 	// Bind the current command's flags to viper
-	return bindFlags(flags, v)
+	return v, bindFlags(flags, v)
 }
 
 // Bind each flag to its associated viper configuration (config file and environment variable)
@@ -0,0 +1,31 @@
+package args
+
+import (
+	"testing"
+)
+
+func TestResourceFiltersInConfigFile(t *testing.T) {
+	octolintConfig, err := ParseArgs([]string{"-configPath", writeConfigFile(t, "exclude:\n  - target:regex=^test\n  - project:projectGroup=Sandbox\n")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(octolintConfig.Exclude) != 2 || octolintConfig.Exclude[0] != "target:regex=^test" {
+		t.Fatalf("expected the filters to be read from the config file, got %v", octolintConfig.Exclude)
+	}
+}
+
+func TestResourceFiltersAreValidated(t *testing.T) {
+	invalidArgs := [][]string{
+		{"-exclude", "target:regex=["},
+		{"-include", "environment:tag=Region/US"},
+		{"-excludeProjectsRegex", "("},
+	}
+
+	for _, args := range invalidArgs {
+		if _, err := ParseArgs(args); err == nil {
+			t.Fatalf("the arguments should not be valid: %v", args)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package args
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/instance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/policy"
+	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
+	"strconv"
+	"strings"
+)
+
+// checkParameter is a threshold or resource limit that can be set for a check in the checks section of the config
+// file. The alias is the name of the flat argument that sets the same value, which is kept for backwards
+// compatibility.
+type checkParameter struct {
+	name    string
+	alias   string
+	numeric bool
+}
+
+// checkParameters lists the parameters of each check that can be set in the checks section of the config file.
+var checkParameters = map[string][]checkParameter{
+	security.OctoLintUnrotatedAccounts: {
+		{name: "maxDaysSinceEdit", numeric: true},
+	},
+	security.OctoLintDeploymentQueuedByAdmin: {
+		{name: "maxProjects", alias: "maxDeploymentsByAdminProjects", numeric: true},
+	},
+	security.OctoLintInsecureK8sTargets: {
+		{name: "maxTargets", alias: "maxInsecureK8sTargets", numeric: true},
+	},
+	organization.OctopusEnvironmentCountCheckName: {
+		{name: "maxEnvironments", alias: "maxEnvironments", numeric: true},
+	},
+	organization.OctoLintDefaultProjectGroupChildCount: {
+		{name: "maxProjectsInDefaultGroup", numeric: true},
+	},
+	organization.OctoLintEmptyProject: {
+		{name: "maxProjects", alias: "maxEmptyProjectCheckProjects", numeric: true},
+	},
+	organization.OctoLintUnusedVariables: {
+		{name: "maxProjects", alias: "maxUnusedVariablesProjects", numeric: true},
+	},
+	organization.OctoLintDuplicatedVariables: {
+		{name: "maxProjects", alias: "maxDuplicateVariableProjects", numeric: true},
+		{name: "maxVariables", alias: "maxDuplicateVariables", numeric: true},
+	},
+	organization.OctoLintTooManySteps: {
+		{name: "maxSteps", numeric: true},
+		{name: "maxProjects", alias: "maxProjectStepsProjects", numeric: true},
+	},
+	organization.OctoLintUnusedTargets: {
+		{name: "maxDaysSinceLastDeployment", numeric: true},
+		{name: "maxTargets", alias: "maxUnusedTargets", numeric: true},
+	},
+	organization.OctoLintProjectSpecificEnvs: {
+		{name: "maxProjects", alias: "maxProjectSpecificEnvironmentProjects", numeric: true},
+		{name: "maxEnvironments", alias: "maxProjectSpecificEnvironmentEnvironments", numeric: true},
+	},
+	organization.OctoLintDirectTenantReferences: {
+		{name: "maxTargets", alias: "maxTenantTagsTargets", numeric: true},
+		{name: "maxTenants", alias: "maxTenantTagsTenants", numeric: true},
+	},
+	organization.OctoLintProjectGroupsWithExclusiveEnvironments: {
+		{name: "maxProjects", alias: "maxExclusiveEnvironmentsProjects", numeric: true},
+	},
+	organization.OctoLintUnhealthyTargets: {
+		{name: "maxDaysSinceHealthy", numeric: true},
+		{name: "maxTargets", alias: "maxUnhealthyTargets", numeric: true},
+	},
+	organization.OctopusUnusedProjectsCheckName: {
+		{name: "maxDaysSinceLastTask", alias: "maxDaysSinceLastTask", numeric: true},
+		{name: "maxProjects", alias: "maxUnusedProjects", numeric: true},
+	},
+	organization.OctopusUnusedTenantsCheckName: {
+		{name: "maxDaysSinceLastTask", alias: "maxDaysSinceLastTask", numeric: true},
+		{name: "maxTenants", alias: "maxUnusedTenants", numeric: true},
+	},
+	performance.OctoLintDeploymentQueuedTime: {
+		{name: "maxQueueTimeMinutes", numeric: true},
+		{name: "maxQueuedTasks", numeric: true},
+		{name: "maxTasks", alias: "maxDeploymentTasks", numeric: true},
+	},
+	naming.OctoLintContainerImageName: {
+		{name: "regex", alias: "containerImageRegex"},
+		{name: "maxProjects", alias: "maxInvalidContainerImageProjects", numeric: true},
+	},
+	naming.OctoLintInvalidVariableNames: {
+		{name: "regex", alias: "variableNameRegex"},
+		{name: "maxProjects", alias: "maxInvalidVariableProjects", numeric: true},
+	},
+	naming.OctoLintInvalidTargetNames: {
+		{name: "regex", alias: "targetNameRegex"},
+		{name: "maxTargets", alias: "maxInvalidNameTargets", numeric: true},
+	},
+	naming.OctoLintInvalidTargetRoles: {
+		{name: "regex", alias: "targetRoleRegex"},
+		{name: "maxTargets", alias: "maxInvalidRoleTargets", numeric: true},
+	},
+	naming.OctoLintProjectReleaseTemplate: {
+		{name: "regex", alias: "projectReleaseTemplateRegex"},
+		{name: "maxProjects", alias: "maxInvalidReleaseTemplateProjects", numeric: true},
+	},
+	naming.OctoLintProjectWorkerPool: {
+		{name: "regex", alias: "projectStepWorkerPoolRegex"},
+		{name: "maxProjects", alias: "maxInvalidWorkerPoolProjects", numeric: true},
+	},
+	naming.OctoLintInvalidLifecycleNames: {
+		{name: "regex", alias: "lifecycleNameRegex"},
+	},
+	naming.OctoLintProjectDefaultStepNames: {
+		{name: "maxProjects", alias: "maxDefaultStepNameProjects", numeric: true},
+	},
+	instance.OctoLintInactiveUsers: {
+		{name: "maxDaysSinceLastLogin", alias: "maxDaysSinceLastLogin", numeric: true},
+	},
+}
+
+// findCheckParameter returns the parameter of a check with the case-insensitive name. Checks that are not listed in
+// checkParameters accept any parameter.
+func findCheckParameter(checkId string, name string) (checkParameter, bool, error) {
+	for id, parameters := range checkParameters {
+		if !strings.EqualFold(id, checkId) {
+			continue
+		}
+
+		for _, parameter := range parameters {
+			if strings.EqualFold(parameter.name, name) {
+				return parameter, true, nil
+			}
+		}
+
+		return checkParameter{}, false, errors.New("the check " + id + " does not have a parameter called " + name)
+	}
+
+	return checkParameter{}, false, nil
+}
+
+// readCheckConfigs reads the checks section of the config file.
+func readCheckConfigs(v *viper.Viper) (map[string]config.CheckConfig, error) {
+	if !v.IsSet("checks") {
+		return map[string]config.CheckConfig{}, nil
+	}
+
+	return parseCheckConfigs(v.GetStringMap("checks"))
+}
+
+// parseCheckConfigs parses a checks section of the config file or a policy pack, which maps check IDs to the settings
+// of the check.
+func parseCheckConfigs(checksSection map[string]any) (map[string]config.CheckConfig, error) {
+	checkConfigs := map[string]config.CheckConfig{}
+
+	for checkId, value := range checksSection {
+		settings, ok := value.(map[string]any)
+
+		if !ok {
+			return nil, errors.New("the settings of the check " + checkId + " must be a map")
+		}
+
+		checkConfig := config.CheckConfig{Parameters: map[string]string{}}
+
+		for name, setting := range settings {
+			settingString := fmt.Sprint(setting)
+
+			switch strings.ToLower(name) {
+			case "enabled":
+				enabled, err := strconv.ParseBool(settingString)
+
+				if err != nil {
+					return nil, errors.New("the enabled setting of the check " + checkId + " must be true or false")
+				}
+
+				checkConfig.Enabled = &enabled
+			case "severity":
+				if slices.Index(failOnSeverityNames, strings.ToLower(settingString)) == -1 {
+					return nil, errors.New("the severity \"" + settingString + "\" of the check " + checkId + " is not supported. Supported values are " + strings.Join(failOnSeverityNames, ", "))
+				}
+
+				checkConfig.Severity = strings.ToLower(settingString)
+			default:
+				parameter, found, err := findCheckParameter(checkId, name)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if found && parameter.numeric {
+					if _, err := strconv.Atoi(settingString); err != nil {
+						return nil, errors.New("the parameter " + parameter.name + " of the check " + checkId + " must be a whole number")
+					}
+				}
+
+				checkConfig.Parameters[strings.ToLower(name)] = settingString
+			}
+		}
+
+		checkConfigs[strings.ToLower(checkId)] = checkConfig
+	}
+
+	return checkConfigs, nil
+}
+
+// mergeCheckConfigs returns the check configurations of the base, with the settings of the overrides replacing the
+// settings of the base. Settings that are not defined by the overrides are kept.
+func mergeCheckConfigs(base map[string]config.CheckConfig, overrides map[string]config.CheckConfig) map[string]config.CheckConfig {
+	merged := map[string]config.CheckConfig{}
+
+	for checkId, checkConfig := range base {
+		merged[checkId] = checkConfig
+	}
+
+	for checkId, override := range overrides {
+		checkConfig := merged[checkId]
+		parameters := map[string]string{}
+
+		for name, value := range checkConfig.Parameters {
+			parameters[name] = value
+		}
+
+		for name, value := range override.Parameters {
+			parameters[name] = value
+		}
+
+		checkConfig.Parameters = parameters
+
+		if override.Enabled != nil {
+			checkConfig.Enabled = override.Enabled
+		}
+
+		if override.Severity != "" {
+			checkConfig.Severity = override.Severity
+		}
+
+		merged[checkId] = checkConfig
+	}
+
+	return merged
+}
+
+// readPolicyPacks loads the policy packs and merges the checks sections of each pack, in the order the packs are
+// passed to the policy argument. A pack is merged after the pack it extends.
+func readPolicyPacks(sources []string) (map[string]config.CheckConfig, error) {
+	packs, err := policy.LoadOctopusPolicyPacks(sources)
+
+	if err != nil {
+		return nil, err
+	}
+
+	checkConfigs := map[string]config.CheckConfig{}
+
+	for _, pack := range packs {
+		packCheckConfigs, err := parseCheckConfigs(pack.Checks)
+
+		if err != nil {
+			return nil, errors.New("the checks section of the policy pack " + pack.Name + " is not valid: " + err.Error())
+		}
+
+		checkConfigs = mergeCheckConfigs(checkConfigs, packCheckConfigs)
+	}
+
+	return checkConfigs, nil
+}
+
+// applyCheckAliases copies the values of the flat arguments passed on the command line to the parameters they are
+// an alias for. Arguments on the command line take precedence over the checks section of the config file, while flat
+// arguments set in the config file or environment variables are only used when the checks section does not set the
+// parameter.
+func applyCheckAliases(octolintConfig *config.OctolintConfig, commandLineFlags []*flag.Flag) {
+	for _, commandLineFlag := range commandLineFlags {
+		for checkId, parameters := range checkParameters {
+			for _, parameter := range parameters {
+				if parameter.alias == commandLineFlag.Name {
+					octolintConfig.SetCheckParameter(checkId, parameter.name, commandLineFlag.Value.String())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package args
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/policy"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "octolint.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestCheckConfigs(t *testing.T) {
+	dir := writeConfigFile(t, `
+maxProjectStepsProjects: 5
+checks:
+  OctoLintTooManySteps:
+    enabled: true
+    severity: Error
+    maxSteps: 30
+  OctoLintProjectContainerImageName:
+    enabled: false
+    regex: ^octopusdeploy/.*
+`)
+
+	octolintConfig, err := ParseArgs([]string{"-configPath", dir})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tooManySteps := octolintConfig.CheckConfig(organization.OctoLintTooManySteps)
+
+	if tooManySteps.Enabled == nil || !*tooManySteps.Enabled || tooManySteps.Severity != "error" {
+		t.Fatalf("the check settings were not read, got %+v", tooManySteps)
+	}
+
+	if octolintConfig.CheckInt(organization.OctoLintTooManySteps, "maxSteps", 20) != 30 {
+		t.Fatal("the check parameters should have been read")
+	}
+
+	if octolintConfig.CheckInt(organization.OctoLintTooManySteps, "maxProjects", octolintConfig.MaxProjectStepsProjects) != 5 {
+		t.Fatal("flat settings in the config file should be used when the checks section does not set the parameter")
+	}
+
+	if octolintConfig.CheckString(naming.OctoLintContainerImageName, "regex", octolintConfig.ContainerImageRegex) != "^octopusdeploy/.*" {
+		t.Fatal("the check parameters should have been read")
+	}
+
+	if octolintConfig.CheckInt(naming.OctoLintInvalidVariableNames, "maxProjects", octolintConfig.MaxInvalidVariableProjects) != defaults.MaxInvalidVariableProjects {
+		t.Fatal("checks without settings should use the flat arguments")
+	}
+}
+
+func TestCheckConfigsCommandLineAliases(t *testing.T) {
+	dir := writeConfigFile(t, `
+checks:
+  OctoLintTooManySteps:
+    maxProjects: 10
+`)
+
+	octolintConfig, err := ParseArgs([]string{"-configPath", dir, "-maxProjectStepsProjects", "50", "-containerImageRegex", "^octopusdeploy/.*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if octolintConfig.CheckInt(organization.OctoLintTooManySteps, "maxProjects", octolintConfig.MaxProjectStepsProjects) != 50 {
+		t.Fatal("arguments passed on the command line should take precedence over the checks section")
+	}
+
+	if octolintConfig.CheckString(naming.OctoLintContainerImageName, "regex", "") != "^octopusdeploy/.*" {
+		t.Fatal("arguments passed on the command line should set the parameter they are an alias for")
+	}
+}
+
+func TestCheckConfigsAreValidated(t *testing.T) {
+	invalidConfigs := []string{
+		"checks:\n  OctoLintTooManySteps:\n    maxSteps: lots\n",
+		"checks:\n  OctoLintTooManySteps:\n    maxStep: 10\n",
+		"checks:\n  OctoLintTooManySteps:\n    severity: critical\n",
+		"checks:\n  OctoLintTooManySteps:\n    enabled: sometimes\n",
+		"checks:\n  OctoLintTooManySteps: true\n",
+	}
+
+	for _, invalidConfig := range invalidConfigs {
+		if _, err := ParseArgs([]string{"-configPath", writeConfigFile(t, invalidConfig)}); err == nil {
+			t.Fatalf("the config should not be valid:\n%s", invalidConfig)
+		}
+	}
+}
+
+func TestBuiltInPolicyPacksAreValid(t *testing.T) {
+	for _, name := range policy.BuiltInPackNames() {
+		if _, err := ParseArgs([]string{"-configPath", t.TempDir(), "-policy", name}); err != nil {
+			t.Fatalf("the built-in pack %s is not valid: %s", name, err)
+		}
+	}
+}
+
+func TestPolicyPacksAreMerged(t *testing.T) {
+	dir := writeConfigFile(t, `
+checks:
+  OctoLintUnrotatedAccounts:
+    maxDaysSinceEdit: 60
+`)
+
+	if err := os.WriteFile(filepath.Join(dir, "team.yaml"), []byte(`
+name: team
+checks:
+  OctoLintUnrotatedAccounts:
+    severity: warning
+    maxDaysSinceEdit: 45
+  OctoLintTooManySteps:
+    maxSteps: 40
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	octolintConfig, err := ParseArgs([]string{"-configPath", dir, "-policy", "strict-security", "-policy", filepath.Join(dir, "team.yaml")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if octolintConfig.CheckConfig(security.OctoLintUnrotatedAccounts).Severity != "warning" {
+		t.Fatal("later packs should override the settings of earlier packs")
+	}
+
+	if octolintConfig.CheckInt(security.OctoLintUnrotatedAccounts, "maxDaysSinceEdit", 0) != 60 {
+		t.Fatal("the config file should override the settings of the policy packs")
+	}
+
+	if octolintConfig.CheckInt(organization.OctoLintTooManySteps, "maxSteps", 0) != 40 {
+		t.Fatal("the settings of the policy packs should be merged")
+	}
+
+	if octolintConfig.CheckInt(organization.OctoLintUnusedTargets, "maxDaysSinceLastDeployment", 0) != 30 {
+		t.Fatal("the settings of extended packs should be merged")
+	}
+
+	if octolintConfig.CheckConfig(security.OctoLintInsecureK8sTargets).Severity != "error" {
+		t.Fatal("settings not overridden by later packs should be kept")
+	}
+}
@@ -0,0 +1,276 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"sort"
+)
+
+const baselineVersion = 2
+
+// OctoLintResolvedFindings is the code of the result listing baseline findings that are no longer reported.
+const OctoLintResolvedFindings = "OctoLintResolvedFindings"
+
+// OctopusBaseline captures the findings from an accepted run. Later runs use the baseline to report only the
+// findings that have been introduced since.
+type OctopusBaseline struct {
+	Version  int                    `json:"version"`
+	Findings []OctopusBaselineEntry `json:"findings"`
+}
+
+// OctopusBaselineEntry is a single finding saved in the baseline.
+type OctopusBaselineEntry struct {
+	// Fingerprint identifies the finding between runs. It is made up of the space ID, the check ID, and the
+	// resource ID.
+	Fingerprint string `json:"fingerprint"`
+	// SpaceId is the ID of the space the finding was reported in. It is empty for the instance checks.
+	SpaceId string `json:"spaceId,omitempty"`
+	// Space is the name of the space the finding was reported in. It is only used to display the finding.
+	Space        string `json:"space,omitempty"`
+	CheckId      string `json:"checkId"`
+	Category     string `json:"category,omitempty"`
+	ResourceType string `json:"resourceType,omitempty"`
+	ResourceId   string `json:"resourceId,omitempty"`
+	ResourceName string `json:"resourceName,omitempty"`
+}
+
+// NewOctopusBaseline creates a baseline from the supplied results.
+func NewOctopusBaseline(results []checks.OctopusCheckResult) OctopusBaseline {
+	entries := map[string]OctopusBaselineEntry{}
+
+	for _, r := range results {
+		if !isBaselined(r) {
+			continue
+		}
+
+		if len(r.Findings()) == 0 {
+			fingerprint := Fingerprint(r.SpaceId(), r.Code(), "")
+			entries[fingerprint] = OctopusBaselineEntry{
+				Fingerprint: fingerprint,
+				SpaceId:     r.SpaceId(),
+				Space:       r.Space(),
+				CheckId:     r.Code(),
+				Category:    r.Category(),
+			}
+			continue
+		}
+
+		for _, f := range r.Findings() {
+			fingerprint := Fingerprint(r.SpaceId(), r.Code(), findingId(f))
+			entries[fingerprint] = OctopusBaselineEntry{
+				Fingerprint:  fingerprint,
+				SpaceId:      r.SpaceId(),
+				Space:        r.Space(),
+				CheckId:      r.Code(),
+				Category:     r.Category(),
+				ResourceType: f.ResourceType,
+				ResourceId:   findingId(f),
+				ResourceName: findingName(f),
+			}
+		}
+	}
+
+	baseline := OctopusBaseline{
+		Version:  baselineVersion,
+		Findings: []OctopusBaselineEntry{},
+	}
+
+	for _, entry := range entries {
+		baseline.Findings = append(baseline.Findings, entry)
+	}
+
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		return baseline.Findings[i].Fingerprint < baseline.Findings[j].Fingerprint
+	})
+
+	return baseline
+}
+
+// ReadOctopusBaseline loads a baseline from a file.
+func ReadOctopusBaseline(path string) (OctopusBaseline, error) {
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return OctopusBaseline{}, err
+	}
+
+	baseline := OctopusBaseline{}
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return OctopusBaseline{}, fmt.Errorf("the baseline file %s is not valid: %w", path, err)
+	}
+
+	// Older baselines identified findings by the space name
+	if baseline.Version != baselineVersion {
+		return OctopusBaseline{}, fmt.Errorf("the baseline file %s has version %d, but only version %d is supported. Recreate it with the -writeBaseline argument", path, baseline.Version, baselineVersion)
+	}
+
+	if baseline.Findings == nil {
+		baseline.Findings = []OctopusBaselineEntry{}
+	}
+
+	return baseline, nil
+}
+
+// Write saves the baseline to a file.
+func (o OctopusBaseline) Write(path string) error {
+	content, err := json.MarshalIndent(o, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0644)
+}
+
+// Apply removes any findings that are already in the baseline. Results whose findings are all in the baseline
+// are reported as passing. Any baseline findings that are no longer reported are returned as resolved.
+func (o OctopusBaseline) Apply(results []checks.OctopusCheckResult) ([]checks.OctopusCheckResult, []OctopusBaselineEntry) {
+	known := map[string]bool{}
+	for _, entry := range o.Findings {
+		known[entry.Fingerprint] = true
+	}
+
+	current := map[string]bool{}
+	// Resolved findings can only be calculated for checks that ran successfully. A check may complete in one space
+	// and fail in another, so each space is tracked separately.
+	completedChecks := map[string]bool{}
+	filteredResults := []checks.OctopusCheckResult{}
+
+	for _, r := range results {
+		if r.Severity() == checks.Permission || checks.IsCheckFailure(r) {
+			filteredResults = append(filteredResults, r)
+			continue
+		}
+
+		// Findings missing from a partial result may not have been resolved
+		if !r.Partial() {
+			completedChecks[Fingerprint(r.SpaceId(), r.Code(), "")] = true
+		}
+
+		if !isBaselined(r) {
+			filteredResults = append(filteredResults, r)
+			continue
+		}
+
+		if len(r.Findings()) == 0 {
+			fingerprint := Fingerprint(r.SpaceId(), r.Code(), "")
+			current[fingerprint] = true
+
+			if known[fingerprint] {
+				filteredResults = append(filteredResults, suppressedResult(r))
+			} else {
+				filteredResults = append(filteredResults, r)
+			}
+			continue
+		}
+
+		newFindings := []checks.OctopusCheckFinding{}
+		for _, f := range r.Findings() {
+			fingerprint := Fingerprint(r.SpaceId(), r.Code(), findingId(f))
+			current[fingerprint] = true
+
+			if !known[fingerprint] {
+				newFindings = append(newFindings, f)
+			}
+		}
+
+		if len(newFindings) == 0 {
+			filteredResults = append(filteredResults, suppressedResult(r))
+		} else {
+			filteredResults = append(filteredResults, checks.CopyOctopusCheckResult(r, newFindings))
+		}
+	}
+
+	resolved := []OctopusBaselineEntry{}
+	for _, entry := range o.Findings {
+		if completedChecks[Fingerprint(entry.SpaceId, entry.CheckId, "")] && !current[entry.Fingerprint] {
+			resolved = append(resolved, entry)
+		}
+	}
+
+	return filteredResults, resolved
+}
+
+// NewResolvedResult creates a result listing the baseline findings that are no longer reported.
+func NewResolvedResult(resolved []OctopusBaselineEntry) checks.OctopusCheckResult {
+	findings := []checks.OctopusCheckFinding{}
+	for _, entry := range resolved {
+		name := entry.ResourceName
+		if name == "" {
+			name = entry.CheckId
+		}
+
+		if entry.Space != "" {
+			name = entry.Space + "/" + name
+		}
+
+		findings = append(findings, checks.OctopusCheckFinding{
+			ResourceType: entry.ResourceType,
+			ResourceId:   entry.ResourceId,
+			ResourceName: name,
+			Details:      "resolved " + entry.CheckId,
+		})
+	}
+
+	return checks.NewOctopusCheckResultWithFindings(
+		"The following findings from the baseline are no longer reported:",
+		OctoLintResolvedFindings,
+		checks.WikiLink(OctoLintResolvedFindings),
+		checks.Info,
+		checks.Organization,
+		findings)
+}
+
+// Fingerprint identifies a finding between runs. The same resource ID can be reported by the same check in different
+// spaces, so the space ID is included when it is known. The ID is used rather than the name, as spaces can be renamed.
+func Fingerprint(spaceId string, checkId string, resourceId string) string {
+	fingerprint := checkId
+	if resourceId != "" {
+		fingerprint += ":" + resourceId
+	}
+
+	if spaceId != "" {
+		return spaceId + "/" + fingerprint
+	}
+
+	return fingerprint
+}
+
+// isBaselined returns true if the result reports an issue that can be saved in the baseline.
+func isBaselined(result checks.OctopusCheckResult) bool {
+	return result.Severity() >= checks.Info && !checks.IsCheckFailure(result)
+}
+
+// findingId returns the ID used to fingerprint a finding. Some resources, like variables, have IDs that are only
+// unique within their parent, so the parent ID is included.
+func findingId(finding checks.OctopusCheckFinding) string {
+	id := finding.ResourceId
+	if id == "" {
+		id = finding.ResourceName
+	}
+
+	if finding.ParentId != "" {
+		return finding.ParentId + "/" + id
+	}
+
+	return id
+}
+
+func findingName(finding checks.OctopusCheckFinding) string {
+	if finding.ParentName != "" {
+		return finding.ParentName + "/" + finding.ResourceName
+	}
+
+	return finding.ResourceName
+}
+
+func suppressedResult(result checks.OctopusCheckResult) checks.OctopusCheckResult {
+	return checks.NewOctopusCheckResultImpl(
+		"All the findings reported by this check are in the baseline",
+		result.Code(),
+		result.Link(),
+		checks.Ok,
+		result.Category())
+}
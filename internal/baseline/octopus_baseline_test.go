@@ -0,0 +1,167 @@
+package baseline
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func projectFinding(id string, name string) checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{ResourceType: checks.ProjectResource, ResourceId: id, ResourceName: name}
+}
+
+func TestBaselineFingerprints(t *testing.T) {
+	emptyProjects := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A")})
+	environments := checks.NewOctopusCheckResultImpl("Too many environments", "OctoLintTooManyEnvironments", "", checks.Warning, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	permissionResult := checks.NewOctopusCheckResultImpl("You do not have permission to run the check", "OctoRecPermission", "", checks.Permission, checks.Security)
+
+	baseline := NewOctopusBaseline([]checks.OctopusCheckResult{emptyProjects, environments, passResult, permissionResult})
+
+	if len(baseline.Findings) != 2 {
+		t.Fatal("Should have saved 2 findings")
+	}
+
+	if baseline.Findings[0].Fingerprint != "OctoLintEmptyProject:Projects-1" || baseline.Findings[1].Fingerprint != "OctoLintTooManyEnvironments" {
+		t.Fatal("Fingerprints must be made up of the check ID and the resource ID")
+	}
+}
+
+func TestBaselineSuppressesKnownFindings(t *testing.T) {
+	original := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A"), projectFinding("Projects-2", "Project B")})
+	baseline := NewOctopusBaseline([]checks.OctopusCheckResult{original})
+
+	latest := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-2", "Project B"), projectFinding("Projects-3", "Project C")})
+	results, resolved := baseline.Apply([]checks.OctopusCheckResult{latest})
+
+	if len(results) != 1 || len(results[0].Findings()) != 1 || results[0].Findings()[0].ResourceId != "Projects-3" {
+		t.Fatal("Should only have reported the new finding")
+	}
+
+	if results[0].Description() != "The following projects have no steps:\nProject C" {
+		t.Fatal("The description must only include the new finding")
+	}
+
+	if len(resolved) != 1 || resolved[0].ResourceId != "Projects-1" {
+		t.Fatal("Should have reported the fixed finding as resolved")
+	}
+}
+
+func TestBaselinePassesWhenAllFindingsAreKnown(t *testing.T) {
+	original := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A")})
+	baseline := NewOctopusBaseline([]checks.OctopusCheckResult{original})
+
+	results, resolved := baseline.Apply([]checks.OctopusCheckResult{original})
+
+	if len(results) != 1 || results[0].Severity() != checks.Ok {
+		t.Fatal("Results whose findings are all in the baseline must pass")
+	}
+
+	if len(resolved) != 0 {
+		t.Fatal("Should not have reported any resolved findings")
+	}
+}
+
+func TestBaselineIgnoresChecksThatDidNotRun(t *testing.T) {
+	original := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A")})
+	baseline := NewOctopusBaseline([]checks.OctopusCheckResult{original})
+
+	permissionResult := checks.NewOctopusCheckResultImpl("You do not have permission to run the check", "OctoLintEmptyProject", "", checks.Permission, checks.Organization)
+	_, resolved := baseline.Apply([]checks.OctopusCheckResult{permissionResult})
+
+	if len(resolved) != 0 {
+		t.Fatal("Findings must not be resolved if the check could not run")
+	}
+}
+
+func TestBaselineReadAndWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	original := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A")})
+
+	if err := NewOctopusBaseline([]checks.OctopusCheckResult{original}).Write(path); err != nil {
+		t.Fatal("Should have written the baseline")
+	}
+
+	baseline, err := ReadOctopusBaseline(path)
+
+	if err != nil {
+		t.Fatal("Should have read the baseline")
+	}
+
+	if len(baseline.Findings) != 1 || baseline.Findings[0].ResourceName != "Project A" {
+		t.Fatal("Should have read the saved findings")
+	}
+}
+
+func TestBaselineTracksEachSpace(t *testing.T) {
+	environments := checks.NewOctopusCheckResultImpl("Too many environments", "OctoLintTooManyEnvironments", "", checks.Warning, checks.Organization)
+	emptyProjects := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A")})
+	baseline := NewOctopusBaseline([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(environments, "Spaces-1", "Space A"),
+		checks.NewOctopusCheckSpaceResult(emptyProjects, "Spaces-2", "Space B"),
+	})
+
+	if baseline.Findings[0].Fingerprint != "Spaces-1/OctoLintTooManyEnvironments" || baseline.Findings[0].Space != "Space A" {
+		t.Fatal("Fingerprints must include the space ID")
+	}
+
+	timedOut := checks.NewOctopusCheckResultImpl("The check timed out", "OctoLintEmptyProject", "", checks.Error, checks.TimedOut)
+	results, resolved := baseline.Apply([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(environments, "Spaces-1", "Space A"),
+		checks.NewOctopusCheckSpaceResult(environments, "Spaces-2", "Space B"),
+		checks.NewOctopusCheckSpaceResult(emptyProjects, "Spaces-1", "Space A"),
+		checks.NewOctopusCheckSpaceResult(timedOut, "Spaces-2", "Space B"),
+	})
+
+	if results[0].Severity() != checks.Ok || results[1].Severity() != checks.Warning {
+		t.Fatal("Findings baselined in one space must still be reported in another space")
+	}
+
+	if len(results[2].Findings()) != 1 {
+		t.Fatal("Findings from another space must be reported as new")
+	}
+
+	if len(resolved) != 0 {
+		t.Fatal("Findings must not be resolved by a check that completed in another space")
+	}
+}
+
+func TestBaselineSurvivesSpaceRename(t *testing.T) {
+	emptyProjects := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{projectFinding("Projects-1", "Project A")})
+	baseline := NewOctopusBaseline([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(emptyProjects, "Spaces-1", "Space A"),
+	})
+
+	results, resolved := baseline.Apply([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(emptyProjects, "Spaces-1", "Renamed Space"),
+	})
+
+	if len(results) != 1 || results[0].Severity() != checks.Ok {
+		t.Fatal("Findings must still match the baseline after the space is renamed")
+	}
+
+	if len(resolved) != 0 {
+		t.Fatal("Findings must not be resolved by renaming the space")
+	}
+}
+
+func TestBaselineRejectsOldVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	if err := os.WriteFile(path, []byte(`{"version":1,"findings":[]}`), 0644); err != nil {
+		t.Fatal("Should have written the baseline")
+	}
+
+	if _, err := ReadOctopusBaseline(path); err == nil {
+		t.Fatal("Should have rejected a baseline with an unsupported version")
+	}
+}
@@ -1,32 +1,156 @@
 package factory
 
 import (
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"context"
+	"errors"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/naming"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/organization"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/performance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/rules"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/samber/lo"
 	"golang.org/x/exp/slices"
 	"strings"
 )
 
-// OctopusCheckFactory builds all the lint checks. This is where you can customize things like error handlers.
+// OctopusCheckFactory builds all the lint checks that are run against a space. This is where you can customize things like error handlers.
 type OctopusCheckFactory struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
-	url          string
-	space        string
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusCheckFactory(client *client.Client, url string, space string) OctopusCheckFactory {
-	return OctopusCheckFactory{client: client, url: url, space: space, errorHandler: checks.OctopusClientPermissiveErrorHandler{}}
+func NewOctopusCheckFactory(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, url string, space string) OctopusCheckFactory {
+	return OctopusCheckFactory{client: client, cache: cache, urlBuilder: checks.NewOctopusUrlBuilder(url, space), errorHandler: checks.OctopusClientPermissiveErrorHandler{}}
 }
 
 // BuildAllChecks creates new instances of all the checks and returns them as an array.
 func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]checks.OctopusCheck, error) {
+	allChecks := []checks.OctopusCheck{
+		security.NewOctopusUnrotatedAccountsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		security.NewOctopusDeploymentQueuedByAdminCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		security.NewOctopusDuplicatedGitCredentialsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		security.NewOctopusInsecureK8sCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		security.NewOctopusInsecureFeedsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		security.NewOctopusInsecureSubscriptionsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusEnvironmentCountCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusDefaultProjectGroupCountCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusEmptyProjectCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusUnusedVariablesCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusDuplicatedVariablesCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusProjectTooManyStepsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusLifecycleRetentionPolicyCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusUnusedTargetsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusProjectSpecificEnvironmentCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusTenantsInsteadOfTagsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusUnhealthyTargetCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusUnusedProjectsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		organization.NewOctopusUnusedTenantsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		performance.NewOctopusDeploymentQueuedTimeCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusProjectContainerImageRegex(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusInvalidVariableNameCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusInvalidTargetName(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusInvalidTargetRole(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusProjectReleaseTemplateRegex(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusProjectWorkerPoolRegex(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusInvalidLifecycleName(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusProjectDefaultStepNames(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+	}
+
+	ruleChecks, err := o.buildRuleChecks(config, allChecks, func(rule *rules.OctopusRule) bool { return true })
+
+	if err != nil {
+		return nil, err
+	}
+
+	allChecks = append(allChecks, ruleChecks...)
+
+	registeredChecks, err := o.buildRegisteredChecks(config, allChecks)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return filterChecks(config, append(allChecks, registeredChecks...)), nil
+}
+
+// BuildOclChecks creates the checks that only inspect deployment processes, runbooks, and variables. These checks can
+// be run against the OCL files of a version controlled project, as they do not read any other resources.
+func (o OctopusCheckFactory) BuildOclChecks(config *config.OctolintConfig) ([]checks.OctopusCheck, error) {
+	allChecks := []checks.OctopusCheck{
+		organization.NewOctopusUnusedVariablesCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusProjectContainerImageRegex(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusInvalidVariableNameCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		naming.NewOctopusProjectDefaultStepNames(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+	}
+
+	ruleChecks, err := o.buildRuleChecks(config, allChecks, (*rules.OctopusRule).InspectsOcl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return filterChecks(config, append(allChecks, ruleChecks...)), nil
+}
+
+// buildRuleChecks creates a check for each custom rule that is included. Rules can not reuse the ID of a built-in
+// check, as the ID is used to configure, skip, and baseline the check.
+func (o OctopusCheckFactory) buildRuleChecks(config *config.OctolintConfig, builtInChecks []checks.OctopusCheck, include func(rule *rules.OctopusRule) bool) ([]checks.OctopusCheck, error) {
+	allRules, err := rules.LoadOctopusRules(config.Rules)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ruleChecks := []checks.OctopusCheck{}
+	for _, rule := range allRules {
+		if lo.ContainsBy(builtInChecks, func(item checks.OctopusCheck) bool { return strings.EqualFold(item.Id(), rule.Id) }) {
+			return nil, errors.New("the rule " + rule.Id + " has the same ID as a built-in check")
+		}
+
+		if !include(rule) {
+			continue
+		}
+
+		ruleChecks = append(ruleChecks, rules.NewOctopusRuleCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler, rule))
+	}
+
+	return ruleChecks, nil
+}
+
+// buildRegisteredChecks creates the checks added with checks.RegisterCheck. Registered checks can not reuse the ID of
+// a built-in check or rule.
+func (o OctopusCheckFactory) buildRegisteredChecks(config *config.OctolintConfig, existingChecks []checks.OctopusCheck) ([]checks.OctopusCheck, error) {
+	registeredChecks, err := checks.RegisteredChecks(checks.OctopusCheckDependencies{
+		Client:       o.client,
+		Cache:        o.cache,
+		Config:       config,
+		UrlBuilder:   o.urlBuilder,
+		ErrorHandler: o.errorHandler,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, registeredCheck := range registeredChecks {
+		if lo.ContainsBy(existingChecks, func(item checks.OctopusCheck) bool { return strings.EqualFold(item.Id(), registeredCheck.Id()) }) {
+			return nil, errors.New("the registered check " + registeredCheck.Id() + " has the same ID as a built-in check or rule")
+		}
+	}
+
+	return registeredChecks, nil
+}
+
+// filterChecks removes the checks excluded by the skipTests and onlyTests arguments. A check that is enabled or
+// disabled in the checks section of the config file is included or removed regardless of these arguments. The
+// severity overrides in the checks section are applied to the remaining checks.
+func filterChecks(config *config.OctolintConfig, allChecks []checks.OctopusCheck) []checks.OctopusCheck {
 	skipChecksSlice := lo.FilterMap(strings.Split(config.SkipTests, ","), func(item string, index int) (string, bool) {
 		itemTrimmed := strings.TrimSpace(item)
 		return itemTrimmed, len(itemTrimmed) != 0
@@ -37,41 +161,43 @@ func (o OctopusCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]ch
 		return itemTrimmed, len(itemTrimmed) != 0
 	})
 
-	allChecks := []checks.OctopusCheck{
-		security.NewOctopusUnrotatedAccountsCheck(o.client, config, o.errorHandler),
-		security.NewOctopusDeploymentQueuedByAdminCheck(o.client, config, o.errorHandler),
-		security.NewOctopusPerpetualApiKeysCheck(o.client, config, o.errorHandler),
-		security.NewOctopusDuplicatedGitCredentialsCheck(o.client, config, o.errorHandler),
-		security.NewOctopusInsecureK8sCheck(o.client, config, o.errorHandler),
-		security.NewOctopusInsecureFeedsCheck(o.client, config, o.errorHandler),
-		security.NewOctopusInsecureSubscriptionsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusEnvironmentCountCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusDefaultProjectGroupCountCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusEmptyProjectCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedVariablesCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusDuplicatedVariablesCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusProjectTooManyStepsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusLifecycleRetentionPolicyCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedTargetsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusProjectSpecificEnvironmentCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusTenantsInsteadOfTagsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnhealthyTargetCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedProjectsCheck(o.client, config, o.errorHandler),
-		organization.NewOctopusUnusedTenantsCheck(o.client, config, o.errorHandler),
-		performance.NewOctopusDeploymentQueuedTimeCheck(o.client, config, o.url, o.space, o.errorHandler),
-		naming.NewOctopusProjectContainerImageRegex(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidVariableNameCheck(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidTargetName(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidTargetRole(o.client, config, o.errorHandler),
-		naming.NewOctopusProjectReleaseTemplateRegex(o.client, config, o.errorHandler),
-		naming.NewOctopusProjectWorkerPoolRegex(o.client, config, o.errorHandler),
-		naming.NewOctopusInvalidLifecycleName(o.client, config, o.errorHandler),
-		naming.NewOctopusProjectDefaultStepNames(o.client, config, o.errorHandler),
-	}
+	enabledChecks := lo.Filter(allChecks, func(item checks.OctopusCheck, index int) bool {
+		if enabled := config.CheckConfig(item.Id()).Enabled; enabled != nil {
+			return *enabled
+		}
 
-	return lo.Filter(allChecks, func(item checks.OctopusCheck, index int) bool {
 		return slices.Index(skipChecksSlice, item.Id()) == -1 &&
 			(len(onlyChecksSlice) == 0 || slices.Index(onlyChecksSlice, item.Id()) != -1)
-	}), nil
+	})
+
+	return lo.Map(enabledChecks, func(item checks.OctopusCheck, index int) checks.OctopusCheck {
+		if severity := config.CheckConfig(item.Id()).Severity; severity != "" {
+			// The severity is validated when the config file is read
+			severityLevel, _ := checks.StringToSeverity(severity)
+			return severityOverrideCheck{check: item, severity: severityLevel}
+		}
+
+		return item
+	})
+}
+
+// severityOverrideCheck replaces the severity of the results of a check that reports findings. Results that pass, or
+// that could not be checked because of permissions or errors, keep their severity.
+type severityOverrideCheck struct {
+	check    checks.OctopusCheck
+	severity int
+}
+
+func (o severityOverrideCheck) Id() string {
+	return o.check.Id()
+}
+
+func (o severityOverrideCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	result, err := o.check.Execute(ctx, concurrency)
+
+	if err != nil || result == nil || result.Severity() < checks.Info || checks.IsCheckFailure(result) {
+		return result, err
+	}
+
+	return checks.NewOctopusCheckSeverityResult(result, o.severity), nil
 }
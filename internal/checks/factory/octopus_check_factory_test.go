@@ -0,0 +1,252 @@
+package factory
+
+import (
+	"context"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/instance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func checkIds(allChecks []checks.OctopusCheck) []string {
+	return lo.Map(allChecks, func(item checks.OctopusCheck, index int) string {
+		return item.Id()
+	})
+}
+
+func TestInstanceChecksAreOnlyBuiltOnce(t *testing.T) {
+	spaceChecks, err := NewOctopusCheckFactory(nil, nil, "http://localhost", "Spaces-1").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instanceChecks, err := NewOctopusInstanceCheckFactory(nil, nil, "http://localhost").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lo.Intersect(checkIds(spaceChecks), checkIds(instanceChecks))) != 0 {
+		t.Fatal("a check must not be run for each space and for the instance")
+	}
+
+	if !lo.Contains(checkIds(instanceChecks), "OctoLintPerpetualApiKeys") {
+		t.Fatal("the perpetual API keys check scans all users, so it must be an instance check")
+	}
+}
+
+func TestInstanceChecksAreFiltered(t *testing.T) {
+	instanceChecks, err := NewOctopusInstanceCheckFactory(nil, nil, "http://localhost").BuildAllChecks(&config.OctolintConfig{
+		SkipTests: instance.OctoLintEmptyTeams,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lo.Contains(checkIds(instanceChecks), instance.OctoLintEmptyTeams) {
+		t.Fatal("skipped checks must not be built")
+	}
+
+	instanceChecks, err = NewOctopusInstanceCheckFactory(nil, nil, "http://localhost").BuildAllChecks(&config.OctolintConfig{
+		OnlyTests: " " + instance.OctoLintGuestAccountEnabled + ", " + security.OctoLintInsecureK8sTargets,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(instanceChecks) != 1 || instanceChecks[0].Id() != instance.OctoLintGuestAccountEnabled {
+		t.Fatal("only the listed checks must be built")
+	}
+}
+
+func TestSkipInstanceChecks(t *testing.T) {
+	instanceChecks, err := NewOctopusInstanceCheckFactory(nil, nil, "http://localhost").BuildAllChecks(&config.OctolintConfig{
+		SkipInstanceChecks: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(instanceChecks) != 0 {
+		t.Fatal("the instance checks must not be built when they are skipped")
+	}
+}
+
+func TestChecksEnabledInConfigFile(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	instanceChecks, err := NewOctopusInstanceCheckFactory(nil, nil, "http://localhost").BuildAllChecks(&config.OctolintConfig{
+		OnlyTests: instance.OctoLintGuestAccountEnabled + "," + instance.OctoLintEmptyTeams,
+		Checks: map[string]config.CheckConfig{
+			strings.ToLower(instance.OctoLintEmptyTeams):    {Enabled: &disabled},
+			strings.ToLower(instance.OctoLintInactiveUsers): {Enabled: &enabled},
+		},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := checkIds(instanceChecks)
+
+	if len(ids) != 2 || !lo.Contains(ids, instance.OctoLintGuestAccountEnabled) || !lo.Contains(ids, instance.OctoLintInactiveUsers) {
+		t.Fatalf("the checks section of the config file must take precedence over onlyTests, got %v", ids)
+	}
+}
+
+type fixedResultCheck struct {
+	result checks.OctopusCheckResult
+}
+
+func (o fixedResultCheck) Id() string {
+	return o.result.Code()
+}
+
+func (o fixedResultCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	return o.result, nil
+}
+
+func TestSeverityOverride(t *testing.T) {
+	octolintConfig := &config.OctolintConfig{
+		Checks: map[string]config.CheckConfig{
+			"failed":     {Severity: checks.ErrorSeverityName},
+			"passed":     {Severity: checks.ErrorSeverityName},
+			"permission": {Severity: checks.ErrorSeverityName},
+		},
+	}
+
+	allChecks := filterChecks(octolintConfig, []checks.OctopusCheck{
+		fixedResultCheck{result: checks.NewOctopusCheckResultImpl("Failed", "Failed", "", checks.Warning, checks.Organization)},
+		fixedResultCheck{result: checks.NewOctopusCheckResultImpl("Passed", "Passed", "", checks.Ok, checks.Organization)},
+		fixedResultCheck{result: checks.NewOctopusCheckResultImpl("Permission", "Permission", "", checks.Permission, checks.Organization)},
+		fixedResultCheck{result: checks.NewOctopusCheckResultImpl("Other", "Other", "", checks.Warning, checks.Organization)},
+	})
+
+	expected := []int{checks.Error, checks.Ok, checks.Permission, checks.Warning}
+
+	for i, check := range allChecks {
+		result, err := check.Execute(context.Background(), 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Severity() != expected[i] {
+			t.Fatalf("the check %s should have a severity of %d, got %d", check.Id(), expected[i], result.Severity())
+		}
+	}
+}
+
+func TestRulesAreBuilt(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	rules := "rules:\n  - id: MyProjectRule\n    resource: project\n    condition: resource.Description != \"\"\n" +
+		"  - id: MyTenantRule\n    resource: tenant\n    condition: resource.Description != \"\"\n" +
+		"  - id: MyLifecycleRule\n    resource: project\n    condition: lifecycle.Name != \"\"\n"
+
+	if err := os.WriteFile(rulesFile, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	spaceChecks, err := NewOctopusCheckFactory(nil, nil, "http://localhost", "Spaces-1").BuildAllChecks(&config.OctolintConfig{
+		Rules: config.StringSliceArgs{rulesFile},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !lo.Contains(checkIds(spaceChecks), "MyProjectRule") || !lo.Contains(checkIds(spaceChecks), "MyTenantRule") {
+		t.Fatal("rules must be built as checks")
+	}
+
+	oclChecks, err := NewOctopusCheckFactory(nil, nil, "", "").BuildOclChecks(&config.OctolintConfig{
+		Rules: config.StringSliceArgs{rulesFile},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !lo.Contains(checkIds(oclChecks), "MyProjectRule") || lo.Contains(checkIds(oclChecks), "MyTenantRule") || lo.Contains(checkIds(oclChecks), "MyLifecycleRule") {
+		t.Fatal("only rules that read the resources in the OCL files can be run against OCL files")
+	}
+}
+
+func TestRulesCanNotReplaceBuiltInChecks(t *testing.T) {
+	rulesFile := filepath.Join(t.TempDir(), "rules.yaml")
+	rules := "rules:\n  - id: " + security.OctoLintInsecureK8sTargets + "\n    resource: target\n    condition: \"true\"\n"
+
+	if err := os.WriteFile(rulesFile, []byte(rules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewOctopusCheckFactory(nil, nil, "http://localhost", "Spaces-1").BuildAllChecks(&config.OctolintConfig{
+		Rules: config.StringSliceArgs{rulesFile},
+	})
+
+	if err == nil {
+		t.Fatal("a rule must not have the same ID as a built-in check")
+	}
+}
+
+func TestRegisteredChecksAreBuilt(t *testing.T) {
+	err := checks.RegisterCheck("MyRegisteredCheck", func(dependencies checks.OctopusCheckDependencies) checks.OctopusCheck {
+		return fixedResultCheck{result: checks.NewOctopusCheckResultImpl("Passed", "MyRegisteredCheck", "", checks.Ok, checks.Organization)}
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer checks.UnregisterCheck("MyRegisteredCheck")
+
+	spaceChecks, err := NewOctopusCheckFactory(nil, nil, "http://localhost", "Spaces-1").BuildAllChecks(&config.OctolintConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !lo.Contains(checkIds(spaceChecks), "MyRegisteredCheck") {
+		t.Fatal("registered checks must be built")
+	}
+
+	spaceChecks, err = NewOctopusCheckFactory(nil, nil, "http://localhost", "Spaces-1").BuildAllChecks(&config.OctolintConfig{
+		SkipTests: "MyRegisteredCheck",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lo.Contains(checkIds(spaceChecks), "MyRegisteredCheck") {
+		t.Fatal("registered checks must be filtered like the built-in checks")
+	}
+}
+
+func TestRegisteredChecksCanNotReplaceBuiltInChecks(t *testing.T) {
+	err := checks.RegisterCheck(security.OctoLintInsecureK8sTargets, func(dependencies checks.OctopusCheckDependencies) checks.OctopusCheck {
+		return fixedResultCheck{result: checks.NewOctopusCheckResultImpl("Passed", security.OctoLintInsecureK8sTargets, "", checks.Ok, checks.Security)}
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer checks.UnregisterCheck(security.OctoLintInsecureK8sTargets)
+
+	_, err = NewOctopusCheckFactory(nil, nil, "http://localhost", "Spaces-1").BuildAllChecks(&config.OctolintConfig{})
+
+	if err == nil {
+		t.Fatal("a registered check must not have the same ID as a built-in check")
+	}
+}
@@ -0,0 +1,44 @@
+package factory
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/instance"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/security"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// OctopusInstanceCheckFactory builds the lint checks that inspect the server rather than a space. These checks are
+// run once, no matter how many spaces are checked, and must be passed a client that is not scoped to a space.
+type OctopusInstanceCheckFactory struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusInstanceCheckFactory(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, url string) OctopusInstanceCheckFactory {
+	return OctopusInstanceCheckFactory{client: client, cache: cache, urlBuilder: checks.NewOctopusUrlBuilder(url, ""), errorHandler: checks.OctopusClientPermissiveErrorHandler{}}
+}
+
+// BuildAllChecks creates new instances of all the instance checks and returns them as an array. No checks are returned
+// if the instance checks are skipped.
+func (o OctopusInstanceCheckFactory) BuildAllChecks(config *config.OctolintConfig) ([]checks.OctopusCheck, error) {
+	if config.SkipInstanceChecks {
+		return []checks.OctopusCheck{}, nil
+	}
+
+	allChecks := []checks.OctopusCheck{
+		security.NewOctopusPerpetualApiKeysCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusGuestAccountCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusAuthenticationProvidersCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusEmptyTeamsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusUnusedUserRolesCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusInactiveUsersCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusLicenseLimitsCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusTaskCapCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+		instance.NewOctopusServerRetentionCheck(o.client, o.cache, config, o.urlBuilder, o.errorHandler),
+	}
+
+	return filterChecks(config, allChecks), nil
+}
@@ -0,0 +1,119 @@
+package instance
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// errPermission is the error returned by the Octopus API when the API key can not read the server configuration.
+var errPermission = &core.APIError{StatusCode: http.StatusForbidden, ErrorMessage: "You do not have permission to perform this action"}
+
+// fakeDataSource returns fixed server wide resources. Methods not implemented here panic, as the embedded interface
+// is nil.
+type fakeDataSource struct {
+	client_wrapper.OctopusDataSource
+	// err is returned by every method, like an API key without permission to read the server configuration
+	err error
+	// eventsErr is returned when reading the login events
+	eventsErr error
+	now       time.Time
+
+	users                 []*users.User
+	logins                map[string][]*events.Event
+	licenseStatus         *client_wrapper.LicenseStatus
+	serverNodes           []*octopusservernodes.OctopusServerNodeResource
+	queuedTasks           int
+	configurationSections []*configuration.ConfigurationSection
+	configurationValues   map[string]map[string]any
+	userRoles             []*userroles.UserRole
+	teams                 []*teams.Team
+	scopedUserRoles       map[string][]*userroles.ScopedUserRole
+	authentication        *authentication.Authentication
+}
+
+func (f *fakeDataSource) GetSpaceID() string {
+	return ""
+}
+
+func (f *fakeDataSource) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeDataSource) GetUsers(ctx context.Context) ([]*users.User, error) {
+	return f.users, f.err
+}
+
+func (f *fakeDataSource) GetEvents(ctx context.Context, query events.EventsQuery) (*resources.Resources[*events.Event], error) {
+	if f.eventsErr != nil {
+		return nil, f.eventsErr
+	}
+
+	logins := f.logins[query.Users[0]]
+	return &resources.Resources[*events.Event]{Items: logins, PagedResults: resources.PagedResults{TotalResults: len(logins)}}, f.err
+}
+
+func (f *fakeDataSource) GetLicenseStatus(ctx context.Context) (*client_wrapper.LicenseStatus, error) {
+	return f.licenseStatus, f.err
+}
+
+func (f *fakeDataSource) GetServerNodes(ctx context.Context) ([]*octopusservernodes.OctopusServerNodeResource, error) {
+	return f.serverNodes, f.err
+}
+
+func (f *fakeDataSource) GetTasks(ctx context.Context, query tasks.TasksQuery) (*resources.Resources[*tasks.Task], error) {
+	return &resources.Resources[*tasks.Task]{PagedResults: resources.PagedResults{TotalResults: f.queuedTasks}}, f.err
+}
+
+func (f *fakeDataSource) GetConfigurationSections(ctx context.Context) ([]*configuration.ConfigurationSection, error) {
+	return f.configurationSections, f.err
+}
+
+func (f *fakeDataSource) GetConfigurationValues(ctx context.Context, section *configuration.ConfigurationSection) (map[string]any, error) {
+	return f.configurationValues[section.ID], f.err
+}
+
+func (f *fakeDataSource) GetUserRoles(ctx context.Context) ([]*userroles.UserRole, error) {
+	return f.userRoles, f.err
+}
+
+func (f *fakeDataSource) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	return f.teams, f.err
+}
+
+func (f *fakeDataSource) GetTeamScopedUserRoles(ctx context.Context, team *teams.Team) ([]*userroles.ScopedUserRole, error) {
+	return f.scopedUserRoles[team.ID], f.err
+}
+
+func (f *fakeDataSource) GetAuthentication(ctx context.Context) (*authentication.Authentication, error) {
+	return f.authentication, f.err
+}
+
+func executeCheck(t *testing.T, check checks.OctopusCheck) checks.OctopusCheckResult {
+	result, err := check.Execute(context.Background(), 2)
+
+	if err != nil {
+		t.Fatalf("Check should not have returned an error: %v", err)
+	}
+
+	return result
+}
+
+func assertPermissionResult(t *testing.T, result checks.OctopusCheckResult) {
+	if result.Severity() != checks.Permission {
+		t.Fatalf("Check should have reported a permission error, got %s", result.Description())
+	}
+}
@@ -0,0 +1,86 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+	"strings"
+)
+
+const OctoLintAuthenticationProviders = "OctoLintAuthenticationProviders"
+
+// OctopusAuthenticationProvidersCheck reports when the server only allows users to log in with a username and
+// password, rather than an external identity provider
+type OctopusAuthenticationProvidersCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusAuthenticationProvidersCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusAuthenticationProvidersCheck {
+	return OctopusAuthenticationProvidersCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusAuthenticationProvidersCheck) Id() string {
+	return OctoLintAuthenticationProviders
+}
+
+func (o OctopusAuthenticationProvidersCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	authentication, err := o.client.GetAuthentication(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	localProviders := []checks.OctopusCheckFinding{}
+	externalProviders := 0
+	for _, provider := range authentication.AuthenticationProviders {
+		if isLocalAuthenticationProvider(provider.Name, provider.IdentityType) {
+			localProviders = append(localProviders, checks.OctopusCheckFinding{
+				ResourceType: checks.AuthenticationResource,
+				ResourceName: provider.Name,
+			})
+		} else {
+			externalProviders++
+		}
+	}
+
+	if externalProviders == 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"No external authentication providers are enabled. Consider using an identity provider like Microsoft Entra ID, Okta, or Google instead of the following providers:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Instance,
+			localProviders), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"An external authentication provider is enabled",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
+
+// isLocalAuthenticationProvider returns true for the providers that are managed by the Octopus server itself.
+func isLocalAuthenticationProvider(name string, identityType string) bool {
+	return identityType == "UsernamePassword" ||
+		strings.EqualFold(name, "Username-Password") ||
+		strings.EqualFold(name, "Guest")
+}
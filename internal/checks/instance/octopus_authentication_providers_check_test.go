@@ -0,0 +1,50 @@
+package instance
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+func newAuthenticationProvidersCheck(dataSource *fakeDataSource) OctopusAuthenticationProvidersCheck {
+	return NewOctopusAuthenticationProvidersCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder("https://example.octopus.app", ""), checks.OctopusClientPermissiveErrorHandler{})
+}
+
+func TestOnlyLocalAuthenticationProviders(t *testing.T) {
+	result := executeCheck(t, newAuthenticationProvidersCheck(&fakeDataSource{
+		authentication: &authentication.Authentication{
+			AuthenticationProviders: []*authentication.AuthenticationProviderElement{
+				{Name: "Username-Password", IdentityType: "UsernamePassword"},
+			},
+		},
+	}))
+
+	if result.Severity() != checks.Warning {
+		t.Fatalf("Check should have returned a warning, got %s", result.Description())
+	}
+
+	if len(result.Findings()) != 1 || result.Findings()[0].ResourceName != "Username-Password" {
+		t.Fatalf("Check should have reported the local provider, got %s", result.Description())
+	}
+}
+
+func TestExternalAuthenticationProvider(t *testing.T) {
+	result := executeCheck(t, newAuthenticationProvidersCheck(&fakeDataSource{
+		authentication: &authentication.Authentication{
+			AuthenticationProviders: []*authentication.AuthenticationProviderElement{
+				{Name: "Username-Password", IdentityType: "UsernamePassword"},
+				{Name: "Azure AD", IdentityType: "OAuth"},
+			},
+		},
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Check should have succeeded, got %s", result.Description())
+	}
+}
+
+func TestAuthenticationProvidersPermission(t *testing.T) {
+	assertPermissionResult(t, executeCheck(t, newAuthenticationProvidersCheck(&fakeDataSource{err: errPermission})))
+}
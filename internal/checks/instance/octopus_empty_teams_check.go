@@ -0,0 +1,82 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+)
+
+const OctoLintEmptyTeams = "OctoLintEmptyTeams"
+
+// OctopusEmptyTeamsCheck finds teams with no members and no external security groups
+type OctopusEmptyTeamsCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusEmptyTeamsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusEmptyTeamsCheck {
+	return OctopusEmptyTeamsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusEmptyTeamsCheck) Id() string {
+	return OctoLintEmptyTeams
+}
+
+func (o OctopusEmptyTeamsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	teams, err := o.client.GetTeams(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	emptyTeams := []checks.OctopusCheckFinding{}
+	for _, team := range teams {
+		// Built in teams, like "Everyone", can not be deleted and are expected to have no explicit members
+		if !team.CanBeDeleted {
+			continue
+		}
+
+		if len(team.MemberUserIDs) == 0 && len(team.ExternalSecurityGroups) == 0 {
+			emptyTeams = append(emptyTeams, checks.OctopusCheckFinding{
+				ResourceType: checks.TeamResource,
+				ResourceId:   team.ID,
+				ResourceName: team.Name,
+				SpaceId:      team.SpaceID,
+				Link:         o.urlBuilder.TeamUrl(team.ID),
+			})
+		}
+	}
+
+	if len(emptyTeams) != 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following teams have no members or external security groups:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Instance,
+			emptyTeams), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are no empty teams",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
@@ -0,0 +1,53 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
+	"testing"
+)
+
+func TestEmptyTeams(t *testing.T) {
+	testFramework := test.OctopusContainerTest{}
+	testFramework.ArrangeTest(t, func(t *testing.T, container *test.OctopusContainer, client *client.Client) error {
+		// Arrange
+		instanceClient, err := octoclient.CreateClient(container.URI, "", test.ApiKey)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = instanceClient.Teams.Add(teams.NewTeam("Empty Team"))
+
+		if err != nil {
+			return err
+		}
+
+		// Act
+		dataSource := client_wrapper.NewLiveDataSource(instanceClient, "")
+		check := NewOctopusEmptyTeamsCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, ""), checks.OctopusClientPermissiveErrorHandler{})
+
+		result, err := check.Execute(context.Background(), 2)
+
+		if err != nil {
+			return err
+		}
+
+		// Assert
+		if result.Severity() != checks.Warning {
+			return errors.New("Check should have returned a warning")
+		}
+
+		if len(result.Findings()) != 1 || result.Findings()[0].ResourceName != "Empty Team" {
+			return errors.New("Check should have reported the empty team")
+		}
+
+		return nil
+	})
+}
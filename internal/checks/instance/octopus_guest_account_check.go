@@ -0,0 +1,74 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+)
+
+const OctoLintGuestAccountEnabled = "OctoLintGuestAccountEnabled"
+
+// guestUsername is the username of the built in guest account
+const guestUsername = "guest"
+
+// OctopusGuestAccountCheck reports when the built in guest account is enabled
+type OctopusGuestAccountCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusGuestAccountCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusGuestAccountCheck {
+	return OctopusGuestAccountCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusGuestAccountCheck) Id() string {
+	return OctoLintGuestAccountEnabled
+}
+
+func (o OctopusGuestAccountCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	users, err := o.client.GetUsers(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	for _, user := range users {
+		if user.Username == guestUsername && user.IsActive {
+			return checks.NewOctopusCheckResultWithFindings(
+				"The guest account is enabled, allowing anyone who can reach the server to log in:",
+				o.Id(),
+				checks.WikiLink(o.Id()),
+				checks.Warning,
+				checks.Instance,
+				[]checks.OctopusCheckFinding{{
+					ResourceType: checks.UserResource,
+					ResourceId:   user.ID,
+					ResourceName: user.Username,
+					Link:         o.urlBuilder.UserUrl(user.ID),
+				}}), nil
+		}
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"The guest account is not enabled",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
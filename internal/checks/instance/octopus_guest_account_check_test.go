@@ -0,0 +1,42 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
+	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
+	"testing"
+)
+
+func TestGuestAccountDisabled(t *testing.T) {
+	testFramework := test.OctopusContainerTest{}
+	testFramework.ArrangeTest(t, func(t *testing.T, container *test.OctopusContainer, client *client.Client) error {
+		// Arrange
+		instanceClient, err := octoclient.CreateClient(container.URI, "", test.ApiKey)
+
+		if err != nil {
+			return err
+		}
+
+		// Act
+		dataSource := client_wrapper.NewLiveDataSource(instanceClient, "")
+		check := NewOctopusGuestAccountCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, ""), checks.OctopusClientPermissiveErrorHandler{})
+
+		result, err := check.Execute(context.Background(), 2)
+
+		if err != nil {
+			return err
+		}
+
+		// Assert: the guest account is disabled by default
+		if result.Severity() != checks.Ok {
+			return errors.New("Check should have succeeded")
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/hayageek/threadsafe"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"time"
+)
+
+const OctoLintInactiveUsers = "OctoLintInactiveUsers"
+
+// OctopusInactiveUsersCheck finds active users that have never logged in, or have not logged in recently. Service
+// accounts are ignored, as they do not log in. Note that login events older than the server's event retention
+// period have been deleted, so users that last logged in before then are reported as never having logged in.
+type OctopusInactiveUsersCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusInactiveUsersCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInactiveUsersCheck {
+	return OctopusInactiveUsersCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusInactiveUsersCheck) Id() string {
+	return OctoLintInactiveUsers
+}
+
+func (o OctopusInactiveUsersCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	maxDaysSinceLastLogin := o.config.CheckInt(o.Id(), "maxDaysSinceLastLogin", o.config.MaxDaysSinceLastLogin)
+
+	users, err := o.client.GetUsers(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	inactiveUsers := threadsafe.NewSlice[checks.OctopusCheckFinding]()
+	goroutineErrors := threadsafe.NewSlice[error]()
+	cutoff := o.client.Now().Add(-time.Hour * 24 * time.Duration(maxDaysSinceLastLogin))
+
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(users))
+
+	for _, user := range users {
+		user := user
+
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
+
+			// Disabled users can't log in, and the guest account is reported by its own check
+			if !user.IsActive || user.IsService || user.Username == guestUsername {
+				return nil
+			}
+
+			logins, err := o.client.GetEvents(ctx, events.EventsQuery{
+				EventCategories: []string{"LoginSucceeded"},
+				Users:           []string{user.ID},
+				IncludeSystem:   true,
+				Take:            1,
+			})
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				}
+				return nil
+			}
+
+			if len(logins.Items) == 0 {
+				inactiveUsers.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.UserResource,
+					ResourceId:   user.ID,
+					ResourceName: user.Username,
+					Link:         o.urlBuilder.UserUrl(user.ID),
+					Details:      "never logged in",
+				})
+			} else if logins.Items[0].Occurred.Before(cutoff) {
+				inactiveUsers.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.UserResource,
+					ResourceId:   user.ID,
+					ResourceName: user.Username,
+					Link:         o.urlBuilder.UserUrl(user.ID),
+					Details:      "last logged in " + logins.Items[0].Occurred.Format(time.DateOnly),
+				})
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Treat the first error as the root cause
+	if goroutineErrors.Length() > 0 {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, goroutineErrors.Values()[0])
+	}
+
+	daysString := fmt.Sprintf("%d", maxDaysSinceLastLogin)
+
+	if inactiveUsers.Length() > 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following users have not logged in during the last "+daysString+" days:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Instance,
+			inactiveUsers.Values()), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"All users have logged in during the last "+daysString+" days",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
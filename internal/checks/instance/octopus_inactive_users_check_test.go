@@ -0,0 +1,83 @@
+package instance
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"golang.org/x/exp/slices"
+	"testing"
+	"time"
+)
+
+func newUser(id string, username string) *users.User {
+	user := users.NewUser(username, username)
+	user.ID = id
+	return user
+}
+
+func newLogin(occurred time.Time) *events.Event {
+	return &events.Event{Category: "LoginSucceeded", Occurred: occurred}
+}
+
+func newInactiveUsersCheck(dataSource *fakeDataSource) OctopusInactiveUsersCheck {
+	return NewOctopusInactiveUsersCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{MaxDaysSinceLastLogin: 90}, checks.NewOctopusUrlBuilder("https://example.octopus.app", ""), checks.OctopusClientPermissiveErrorHandler{})
+}
+
+func TestInactiveUsers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	service := newUser("Users-4", "deployer")
+	service.IsService = true
+
+	result := executeCheck(t, newInactiveUsersCheck(&fakeDataSource{
+		now:   now,
+		users: []*users.User{newUser("Users-1", "active"), newUser("Users-2", "stale"), newUser("Users-3", "new"), service},
+		logins: map[string][]*events.Event{
+			"Users-1": {newLogin(now.AddDate(0, 0, -1))},
+			"Users-2": {newLogin(now.AddDate(0, 0, -91))},
+		},
+	}))
+
+	if result.Severity() != checks.Warning {
+		t.Fatalf("Check should have returned a warning, got %s", result.Description())
+	}
+
+	names := []string{}
+	for _, finding := range result.Findings() {
+		names = append(names, finding.ResourceName)
+	}
+
+	if len(names) != 2 || !slices.Contains(names, "new") || !slices.Contains(names, "stale") {
+		t.Fatalf("Check should have reported the users that never or not recently logged in, got %s", result.Description())
+	}
+}
+
+func TestNoInactiveUsers(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	result := executeCheck(t, newInactiveUsersCheck(&fakeDataSource{
+		now:    now,
+		users:  []*users.User{newUser("Users-1", "active")},
+		logins: map[string][]*events.Event{"Users-1": {newLogin(now.AddDate(0, 0, -1))}},
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Check should have succeeded, got %s", result.Description())
+	}
+}
+
+func TestInactiveUsersPermission(t *testing.T) {
+	assertPermissionResult(t, executeCheck(t, newInactiveUsersCheck(&fakeDataSource{err: errPermission})))
+}
+
+func TestInactiveUsersEventsPermission(t *testing.T) {
+	result := executeCheck(t, newInactiveUsersCheck(&fakeDataSource{
+		users:     []*users.User{newUser("Users-1", "active")},
+		eventsErr: errPermission,
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Users whose login events can not be read should be skipped, got %s", result.Description())
+	}
+}
@@ -0,0 +1,93 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+)
+
+const OctoLintLicenseLimits = "OctoLintLicenseLimits"
+
+// licenseUsageWarningPercent is the percentage of a license limit that can be used before it is reported
+const licenseUsageWarningPercent = 90
+
+// OctopusLicenseLimitsCheck reports when the server is not compliant with its license, or is close to a license limit
+type OctopusLicenseLimitsCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusLicenseLimitsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusLicenseLimitsCheck {
+	return OctopusLicenseLimitsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusLicenseLimitsCheck) Id() string {
+	return OctoLintLicenseLimits
+}
+
+func (o OctopusLicenseLimitsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	status, err := o.client.GetLicenseStatus(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	limits := []checks.OctopusCheckFinding{}
+	for _, limit := range status.Limits {
+		if limit.IsUnlimited || limit.EffectiveValue <= 0 {
+			continue
+		}
+
+		if limit.CurrentUsage*100 >= limit.EffectiveValue*licenseUsageWarningPercent {
+			limits = append(limits, checks.OctopusCheckFinding{
+				ResourceType: checks.LicenseResource,
+				ResourceName: limit.Name,
+				Details:      fmt.Sprint(limit.CurrentUsage) + " of " + fmt.Sprint(limit.EffectiveValue) + " used",
+			})
+		}
+	}
+
+	if !status.IsCompliant {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The server is not compliant with its license: "+status.ComplianceSummary,
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Error,
+			checks.Instance,
+			limits), nil
+	}
+
+	if len(limits) != 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following license limits are at least "+fmt.Sprint(licenseUsageWarningPercent)+"% used:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Instance,
+			limits), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"No license limits are close to being reached",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
@@ -0,0 +1,60 @@
+package instance
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+func newLicenseLimitsCheck(dataSource *fakeDataSource) OctopusLicenseLimitsCheck {
+	return NewOctopusLicenseLimitsCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder("https://example.octopus.app", ""), checks.OctopusClientPermissiveErrorHandler{})
+}
+
+func TestLicenseLimits(t *testing.T) {
+	result := executeCheck(t, newLicenseLimitsCheck(&fakeDataSource{
+		licenseStatus: &client_wrapper.LicenseStatus{
+			IsCompliant: true,
+			Limits: []client_wrapper.LicenseLimit{
+				{Name: "Projects", EffectiveValue: 100, CurrentUsage: 95},
+				{Name: "Tenants", EffectiveValue: 100, CurrentUsage: 10},
+				{Name: "Machines", IsUnlimited: true, CurrentUsage: 1000},
+			},
+		},
+	}))
+
+	if result.Severity() != checks.Warning {
+		t.Fatalf("Check should have returned a warning, got %s", result.Description())
+	}
+
+	if len(result.Findings()) != 1 || result.Findings()[0].ResourceName != "Projects" {
+		t.Fatalf("Check should have reported the limit that is almost used up, got %s", result.Description())
+	}
+}
+
+func TestLicenseNotCompliant(t *testing.T) {
+	result := executeCheck(t, newLicenseLimitsCheck(&fakeDataSource{
+		licenseStatus: &client_wrapper.LicenseStatus{IsCompliant: false, ComplianceSummary: "Too many projects"},
+	}))
+
+	if result.Severity() != checks.Error {
+		t.Fatalf("Check should have returned an error, got %s", result.Description())
+	}
+}
+
+func TestLicenseLimitsNotReached(t *testing.T) {
+	result := executeCheck(t, newLicenseLimitsCheck(&fakeDataSource{
+		licenseStatus: &client_wrapper.LicenseStatus{
+			IsCompliant: true,
+			Limits:      []client_wrapper.LicenseLimit{{Name: "Projects", EffectiveValue: 100, CurrentUsage: 10}},
+		},
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Check should have succeeded, got %s", result.Description())
+	}
+}
+
+func TestLicenseLimitsPermission(t *testing.T) {
+	assertPermissionResult(t, executeCheck(t, newLicenseLimitsCheck(&fakeDataSource{err: errPermission})))
+}
@@ -0,0 +1,123 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"strings"
+)
+
+const OctoLintServerRetention = "OctoLintServerRetention"
+
+// maxServerRetentionDays is the longest server wide retention period that is not reported
+const maxServerRetentionDays = 365
+
+// OctopusServerRetentionCheck finds server wide retention settings, like the event retention, that keep records for
+// a long time. Long retention periods grow the database and slow down the server. The configuration sections are
+// found by name, as the retention settings vary between Octopus versions.
+type OctopusServerRetentionCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusServerRetentionCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusServerRetentionCheck {
+	return OctopusServerRetentionCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusServerRetentionCheck) Id() string {
+	return OctoLintServerRetention
+}
+
+func (o OctopusServerRetentionCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	sections, err := o.client.GetConfigurationSections(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	retentionSections := []*configuration.ConfigurationSection{}
+	for _, section := range sections {
+		if strings.Contains(strings.ToLower(section.ID), "retention") && section.Links["Values"] != "" {
+			retentionSections = append(retentionSections, section)
+		}
+	}
+
+	longRetention := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(retentionSections))
+
+	for _, section := range retentionSections {
+		progress.Complete(1)
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		values, err := o.client.GetConfigurationValues(ctx, section)
+
+		if err != nil {
+			if !o.errorHandler.ShouldContinue(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		// Sort the settings so the findings are consistent between runs
+		settings := maps.Keys(values)
+		slices.Sort(settings)
+
+		for _, setting := range settings {
+			days, ok := values[setting].(float64)
+
+			if !ok || !strings.HasSuffix(strings.ToLower(setting), "days") {
+				continue
+			}
+
+			if days > maxServerRetentionDays {
+				longRetention = append(longRetention, checks.OctopusCheckFinding{
+					ResourceType: checks.ConfigurationResource,
+					ResourceId:   section.ID,
+					ResourceName: section.Name,
+					Details:      setting + " is " + fmt.Sprint(days),
+				})
+			}
+		}
+	}
+
+	if len(longRetention) != 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following server retention settings keep records for more than "+fmt.Sprint(maxServerRetentionDays)+" days:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Instance,
+			longRetention), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"No server retention settings keep records for more than "+fmt.Sprint(maxServerRetentionDays)+" days",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
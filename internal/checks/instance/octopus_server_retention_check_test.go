@@ -0,0 +1,56 @@
+package instance
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+func newConfigurationSection(id string) *configuration.ConfigurationSection {
+	section := configuration.NewConfigurationSection()
+	section.ID = id
+	section.Name = id
+	section.Links = map[string]string{"Values": "/api/configuration/" + id + "/values"}
+	return section
+}
+
+func newServerRetentionCheck(dataSource *fakeDataSource) OctopusServerRetentionCheck {
+	return NewOctopusServerRetentionCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder("https://example.octopus.app", ""), checks.OctopusClientPermissiveErrorHandler{})
+}
+
+func TestServerRetention(t *testing.T) {
+	result := executeCheck(t, newServerRetentionCheck(&fakeDataSource{
+		configurationSections: []*configuration.ConfigurationSection{newConfigurationSection("event-retention"), newConfigurationSection("smtp")},
+		configurationValues: map[string]map[string]any{
+			"event-retention": {"EventRetentionDays": float64(1000), "Enabled": true},
+			"smtp":            {"TimeoutDays": float64(1000)},
+		},
+	}))
+
+	if result.Severity() != checks.Warning {
+		t.Fatalf("Check should have returned a warning, got %s", result.Description())
+	}
+
+	if len(result.Findings()) != 1 || result.Findings()[0].ResourceId != "event-retention" {
+		t.Fatalf("Check should have reported the long retention setting, got %s", result.Description())
+	}
+}
+
+func TestServerRetentionShort(t *testing.T) {
+	result := executeCheck(t, newServerRetentionCheck(&fakeDataSource{
+		configurationSections: []*configuration.ConfigurationSection{newConfigurationSection("event-retention")},
+		configurationValues: map[string]map[string]any{
+			"event-retention": {"EventRetentionDays": float64(90)},
+		},
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Check should have succeeded, got %s", result.Description())
+	}
+}
+
+func TestServerRetentionPermission(t *testing.T) {
+	assertPermissionResult(t, executeCheck(t, newServerRetentionCheck(&fakeDataSource{err: errPermission})))
+}
@@ -0,0 +1,94 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+)
+
+const OctoLintTaskCap = "OctoLintTaskCap"
+
+// OctopusTaskCapCheck compares the number of queued tasks to the number of tasks the server nodes can run at once.
+// A queue larger than the task cap means tasks are waiting for a free slot.
+type OctopusTaskCapCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusTaskCapCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusTaskCapCheck {
+	return OctopusTaskCapCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusTaskCapCheck) Id() string {
+	return OctoLintTaskCap
+}
+
+func (o OctopusTaskCapCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	nodes, err := o.client.GetServerNodes(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Performance, err)
+	}
+
+	taskCap := 0
+	nodeFindings := []checks.OctopusCheckFinding{}
+	for _, node := range nodes {
+		// Nodes in maintenance mode do not pick up new tasks
+		if node.IsInMaintenanceMode {
+			continue
+		}
+
+		taskCap += int(node.MaxConcurrentTasks)
+		nodeFindings = append(nodeFindings, checks.OctopusCheckFinding{
+			ResourceType: checks.ServerNodeResource,
+			ResourceId:   node.ID,
+			ResourceName: node.Name,
+			Details:      "task cap of " + fmt.Sprint(node.MaxConcurrentTasks),
+		})
+	}
+
+	queuedTasks, err := o.client.GetTasks(ctx, tasks.TasksQuery{
+		States: []string{"Queued"},
+		Take:   1,
+	})
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Performance, err)
+	}
+
+	if queuedTasks.TotalResults > taskCap {
+		return checks.NewOctopusCheckResultWithFindings(
+			"There are "+fmt.Sprint(queuedTasks.TotalResults)+" queued tasks, which is more than the combined task cap of "+
+				fmt.Sprint(taskCap)+" for the following nodes. Consider increasing the task cap or adding nodes:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Performance,
+			nodeFindings), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are "+fmt.Sprint(queuedTasks.TotalResults)+" queued tasks and a combined task cap of "+fmt.Sprint(taskCap),
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Performance), nil
+}
@@ -0,0 +1,52 @@
+package instance
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+func newServerNode(id string, maxConcurrentTasks int32, maintenance bool) *octopusservernodes.OctopusServerNodeResource {
+	node := octopusservernodes.NewOctopusServerNodeResource()
+	node.ID = id
+	node.Name = id
+	node.MaxConcurrentTasks = maxConcurrentTasks
+	node.IsInMaintenanceMode = maintenance
+	return node
+}
+
+func newTaskCapCheck(dataSource *fakeDataSource) OctopusTaskCapCheck {
+	return NewOctopusTaskCapCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder("https://example.octopus.app", ""), checks.OctopusClientPermissiveErrorHandler{})
+}
+
+func TestTaskCapExceeded(t *testing.T) {
+	result := executeCheck(t, newTaskCapCheck(&fakeDataSource{
+		serverNodes: []*octopusservernodes.OctopusServerNodeResource{newServerNode("OctopusServerNodes-1", 5, false), newServerNode("OctopusServerNodes-2", 5, true)},
+		queuedTasks: 6,
+	}))
+
+	if result.Severity() != checks.Warning {
+		t.Fatalf("Check should have returned a warning, got %s", result.Description())
+	}
+
+	if len(result.Findings()) != 1 || result.Findings()[0].ResourceId != "OctopusServerNodes-1" {
+		t.Fatalf("Check should have reported the nodes that are not in maintenance mode, got %s", result.Description())
+	}
+}
+
+func TestTaskCapNotExceeded(t *testing.T) {
+	result := executeCheck(t, newTaskCapCheck(&fakeDataSource{
+		serverNodes: []*octopusservernodes.OctopusServerNodeResource{newServerNode("OctopusServerNodes-1", 5, false)},
+		queuedTasks: 5,
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Check should have succeeded, got %s", result.Description())
+	}
+}
+
+func TestTaskCapPermission(t *testing.T) {
+	assertPermissionResult(t, executeCheck(t, newTaskCapCheck(&fakeDataSource{err: errPermission})))
+}
@@ -0,0 +1,134 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/hayageek/threadsafe"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+	"golang.org/x/sync/errgroup"
+)
+
+const OctoLintUnusedUserRoles = "OctoLintUnusedUserRoles"
+
+// OctopusUnusedUserRolesCheck finds custom user roles that are not assigned to any team
+type OctopusUnusedUserRolesCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+}
+
+func NewOctopusUnusedUserRolesCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedUserRolesCheck {
+	return OctopusUnusedUserRolesCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
+}
+
+func (o OctopusUnusedUserRolesCheck) Id() string {
+	return OctoLintUnusedUserRoles
+}
+
+func (o OctopusUnusedUserRolesCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	userRoles, err := o.client.GetUserRoles(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	teams, err := o.client.GetTeams(ctx)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, err)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	usedUserRoles := threadsafe.NewSlice[string]()
+	goroutineErrors := threadsafe.NewSlice[error]()
+
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(teams))
+
+	for _, team := range teams {
+		team := team
+
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
+
+			scopedUserRoles, err := o.client.GetTeamScopedUserRoles(ctx, team)
+
+			if err != nil {
+				goroutineErrors.Append(err)
+				return nil
+			}
+
+			for _, scopedUserRole := range scopedUserRoles {
+				usedUserRoles.Append(scopedUserRole.UserRoleID)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Treat the first error as the root cause
+	if goroutineErrors.Length() > 0 {
+		return o.errorHandler.HandleError(o.Id(), checks.Instance, goroutineErrors.Values()[0])
+	}
+
+	usedUserRoleIds := usedUserRoles.Values()
+	unusedUserRoles := []checks.OctopusCheckFinding{}
+	for _, userRole := range userRoles {
+		// Only custom roles can be deleted, and the built in roles are expected to go unused
+		if !userRole.CanBeDeleted {
+			continue
+		}
+
+		if !slices.Contains(usedUserRoleIds, userRole.ID) {
+			unusedUserRoles = append(unusedUserRoles, checks.OctopusCheckFinding{
+				ResourceType: checks.UserRoleResource,
+				ResourceId:   userRole.ID,
+				ResourceName: userRole.Name,
+				Link:         o.urlBuilder.UserRoleUrl(userRole.ID),
+			})
+		}
+	}
+
+	if len(unusedUserRoles) != 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following custom user roles are not assigned to any team:",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Instance,
+			unusedUserRoles), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"There are no unused custom user roles",
+		o.Id(),
+		checks.WikiLink(o.Id()),
+		checks.Ok,
+		checks.Instance), nil
+}
@@ -0,0 +1,63 @@
+package instance
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+func newUserRole(id string, name string, custom bool) *userroles.UserRole {
+	userRole := userroles.NewUserRole(name)
+	userRole.ID = id
+	userRole.CanBeDeleted = custom
+	return userRole
+}
+
+func newTeam(id string, name string) *teams.Team {
+	team := teams.NewTeam(name)
+	team.ID = id
+	return team
+}
+
+func newUnusedUserRolesCheck(dataSource *fakeDataSource) OctopusUnusedUserRolesCheck {
+	return NewOctopusUnusedUserRolesCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder("https://example.octopus.app", ""), checks.OctopusClientPermissiveErrorHandler{})
+}
+
+func TestUnusedUserRoles(t *testing.T) {
+	result := executeCheck(t, newUnusedUserRolesCheck(&fakeDataSource{
+		userRoles: []*userroles.UserRole{
+			newUserRole("UserRoles-1", "Assigned", true),
+			newUserRole("UserRoles-2", "Unassigned", true),
+			newUserRole("UserRoles-3", "Built In", false),
+		},
+		teams:           []*teams.Team{newTeam("Teams-1", "Developers")},
+		scopedUserRoles: map[string][]*userroles.ScopedUserRole{"Teams-1": {userroles.NewScopedUserRole("UserRoles-1")}},
+	}))
+
+	if result.Severity() != checks.Warning {
+		t.Fatalf("Check should have returned a warning, got %s", result.Description())
+	}
+
+	if len(result.Findings()) != 1 || result.Findings()[0].ResourceName != "Unassigned" {
+		t.Fatalf("Check should have reported the unassigned custom role, got %s", result.Description())
+	}
+}
+
+func TestNoUnusedUserRoles(t *testing.T) {
+	result := executeCheck(t, newUnusedUserRolesCheck(&fakeDataSource{
+		userRoles:       []*userroles.UserRole{newUserRole("UserRoles-1", "Assigned", true)},
+		teams:           []*teams.Team{newTeam("Teams-1", "Developers")},
+		scopedUserRoles: map[string][]*userroles.ScopedUserRole{"Teams-1": {userroles.NewScopedUserRole("UserRoles-1")}},
+	}))
+
+	if result.Severity() != checks.Ok {
+		t.Fatalf("Check should have succeeded, got %s", result.Description())
+	}
+}
+
+func TestUnusedUserRolesPermission(t *testing.T) {
+	assertPermissionResult(t, executeCheck(t, newUnusedUserRolesCheck(&fakeDataSource{err: errPermission})))
+}
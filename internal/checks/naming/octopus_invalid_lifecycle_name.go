@@ -1,10 +1,10 @@
 package naming
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
 	"regexp"
@@ -15,16 +15,20 @@ const OctoLintInvalidLifecycleNames = "OctoLintInvalidLifecycleNames"
 
 // OctopusInvalidLifecycleName find targets that have not been healthy in the last 30 days.
 type OctopusInvalidLifecycleName struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInvalidLifecycleName(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidLifecycleName {
+func NewOctopusInvalidLifecycleName(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidLifecycleName {
 	return OctopusInvalidLifecycleName{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -32,7 +36,7 @@ func (o OctopusInvalidLifecycleName) Id() string {
 	return OctoLintInvalidLifecycleNames
 }
 
-func (o OctopusInvalidLifecycleName) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInvalidLifecycleName) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -43,49 +47,61 @@ func (o OctopusInvalidLifecycleName) Execute(concurrency int) (checks.OctopusChe
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.LifecycleNameRegex) == "" {
+	lifecycleNameRegex := o.config.CheckString(o.Id(), "regex", o.config.LifecycleNameRegex)
+
+	if strings.TrimSpace(lifecycleNameRegex) == "" {
 		return nil, nil
 	}
 
-	regex, err := regexp.Compile(o.config.LifecycleNameRegex)
+	regex, err := regexp.Compile(lifecycleNameRegex)
 
 	if err != nil {
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.LifecycleNameRegex+" does not compile",
+			"The supplied regex "+lifecycleNameRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	lifecycles, err := o.client.Lifecycles.GetAll()
+	lifecycles, err := o.cache.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	responses := []string{}
-	for i, l := range lifecycles {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(lifecycles))*100) + "% complete")
+	responses := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(lifecycles))
+
+	for _, l := range lifecycles {
+		progress.Complete(1)
 
 		if !regex.Match([]byte(l.Name)) {
-			responses = append(responses, l.Name)
+			responses = append(responses, checks.OctopusCheckFinding{
+				ResourceType: checks.LifecycleResource,
+				ResourceId:   l.ID,
+				ResourceName: l.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Link:         o.urlBuilder.LifecycleUrl(l.ID),
+			})
 		}
 	}
 
 	if len(responses) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following lifecycle names do not match the regex "+o.config.LifecycleNameRegex+":\n"+strings.Join(responses, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following lifecycle names do not match the regex "+lifecycleNameRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			responses), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
-		"All lifecycles match the regex "+o.config.LifecycleNameRegex,
+		"All lifecycles match the regex "+lifecycleNameRegex,
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
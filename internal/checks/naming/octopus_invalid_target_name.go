@@ -1,9 +1,8 @@
 package naming
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
@@ -16,16 +15,20 @@ const OctoLintInvalidTargetNames = "OctoLintInvalidTargetNames"
 
 // OctopusInvalidTargetName find targets that have not been healthy in the last 30 days.
 type OctopusInvalidTargetName struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInvalidTargetName(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidTargetName {
+func NewOctopusInvalidTargetName(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidTargetName {
 	return OctopusInvalidTargetName{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -33,7 +36,7 @@ func (o OctopusInvalidTargetName) Id() string {
 	return OctoLintInvalidTargetNames
 }
 
-func (o OctopusInvalidTargetName) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInvalidTargetName) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,49 +47,61 @@ func (o OctopusInvalidTargetName) Execute(concurrency int) (checks.OctopusCheckR
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.TargetNameRegex) == "" {
+	targetNameRegex := o.config.CheckString(o.Id(), "regex", o.config.TargetNameRegex)
+
+	if strings.TrimSpace(targetNameRegex) == "" {
 		return nil, nil
 	}
 
-	regex, err := regexp.Compile(o.config.TargetNameRegex)
+	regex, err := regexp.Compile(targetNameRegex)
 
 	if err != nil {
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.TargetNameRegex+" does not compile",
+			"The supplied regex "+targetNameRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidNameTargets, o.client, o.client.GetSpaceID())
+	allMachines, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", o.config.MaxInvalidNameTargets))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	responses := []string{}
-	for i, m := range allMachines {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
+	responses := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allMachines))
+
+	for _, m := range allMachines {
+		progress.Complete(1)
 
 		if !regex.Match([]byte(m.Name)) {
-			responses = append(responses, m.Name)
+			responses = append(responses, checks.OctopusCheckFinding{
+				ResourceType: checks.TargetResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Link:         o.urlBuilder.TargetUrl(m.ID),
+			})
 		}
 	}
 
 	if len(responses) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following target names do not match the regex "+o.config.TargetNameRegex+":\n"+strings.Join(responses, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following target names do not match the regex "+targetNameRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			responses), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
-		"All targets match the regex "+o.config.TargetNameRegex,
+		"All targets match the regex "+targetNameRegex,
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
@@ -1,9 +1,8 @@
 package naming
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
@@ -16,16 +15,20 @@ const OctoLintInvalidTargetRoles = "OctoLintInvalidTargetRoles"
 
 // OctopusInvalidTargetRole find targets that have not been healthy in the last 30 days.
 type OctopusInvalidTargetRole struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInvalidTargetRole(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidTargetRole {
+func NewOctopusInvalidTargetRole(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidTargetRole {
 	return OctopusInvalidTargetRole{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -33,7 +36,7 @@ func (o OctopusInvalidTargetRole) Id() string {
 	return OctoLintInvalidTargetRoles
 }
 
-func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInvalidTargetRole) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,30 +47,35 @@ func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckR
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.TargetRoleRegex) == "" {
+	targetRoleRegex := o.config.CheckString(o.Id(), "regex", o.config.TargetRoleRegex)
+
+	if strings.TrimSpace(targetRoleRegex) == "" {
 		return nil, nil
 	}
 
-	regex, err := regexp.Compile(o.config.TargetRoleRegex)
+	regex, err := regexp.Compile(targetRoleRegex)
 
 	if err != nil {
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.TargetNameRegex+" does not compile",
+			"The supplied regex "+targetRoleRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxInvalidRoleTargets, o.client, o.client.GetSpaceID())
+	allMachines, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", o.config.MaxInvalidRoleTargets))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	responses := []string{}
-	for i, m := range allMachines {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
+	responses := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allMachines))
+
+	for _, m := range allMachines {
+		progress.Complete(1)
 
 		invalidRoles := []string{}
 		for _, r := range m.Roles {
@@ -77,23 +85,31 @@ func (o OctopusInvalidTargetRole) Execute(concurrency int) (checks.OctopusCheckR
 		}
 
 		if len(invalidRoles) != 0 {
-			responses = append(responses, m.Name+" - "+strings.Join(invalidRoles, ","))
+			responses = append(responses, checks.OctopusCheckFinding{
+				ResourceType: checks.TargetResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Details:      strings.Join(invalidRoles, ","),
+				Link:         o.urlBuilder.TargetUrl(m.ID),
+			})
 		}
 	}
 
 	if len(responses) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following target roles do not match the regex "+o.config.TargetRoleRegex+":\n"+strings.Join(responses, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following target roles do not match the regex "+targetRoleRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			responses), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
-		"All targets match the regex "+o.config.TargetNameRegex,
+		"All targets match the regex "+targetRoleRegex,
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
@@ -3,13 +3,8 @@ package naming
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -21,22 +16,24 @@ import (
 	"strings"
 )
 
-var linkOptions = regexp.MustCompile(`\{.*?}`)
-
 const OctoLintInvalidVariableNames = "OctoLintInvalidVariableNames"
 
 // OctopusInvalidVariableNameCheck checks to see if any project variables are unused.
 type OctopusInvalidVariableNameCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInvalidVariableNameCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidVariableNameCheck {
+func NewOctopusInvalidVariableNameCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInvalidVariableNameCheck {
 	return OctopusInvalidVariableNameCheck{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -44,7 +41,7 @@ func (o OctopusInvalidVariableNameCheck) Id() string {
 	return OctoLintInvalidVariableNames
 }
 
-func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInvalidVariableNameCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -55,43 +52,50 @@ func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.Octopu
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	variableNameRegex := o.config.CheckString(o.Id(), "regex", o.config.VariableNameRegex)
+
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidVariableProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxInvalidVariableProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	regex, err := regexp.Compile(o.config.VariableNameRegex)
+	regex, err := regexp.Compile(variableNameRegex)
 
 	if err != nil {
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.VariableNameRegex+" does not compile",
+			"The supplied regex "+variableNameRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	messages := threadsafe.NewSlice[string]()
+	messages := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 
-		i := i
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
 
-			variableSet, err := o.client.Variables.GetAll(p.ID)
+			variableSet, err := o.cache.GetVariables(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -106,7 +110,16 @@ func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.Octopu
 				}
 
 				if !regex.Match([]byte(v.Name)) {
-					messages.Append(p.Name + ": " + v.Name)
+					messages.Append(checks.OctopusCheckFinding{
+						ResourceType: checks.VariableResource,
+						ResourceId:   v.ID,
+						ResourceName: v.Name,
+						SpaceId:      o.client.GetSpaceID(),
+						ParentType:   checks.ProjectResource,
+						ParentId:     p.ID,
+						ParentName:   p.Name,
+						Link:         o.urlBuilder.ProjectVariablesUrl(p.ID),
+					})
 				}
 
 			}
@@ -126,25 +139,26 @@ func (o OctopusInvalidVariableNameCheck) Execute(concurrency int) (checks.Octopu
 
 	if messages.Length() > 0 {
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following variables do not match the regex "+o.config.VariableNameRegex+":\n"+strings.Join(messages.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following variables do not match the regex "+variableNameRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			messages.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unused variables",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(p *projects2.Project) ([]*deployments.DeploymentStep, error) {
+func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(ctx context.Context, p *projects2.Project) ([]*deployments.DeploymentStep, error) {
 	deploymentProcesses := []*deployments.DeploymentStep{}
-	deploymentProcess, err := o.client.DeploymentProcesses.GetByID(p.DeploymentProcessID)
+	deploymentProcess, err := o.cache.GetDeploymentProcess(ctx, p)
 
 	if err != nil {
 		if !o.errorHandler.ShouldContinue(err) {
@@ -156,27 +170,25 @@ func (o OctopusInvalidVariableNameCheck) getDeploymentSteps(p *projects2.Project
 		}
 	}
 
-	if link, ok := p.Links["Runbooks"]; ok {
-		runbooks, err := newclient.Get[resources.Resources[runbooks.Runbook]](o.client.HttpSession(), linkOptions.ReplaceAllString(link, ""))
+	runbooks, err := o.cache.GetProjectRunbooks(ctx, p)
+
+	if err != nil {
+		if !o.errorHandler.ShouldContinue(err) {
+			return nil, err
+		}
+	}
+
+	for _, runbook := range runbooks {
+		runbookProcess, err := o.cache.GetRunbookProcess(ctx, p, runbook)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
-		}
-
-		for _, runbook := range runbooks.Items {
-			runbookProcess, err := o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID)
-
-			if err != nil {
-				if !o.errorHandler.ShouldContinue(err) {
-					return nil, err
-				}
-				continue
-			} else {
-				if runbookProcess != nil && runbookProcess.Steps != nil {
-					deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
-				}
+			continue
+		} else {
+			if runbookProcess != nil && runbookProcess.Steps != nil {
+				deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
 			}
 		}
 	}
@@ -1,11 +1,11 @@
 package naming
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
@@ -18,16 +18,20 @@ const OctoLintProjectReleaseTemplate = "OctoLintProjectReleaseTemplate"
 
 // OctopusProjectReleaseTemplateRegex checks to see if any project has too many steps.
 type OctopusProjectReleaseTemplateRegex struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectReleaseTemplateRegex(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectReleaseTemplateRegex {
+func NewOctopusProjectReleaseTemplateRegex(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectReleaseTemplateRegex {
 	return OctopusProjectReleaseTemplateRegex{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -35,7 +39,7 @@ func (o OctopusProjectReleaseTemplateRegex) Id() string {
 	return OctoLintProjectReleaseTemplate
 }
 
-func (o OctopusProjectReleaseTemplateRegex) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectReleaseTemplateRegex) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -46,69 +50,81 @@ func (o OctopusProjectReleaseTemplateRegex) Execute(concurrency int) (checks.Oct
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.ProjectReleaseTemplateRegex) == "" {
+	releaseTemplateRegex := o.config.CheckString(o.Id(), "regex", o.config.ProjectReleaseTemplateRegex)
+
+	if strings.TrimSpace(releaseTemplateRegex) == "" {
 		return nil, nil
 	}
 
-	regex, err := regexp.Compile(o.config.ProjectReleaseTemplateRegex)
+	regex, err := regexp.Compile(releaseTemplateRegex)
 
 	if err != nil {
 
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.ProjectReleaseTemplateRegex+" does not compile",
+			"The supplied regex "+releaseTemplateRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidReleaseTemplateProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxInvalidReleaseTemplateProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	results := []string{}
-	for i, p := range projects {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+	results := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
+		progress.Complete(1)
 
 		if p.VersioningStrategy != nil && !regex.Match([]byte(p.VersioningStrategy.Template)) {
-			results = append(results, p.Name+" - "+p.VersioningStrategy.Template)
+			results = append(results, checks.OctopusCheckFinding{
+				ResourceType: checks.ProjectResource,
+				ResourceId:   p.ID,
+				ResourceName: p.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Details:      p.VersioningStrategy.Template,
+				Link:         o.urlBuilder.ProjectSettingsUrl(p.ID),
+			})
 		}
 	}
 
 	if len(results) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project release templates do not match the regex "+o.config.ProjectReleaseTemplateRegex+":\n"+strings.Join(results, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following project release templates do not match the regex "+releaseTemplateRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			results), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
-		"All projects match the release templates regex "+o.config.ProjectReleaseTemplateRegex,
+		"All projects match the release templates regex "+releaseTemplateRegex,
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusProjectReleaseTemplateRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
+func (o OctopusProjectReleaseTemplateRegex) stepsInDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if project.DeploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
+		if apiError, ok := err.(*core.APIError); ok && apiError.StatusCode == 404 {
 			return nil, nil
 		}
 		return nil, err
@@ -1,9 +1,11 @@
 package naming
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
@@ -30,14 +32,17 @@ func TestInvalidReleaseTemplate(t *testing.T) {
 			return err
 		}
 
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
 		check := NewOctopusProjectReleaseTemplateRegex(
-			newSpaceClient,
+			dataSource,
+			client_wrapper.NewOctopusClientCache(dataSource),
 			&config.OctolintConfig{
 				ProjectReleaseTemplateRegex: "^#\\{Octopus\\.Version\\.LastMajor\\}\\.#\\{Octopus\\.Version\\.LastMinor\\}\\.#\\{Octopus\\.Version\\.LastPatch\\}$",
 			},
+			checks.NewOctopusUrlBuilder(container.URI, newSpaceId),
 			checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -3,10 +3,9 @@ package naming
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
@@ -14,23 +13,26 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
-	"strings"
 )
 
 const OctoLintProjectDefaultStepNames = "OctoLintProjectDefaultStepNames"
 
 // OctopusProjectDefaultStepNames checks to see if any project has too many steps.
 type OctopusProjectDefaultStepNames struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectDefaultStepNames(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectDefaultStepNames {
+func NewOctopusProjectDefaultStepNames(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectDefaultStepNames {
 	return OctopusProjectDefaultStepNames{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -38,7 +40,7 @@ func (o OctopusProjectDefaultStepNames) Id() string {
 	return OctoLintProjectDefaultStepNames
 }
 
-func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectDefaultStepNames) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -49,31 +51,36 @@ func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.Octopus
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxDefaultStepNameProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxDefaultStepNameProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	actionsWithDefaultNames := threadsafe.NewSlice[string]()
+	actionsWithDefaultNames := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
 
-			deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			deploymentProcess, err := o.stepsInDeploymentProcess(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -89,7 +96,16 @@ func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.Octopus
 			for _, s := range deploymentProcess.Steps {
 				for _, a := range s.Actions {
 					if slices.Index(checks.DefaultStepNames, a.Name) != -1 {
-						actionsWithDefaultNames.Append(p.Name + "/" + a.Name)
+						actionsWithDefaultNames.Append(checks.OctopusCheckFinding{
+							ResourceType: checks.ActionResource,
+							ResourceId:   a.ID,
+							ResourceName: a.Name,
+							SpaceId:      o.client.GetSpaceID(),
+							ParentType:   checks.ProjectResource,
+							ParentId:     p.ID,
+							ParentName:   p.Name,
+							Link:         o.urlBuilder.ProjectProcessUrl(p.ID),
+						})
 					}
 				}
 			}
@@ -108,32 +124,33 @@ func (o OctopusProjectDefaultStepNames) Execute(concurrency int) (checks.Octopus
 	}
 
 	if actionsWithDefaultNames.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project actions use the default step names:\n"+strings.Join(actionsWithDefaultNames.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following project actions use the default step names:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			actionsWithDefaultNames.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no project actions default step names",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusProjectDefaultStepNames) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
+func (o OctopusProjectDefaultStepNames) stepsInDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if project.DeploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
+		if apiError, ok := err.(*core.APIError); ok && apiError.StatusCode == 404 {
 			return nil, nil
 		}
 		return nil, err
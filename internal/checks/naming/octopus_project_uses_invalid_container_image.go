@@ -3,10 +3,9 @@ package naming
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
@@ -21,16 +20,20 @@ const OctoLintContainerImageName = "OctoLintProjectContainerImageName"
 
 // OctopusProjectContainerImageRegex checks to see if any project has too many steps.
 type OctopusProjectContainerImageRegex struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectContainerImageRegex(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectContainerImageRegex {
+func NewOctopusProjectContainerImageRegex(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectContainerImageRegex {
 	return OctopusProjectContainerImageRegex{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -38,7 +41,7 @@ func (o OctopusProjectContainerImageRegex) Id() string {
 	return OctoLintContainerImageName
 }
 
-func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectContainerImageRegex) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -49,49 +52,55 @@ func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.Octo
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.ContainerImageRegex) == "" {
+	containerImageRegex := o.config.CheckString(o.Id(), "regex", o.config.ContainerImageRegex)
+
+	if strings.TrimSpace(containerImageRegex) == "" {
 		return nil, nil
 	}
 
-	regex, err := regexp.Compile(o.config.ContainerImageRegex)
+	regex, err := regexp.Compile(containerImageRegex)
 
 	if err != nil {
 
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.ContainerImageRegex+" does not compile",
+			"The supplied regex "+containerImageRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidContainerImageProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxInvalidContainerImageProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	actionsWithInvalidImages := threadsafe.NewSlice[string]()
+	actionsWithInvalidImages := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 
-		i := i
 		p := p
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			defer progress.Complete(1)
 
-			deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			deploymentProcess, err := o.stepsInDeploymentProcess(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -111,7 +120,17 @@ func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.Octo
 					}
 
 					if !regex.Match([]byte(a.Container.Image)) {
-						actionsWithInvalidImages.Append(p.Name + "/" + a.Name + ": " + a.Container.Image)
+						actionsWithInvalidImages.Append(checks.OctopusCheckFinding{
+							ResourceType: checks.ActionResource,
+							ResourceId:   a.ID,
+							ResourceName: a.Name,
+							SpaceId:      o.client.GetSpaceID(),
+							ParentType:   checks.ProjectResource,
+							ParentId:     p.ID,
+							ParentName:   p.Name,
+							Details:      a.Container.Image,
+							Link:         o.urlBuilder.ProjectProcessUrl(p.ID),
+						})
 					}
 				}
 			}
@@ -131,32 +150,33 @@ func (o OctopusProjectContainerImageRegex) Execute(concurrency int) (checks.Octo
 	}
 
 	if actionsWithInvalidImages.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project actions do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(actionsWithInvalidImages.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following project actions do not match the regex "+containerImageRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			actionsWithInvalidImages.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no project actions with invalid container images",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusProjectContainerImageRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
+func (o OctopusProjectContainerImageRegex) stepsInDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if project.DeploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
+		if apiError, ok := err.(*core.APIError); ok && apiError.StatusCode == 404 {
 			return nil, nil
 		}
 		return nil, err
@@ -3,10 +3,9 @@ package naming
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -23,16 +22,20 @@ const OctoLintProjectWorkerPool = "OctoLintProjectWorkerPool"
 
 // OctopusProjectWorkerPoolRegex checks to see if any project has too many steps.
 type OctopusProjectWorkerPoolRegex struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectWorkerPoolRegex(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectWorkerPoolRegex {
+func NewOctopusProjectWorkerPoolRegex(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectWorkerPoolRegex {
 	return OctopusProjectWorkerPoolRegex{
 		client:       client,
+		cache:        cache,
 		errorHandler: errorHandler,
 		config:       config,
+		urlBuilder:   urlBuilder,
 	}
 }
 
@@ -40,7 +43,7 @@ func (o OctopusProjectWorkerPoolRegex) Id() string {
 	return OctoLintProjectWorkerPool
 }
 
-func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectWorkerPoolRegex) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -51,34 +54,35 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	if strings.TrimSpace(o.config.ProjectStepWorkerPoolRegex) == "" {
+	workerPoolRegex := o.config.CheckString(o.Id(), "regex", o.config.ProjectStepWorkerPoolRegex)
+
+	if strings.TrimSpace(workerPoolRegex) == "" {
 		return nil, nil
 	}
 
-	regex, err := regexp.Compile(o.config.ProjectStepWorkerPoolRegex)
+	regex, err := regexp.Compile(workerPoolRegex)
 
 	if err != nil {
 
 		return checks.NewOctopusCheckResultImpl(
-			"The supplied regex "+o.config.ProjectStepWorkerPoolRegex+" does not compile",
+			"The supplied regex "+workerPoolRegex+" does not compile",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Error,
 			checks.Naming), nil
 	}
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxInvalidWorkerPoolProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxInvalidWorkerPoolProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
 	}
 
-	workerPools, err := o.client.WorkerPools.GetAll()
+	workerPools, err := o.client.GetWorkerPools(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Naming, err)
@@ -93,21 +97,26 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 		defaultWorkerPool = defaultWorkerPools[0].Name
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	actionsWithInvalidWorkerPools := threadsafe.NewSlice[string]()
+	actionsWithInvalidWorkerPools := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 		p := p
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			defer progress.Complete(1)
 
-			deploymentProcess, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			deploymentProcess, err := o.stepsInDeploymentProcess(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -130,7 +139,17 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 					if a.WorkerPool == "" {
 						if defaultWorkerPool != "" && !regex.Match([]byte(defaultWorkerPool)) {
 
-							actionsWithInvalidWorkerPools.Append(p.Name + "/" + a.Name + ": " + defaultWorkerPool + " (default)")
+							actionsWithInvalidWorkerPools.Append(checks.OctopusCheckFinding{
+								ResourceType: checks.ActionResource,
+								ResourceId:   a.ID,
+								ResourceName: a.Name,
+								SpaceId:      o.client.GetSpaceID(),
+								ParentType:   checks.ProjectResource,
+								ParentId:     p.ID,
+								ParentName:   p.Name,
+								Details:      defaultWorkerPool + " (default)",
+								Link:         o.urlBuilder.ProjectProcessUrl(p.ID),
+							})
 						}
 					} else if !regex.Match([]byte(a.WorkerPool)) {
 						workerPool := lo.Filter(workerPools, func(item *workerpools.WorkerPoolListResult, index int) bool {
@@ -138,7 +157,17 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 						})
 
 						if len(workerPool) == 1 && !regex.Match([]byte(workerPool[0].Name)) {
-							actionsWithInvalidWorkerPools.Append(p.Name + "/" + a.Name + ": " + workerPool[0].Name)
+							actionsWithInvalidWorkerPools.Append(checks.OctopusCheckFinding{
+								ResourceType: checks.ActionResource,
+								ResourceId:   a.ID,
+								ResourceName: a.Name,
+								SpaceId:      o.client.GetSpaceID(),
+								ParentType:   checks.ProjectResource,
+								ParentId:     p.ID,
+								ParentName:   p.Name,
+								Details:      workerPool[0].Name,
+								Link:         o.urlBuilder.ProjectProcessUrl(p.ID),
+							})
 						}
 					}
 				}
@@ -159,32 +188,33 @@ func (o OctopusProjectWorkerPoolRegex) Execute(concurrency int) (checks.OctopusC
 	}
 
 	if actionsWithInvalidWorkerPools.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project actions use worker pools that do not match the regex "+o.config.ContainerImageRegex+":\n"+strings.Join(actionsWithInvalidWorkerPools.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following project actions use worker pools that do not match the regex "+workerPoolRegex+":",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Naming), nil
+			checks.Naming,
+			actionsWithInvalidWorkerPools.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
-		"There are no actions that use worker pools that do not match the regex "+o.config.ContainerImageRegex,
+		"There are no actions that use worker pools that do not match the regex "+workerPoolRegex,
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Naming), nil
 }
 
-func (o OctopusProjectWorkerPoolRegex) stepsInDeploymentProcess(deploymentProcessID string) (*deployments.DeploymentProcess, error) {
-	if deploymentProcessID == "" {
+func (o OctopusProjectWorkerPoolRegex) stepsInDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if project.DeploymentProcessID == "" {
 		return nil, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
+		if apiError, ok := err.(*core.APIError); ok && apiError.StatusCode == 404 {
 			return nil, nil
 		}
 		return nil, err
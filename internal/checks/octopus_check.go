@@ -1,9 +1,11 @@
 package checks
 
+import "context"
+
 // OctopusCheck defines the contract for each lint check
 type OctopusCheck interface {
-	// Execute runs the check
-	Execute(concurrency int) (OctopusCheckResult, error)
+	// Execute runs the check. The check stops making requests and returns the context error once the context is done.
+	Execute(ctx context.Context, concurrency int) (OctopusCheckResult, error)
 	// Id returns the unique ID of the check, used to cross-reference with documentation
 	Id() string
 }
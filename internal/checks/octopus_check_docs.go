@@ -0,0 +1,9 @@
+package checks
+
+// WikiUrl is the location of the documentation for each check.
+const WikiUrl = "https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/wiki"
+
+// WikiLink returns the link to the wiki page documenting the check with the supplied ID.
+func WikiLink(id string) string {
+	return WikiUrl + "/" + id
+}
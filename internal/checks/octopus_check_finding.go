@@ -0,0 +1,84 @@
+package checks
+
+import "fmt"
+
+const (
+	ProjectResource            = "Project"
+	ProjectGroupResource       = "ProjectGroup"
+	VariableResource           = "Variable"
+	StepResource               = "Step"
+	ActionResource             = "Action"
+	TargetResource             = "Target"
+	TenantResource             = "Tenant"
+	EnvironmentResource        = "Environment"
+	LifecycleResource          = "Lifecycle"
+	FeedResource               = "Feed"
+	AccountResource            = "Account"
+	CertificateResource        = "Certificate"
+	SubscriptionResource       = "Subscription"
+	UserResource               = "User"
+	ApiKeyResource             = "ApiKey"
+	DeploymentResource         = "Deployment"
+	LibraryVariableSetResource = "LibraryVariableSet"
+	SpaceResource              = "Space"
+	TeamResource               = "Team"
+	UserRoleResource           = "UserRole"
+	ServerNodeResource         = "ServerNode"
+	LicenseResource            = "License"
+	ConfigurationResource      = "Configuration"
+	AuthenticationResource     = "AuthenticationProvider"
+	WorkerPoolResource         = "WorkerPool"
+)
+
+// OctopusCheckFinding identifies an individual resource that was flagged by a check.
+type OctopusCheckFinding struct {
+	// ResourceType is the type of the flagged resource, like Project or Target
+	ResourceType string `json:"resourceType"`
+	ResourceId   string `json:"resourceId"`
+	ResourceName string `json:"resourceName"`
+	SpaceId      string `json:"spaceId,omitempty"`
+	// ParentType, ParentId and ParentName identify the resource that owns the flagged resource, like
+	// the project that holds a variable.
+	ParentType string `json:"parentType,omitempty"`
+	ParentId   string `json:"parentId,omitempty"`
+	ParentName string `json:"parentName,omitempty"`
+	// Details captures any additional information about why the resource was flagged
+	Details string `json:"details,omitempty"`
+	// Link is the location of the resource in the Octopus web portal
+	Link string `json:"link,omitempty"`
+	// File and Line are the location of the resource in a local OCL file
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+// Location returns the file and line of the resource, or an empty string if the resource was not read from a file.
+func (o OctopusCheckFinding) Location() string {
+	if o.File == "" {
+		return ""
+	}
+
+	if o.Line == 0 {
+		return o.File
+	}
+
+	return o.File + ":" + fmt.Sprint(o.Line)
+}
+
+// String returns the human-readable representation of a finding used in the result description.
+func (o OctopusCheckFinding) String() string {
+	name := o.ResourceName
+
+	if o.ParentName != "" {
+		name = o.ParentName + "/" + name
+	}
+
+	if o.Details != "" {
+		name += ": " + o.Details
+	}
+
+	if o.File != "" {
+		name = o.Location() + ": " + name
+	}
+
+	return name
+}
@@ -0,0 +1,39 @@
+package checks
+
+import "context"
+
+// ProgressReporter is used by a check to report how much of its work is complete. Checks call AddTotal with the
+// number of resources they are about to process, and Complete as each resource is processed. Implementations must
+// be safe for concurrent use, as checks process resources in parallel.
+type ProgressReporter interface {
+	// AddTotal adds to the number of units of work the check has to do
+	AddTotal(units int)
+	// Complete records that units of work are done
+	Complete(units int)
+}
+
+type progressKey struct{}
+
+// ContextWithProgress returns a context that passes the progress reporter to a check.
+func ContextWithProgress(ctx context.Context, reporter ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressKey{}, reporter)
+}
+
+// Progress returns the progress reporter passed to the check in the context. If there is no reporter, the progress
+// is discarded.
+func Progress(ctx context.Context) ProgressReporter {
+	if reporter, ok := ctx.Value(progressKey{}).(ProgressReporter); ok {
+		return reporter
+	}
+
+	return noProgress{}
+}
+
+type noProgress struct {
+}
+
+func (n noProgress) AddTotal(units int) {
+}
+
+func (n noProgress) Complete(units int) {
+}
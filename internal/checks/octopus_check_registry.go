@@ -0,0 +1,85 @@
+package checks
+
+import (
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OctopusCheckDependencies are the shared resources passed to the constructor of a registered check. They are the
+// same client, cache, config, link builder, and error handler passed to the built-in checks.
+type OctopusCheckDependencies struct {
+	Client       client_wrapper.OctopusDataSource
+	Cache        *client_wrapper.OctopusClientCache
+	Config       *config.OctolintConfig
+	UrlBuilder   OctopusUrlBuilder
+	ErrorHandler OctopusClientErrorHandler
+}
+
+// OctopusCheckConstructor creates a new instance of a registered check for a space.
+type OctopusCheckConstructor func(dependencies OctopusCheckDependencies) OctopusCheck
+
+var registeredChecks = map[string]OctopusCheckConstructor{}
+var registeredChecksMutex = sync.Mutex{}
+
+// RegisterCheck adds a check that is run against each space along with the built-in checks. Checks are usually
+// registered from the init function of the package that defines them. The ID must match the ID returned by the check.
+func RegisterCheck(id string, constructor OctopusCheckConstructor) error {
+	if strings.TrimSpace(id) == "" {
+		return errors.New("the check ID can not be empty")
+	}
+
+	if constructor == nil {
+		return errors.New("the constructor of the check " + id + " can not be nil")
+	}
+
+	registeredChecksMutex.Lock()
+	defer registeredChecksMutex.Unlock()
+
+	for registeredId := range registeredChecks {
+		if strings.EqualFold(registeredId, id) {
+			return errors.New("the check " + id + " has already been registered")
+		}
+	}
+
+	registeredChecks[id] = constructor
+
+	return nil
+}
+
+// RegisteredChecks creates a new instance of each registered check, ordered by ID.
+func RegisteredChecks(dependencies OctopusCheckDependencies) ([]OctopusCheck, error) {
+	registeredChecksMutex.Lock()
+	defer registeredChecksMutex.Unlock()
+
+	ids := []string{}
+	for id := range registeredChecks {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	allChecks := []OctopusCheck{}
+	for _, id := range ids {
+		check := registeredChecks[id](dependencies)
+
+		if check == nil || check.Id() != id {
+			return nil, errors.New("the check registered as " + id + " must return " + id + " from its Id function")
+		}
+
+		allChecks = append(allChecks, check)
+	}
+
+	return allChecks, nil
+}
+
+// UnregisterCheck removes a registered check. It is used by tests to clean up the checks they register.
+func UnregisterCheck(id string) {
+	registeredChecksMutex.Lock()
+	defer registeredChecksMutex.Unlock()
+
+	delete(registeredChecks, id)
+}
@@ -0,0 +1,68 @@
+package checks
+
+import (
+	"context"
+	"testing"
+)
+
+type registeredCheck struct {
+	id string
+}
+
+func (o registeredCheck) Id() string {
+	return o.id
+}
+
+func (o registeredCheck) Execute(ctx context.Context, concurrency int) (OctopusCheckResult, error) {
+	return NewOctopusCheckResultImpl("Passed", o.id, "", Ok, Organization), nil
+}
+
+func TestRegisterCheck(t *testing.T) {
+	constructor := func(dependencies OctopusCheckDependencies) OctopusCheck {
+		return registeredCheck{id: "MyCheck"}
+	}
+
+	if err := RegisterCheck("MyCheck", constructor); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnregisterCheck("MyCheck")
+
+	if err := RegisterCheck("mycheck", constructor); err == nil {
+		t.Fatal("a check ID must only be registered once")
+	}
+
+	if err := RegisterCheck(" ", constructor); err == nil {
+		t.Fatal("a check must have an ID")
+	}
+
+	if err := RegisterCheck("NoConstructor", nil); err == nil {
+		t.Fatal("a check must have a constructor")
+	}
+
+	registeredChecks, err := RegisteredChecks(OctopusCheckDependencies{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(registeredChecks) != 1 || registeredChecks[0].Id() != "MyCheck" {
+		t.Fatal("the registered check must be created")
+	}
+}
+
+func TestRegisteredCheckMustMatchId(t *testing.T) {
+	err := RegisterCheck("MyMismatchedCheck", func(dependencies OctopusCheckDependencies) OctopusCheck {
+		return registeredCheck{id: "SomethingElse"}
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnregisterCheck("MyMismatchedCheck")
+
+	if _, err := RegisteredChecks(OctopusCheckDependencies{}); err == nil {
+		t.Fatal("a registered check must return the ID it was registered with")
+	}
+}
@@ -1,5 +1,11 @@
 package checks
 
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
 const (
 	Error      int = 20
 	Warning        = 15
@@ -15,15 +21,85 @@ const (
 	Performance         = "Performance"
 	Optimization        = "Optimization"
 	GeneralError        = "GeneralError"
+	// Instance is the category of checks that inspect the configuration of the server rather than a space
+	Instance = "Instance"
+	// TimedOut is the category of results for checks that did not complete before their timeout
+	TimedOut = "TimedOut"
+	// BudgetExhausted is the category of results for checks that could not run because the API request budget was used up
+	BudgetExhausted = "BudgetExhausted"
+)
+
+const (
+	ErrorSeverityName      = "error"
+	WarningSeverityName    = "warning"
+	InfoSeverityName       = "info"
+	PermissionSeverityName = "permission"
+	OkSeverityName         = "ok"
 )
 
+// SeverityNames lists the severities that can be passed to command line arguments.
+var SeverityNames = []string{ErrorSeverityName, WarningSeverityName, InfoSeverityName, PermissionSeverityName, OkSeverityName}
+
+// SeverityToString converts a severity level to the name used in reports and command line arguments.
+func SeverityToString(severity int) string {
+	switch {
+	case severity >= Error:
+		return ErrorSeverityName
+	case severity >= Warning:
+		return WarningSeverityName
+	case severity >= Info:
+		return InfoSeverityName
+	case severity >= Permission:
+		return PermissionSeverityName
+	default:
+		return OkSeverityName
+	}
+}
+
+// StringToSeverity converts the name of a severity to its level.
+func StringToSeverity(severity string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(severity)) {
+	case ErrorSeverityName:
+		return Error, nil
+	case WarningSeverityName:
+		return Warning, nil
+	case InfoSeverityName:
+		return Info, nil
+	case PermissionSeverityName:
+		return Permission, nil
+	case OkSeverityName:
+		return Ok, nil
+	}
+
+	return 0, errors.New("the severity \"" + severity + "\" is not supported")
+}
+
+// IsCheckFailure returns true if the result reports a check that failed to run, timed out, or could not read its data
+// because the API request budget was used up, rather than the findings of a check.
+func IsCheckFailure(result OctopusCheckResult) bool {
+	return result.Category() == GeneralError || result.Category() == TimedOut || result.Category() == BudgetExhausted
+}
+
 // OctopusCheckResult describes the result of an OctopusCheck
 type OctopusCheckResult interface {
+	// Description is a summary of the result, including any findings
 	Description() string
+	// Summary is the description of the result without the findings
+	Summary() string
 	Code() string
 	Link() string
 	Severity() int
 	Category() string
+	// Findings lists the individual resources that were flagged by the check
+	Findings() []OctopusCheckFinding
+	// Partial is true if the check could not read all the resources it needed, so the findings may be incomplete
+	Partial() bool
+	// Space is the name of the space the check was run against, or an empty string if the result is not
+	// associated with a space
+	Space() string
+	// SpaceId is the ID of the space the check was run against. Unlike the name, the ID does not change when the
+	// space is renamed.
+	SpaceId() string
 }
 
 type OctopusCheckResultImpl struct {
@@ -32,6 +108,10 @@ type OctopusCheckResultImpl struct {
 	link        string
 	severity    int
 	category    string
+	findings    []OctopusCheckFinding
+	partial     bool
+	space       string
+	spaceId     string
 }
 
 func NewOctopusCheckResultImpl(description string, code string, link string, severity int, category string) OctopusCheckResultImpl {
@@ -41,13 +121,126 @@ func NewOctopusCheckResultImpl(description string, code string, link string, sev
 		link:        link,
 		severity:    severity,
 		category:    category,
+		findings:    []OctopusCheckFinding{},
+	}
+}
+
+// NewOctopusCheckResultWithFindings creates a result that lists the resources flagged by a check. The description
+// is built from the summary followed by one line per finding. Checks often collect findings from maps or goroutines,
+// so the findings are sorted to keep the reports the same between runs.
+func NewOctopusCheckResultWithFindings(summary string, code string, link string, severity int, category string, findings []OctopusCheckFinding) OctopusCheckResultImpl {
+	return OctopusCheckResultImpl{
+		description: summary,
+		code:        code,
+		link:        link,
+		severity:    severity,
+		category:    category,
+		findings:    sortFindings(findings),
+	}
+}
+
+// sortFindings returns a copy of the findings sorted by resource type, name, and ID.
+func sortFindings(findings []OctopusCheckFinding) []OctopusCheckFinding {
+	sorted := make([]OctopusCheckFinding, len(findings))
+	copy(sorted, findings)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ResourceType != sorted[j].ResourceType {
+			return sorted[i].ResourceType < sorted[j].ResourceType
+		}
+
+		if sorted[i].ResourceName != sorted[j].ResourceName {
+			return sorted[i].ResourceName < sorted[j].ResourceName
+		}
+
+		return sorted[i].ResourceId < sorted[j].ResourceId
+	})
+
+	return sorted
+}
+
+// NewOctopusCheckPartialResult copies a result, marking it as partial. The reason is included in the description so
+// every report format shows that the findings may be incomplete.
+func NewOctopusCheckPartialResult(result OctopusCheckResult, reason string) OctopusCheckResultImpl {
+	return OctopusCheckResultImpl{
+		description: "Partial result, " + reason + ". " + result.Summary(),
+		code:        result.Code(),
+		link:        result.Link(),
+		severity:    result.Severity(),
+		category:    result.Category(),
+		findings:    result.Findings(),
+		partial:     true,
+		space:       result.Space(),
+		spaceId:     result.SpaceId(),
+	}
+}
+
+// CopyOctopusCheckResult copies a result, replacing the findings.
+func CopyOctopusCheckResult(result OctopusCheckResult, findings []OctopusCheckFinding) OctopusCheckResultImpl {
+	return OctopusCheckResultImpl{
+		description: result.Summary(),
+		code:        result.Code(),
+		link:        result.Link(),
+		severity:    result.Severity(),
+		category:    result.Category(),
+		findings:    sortFindings(findings),
+		partial:     result.Partial(),
+		space:       result.Space(),
+		spaceId:     result.SpaceId(),
+	}
+}
+
+// NewOctopusCheckSpaceResult copies a result, recording the ID and name of the space the check was run against.
+func NewOctopusCheckSpaceResult(result OctopusCheckResult, spaceId string, space string) OctopusCheckResultImpl {
+	return OctopusCheckResultImpl{
+		description: result.Summary(),
+		code:        result.Code(),
+		link:        result.Link(),
+		severity:    result.Severity(),
+		category:    result.Category(),
+		findings:    result.Findings(),
+		partial:     result.Partial(),
+		space:       space,
+		spaceId:     spaceId,
+	}
+}
+
+// NewOctopusCheckSeverityResult copies a result, replacing the severity.
+func NewOctopusCheckSeverityResult(result OctopusCheckResult, severity int) OctopusCheckResultImpl {
+	return OctopusCheckResultImpl{
+		description: result.Summary(),
+		code:        result.Code(),
+		link:        result.Link(),
+		severity:    severity,
+		category:    result.Category(),
+		findings:    result.Findings(),
+		partial:     result.Partial(),
+		space:       result.Space(),
+		spaceId:     result.SpaceId(),
 	}
 }
 
 func (o OctopusCheckResultImpl) Description() string {
+	if len(o.findings) == 0 {
+		return o.description
+	}
+
+	lines := []string{o.description}
+	for _, f := range o.findings {
+		lines = append(lines, f.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (o OctopusCheckResultImpl) Summary() string {
 	return o.description
 }
 
+func (o OctopusCheckResultImpl) Findings() []OctopusCheckFinding {
+	return o.findings
+}
+
 func (o OctopusCheckResultImpl) Code() string {
 	return o.code
 }
@@ -63,3 +256,15 @@ func (o OctopusCheckResultImpl) Severity() int {
 func (o OctopusCheckResultImpl) Category() string {
 	return o.category
 }
+
+func (o OctopusCheckResultImpl) Partial() bool {
+	return o.partial
+}
+
+func (o OctopusCheckResultImpl) Space() string {
+	return o.space
+}
+
+func (o OctopusCheckResultImpl) SpaceId() string {
+	return o.spaceId
+}
@@ -0,0 +1,33 @@
+package checks
+
+import "testing"
+
+func TestResultSortsFindings(t *testing.T) {
+	findings := []OctopusCheckFinding{
+		{ResourceType: ProjectResource, ResourceId: "Projects-2", ResourceName: "B"},
+		{ResourceType: TargetResource, ResourceId: "Machines-1", ResourceName: "A"},
+		{ResourceType: ProjectResource, ResourceId: "Projects-3", ResourceName: "A"},
+		{ResourceType: ProjectResource, ResourceId: "Projects-1", ResourceName: "A"},
+	}
+
+	result := NewOctopusCheckResultWithFindings("Findings:", "OctoLintTest", "", Warning, Organization, findings)
+
+	ids := []string{}
+	for _, f := range result.Findings() {
+		ids = append(ids, f.ResourceId)
+	}
+
+	if len(ids) != 4 || ids[0] != "Projects-1" || ids[1] != "Projects-3" || ids[2] != "Projects-2" || ids[3] != "Machines-1" {
+		t.Fatalf("Findings should have been sorted by type, name, and ID, got %v", ids)
+	}
+
+	if findings[0].ResourceId != "Projects-2" {
+		t.Fatal("The findings passed to the result must not be modified")
+	}
+
+	copied := CopyOctopusCheckResult(result, []OctopusCheckFinding{findings[0], findings[3]})
+
+	if copied.Findings()[0].ResourceId != "Projects-1" {
+		t.Fatal("Copied findings should have been sorted")
+	}
+}
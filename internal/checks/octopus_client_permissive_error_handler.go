@@ -1,7 +1,10 @@
 package checks
 
 import (
+	"context"
+	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"net/http"
 	"strings"
 )
@@ -12,11 +15,21 @@ type OctopusClientPermissiveErrorHandler struct {
 }
 
 func (o OctopusClientPermissiveErrorHandler) HandleError(id string, group string, err error) (OctopusCheckResult, error) {
+	// The executor reports checks that could not start because they ran out of requests
+	if client_wrapper.IsRequestBudgetError(err) {
+		return nil, err
+	}
+
+	// A snapshot that does not include the resources a check needs is not a permissions problem
+	if client_wrapper.IsNotInSnapshotError(err) {
+		return nil, err
+	}
+
 	if o.ShouldContinue(err) {
 		return NewOctopusCheckResultImpl(
 			"You do not have permission to run the check: "+err.Error(),
 			id,
-			"",
+			WikiLink(id),
 			Permission,
 			group), nil
 	}
@@ -25,8 +38,21 @@ func (o OctopusClientPermissiveErrorHandler) HandleError(id string, group string
 
 // ShouldContinue is used to determine if an error was a permissions error. Things like 404s are also treated
 // as permission errors (we saw this a lot trying to get deployment processes). Interestingly we also saw a lot of
-// StatusCode's set to 0, so this function also reads the error to work out what is going on.
+// StatusCode's set to 0, so this function also reads the error to work out what is going on. Errors caused by a check
+// timing out or being cancelled, and transient errors that persisted after all the retries, are never treated as
+// permission errors. Errors caused by the request budget running out allow the check to continue with the resources
+// it has already read, and the executor marks the result as partial. Resources missing from a snapshot stop the check,
+// as the results would not match the run that saved the snapshot.
 func (o OctopusClientPermissiveErrorHandler) ShouldContinue(err error) bool {
+	if client_wrapper.IsRequestBudgetError(err) {
+		return true
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || client_wrapper.IsTransientError(err) ||
+		client_wrapper.IsNotInSnapshotError(err) {
+		return false
+	}
+
 	apiError, ok := err.(*core.APIError)
 	if ok {
 		return apiError.StatusCode == http.StatusUnauthorized ||
@@ -0,0 +1,142 @@
+package checks
+
+import (
+	"strings"
+)
+
+// OctopusUrlBuilder creates links to resources in the Octopus web portal.
+type OctopusUrlBuilder struct {
+	url   string
+	space string
+}
+
+func NewOctopusUrlBuilder(url string, space string) OctopusUrlBuilder {
+	return OctopusUrlBuilder{url: strings.TrimSuffix(strings.TrimSpace(url), "/"), space: space}
+}
+
+func (o OctopusUrlBuilder) ProjectUrl(projectId string) string {
+	return o.spaceUrl("/projects/" + projectId + "/deployments")
+}
+
+func (o OctopusUrlBuilder) ProjectProcessUrl(projectId string) string {
+	return o.spaceUrl("/projects/" + projectId + "/deployments/process")
+}
+
+func (o OctopusUrlBuilder) ProjectSettingsUrl(projectId string) string {
+	return o.spaceUrl("/projects/" + projectId + "/settings")
+}
+
+func (o OctopusUrlBuilder) ProjectVariablesUrl(projectId string) string {
+	return o.spaceUrl("/projects/" + projectId + "/variables")
+}
+
+func (o OctopusUrlBuilder) ProjectGroupUrl(projectGroupId string) string {
+	return o.spaceUrl("/projectGroups/" + projectGroupId)
+}
+
+func (o OctopusUrlBuilder) DeploymentUrl(projectId string, releaseId string, deploymentId string) string {
+	return o.spaceUrl("/projects/" + projectId + "/deployments/releases/" + releaseId + "/deployments/" + deploymentId)
+}
+
+func (o OctopusUrlBuilder) LibraryVariableSetUrl(libraryVariableSetId string) string {
+	return o.spaceUrl("/library/variables/" + libraryVariableSetId)
+}
+
+func (o OctopusUrlBuilder) TargetUrl(targetId string) string {
+	return o.spaceUrl("/infrastructure/machines/" + targetId + "/settings")
+}
+
+func (o OctopusUrlBuilder) EnvironmentUrl(environmentId string) string {
+	return o.spaceUrl("/infrastructure/environments/" + environmentId)
+}
+
+func (o OctopusUrlBuilder) AccountUrl(accountId string) string {
+	return o.spaceUrl("/infrastructure/accounts/" + accountId)
+}
+
+func (o OctopusUrlBuilder) TenantUrl(tenantId string) string {
+	return o.spaceUrl("/tenants/" + tenantId + "/overview")
+}
+
+func (o OctopusUrlBuilder) FeedUrl(feedId string) string {
+	return o.spaceUrl("/library/feeds/" + feedId + "/edit")
+}
+
+func (o OctopusUrlBuilder) CertificateUrl(certificateId string) string {
+	return o.spaceUrl("/library/certificates/" + certificateId)
+}
+
+func (o OctopusUrlBuilder) LifecycleUrl(lifecycleId string) string {
+	return o.spaceUrl("/library/lifecycles/" + lifecycleId)
+}
+
+func (o OctopusUrlBuilder) WorkerPoolUrl(workerPoolId string) string {
+	return o.spaceUrl("/infrastructure/workerpools/" + workerPoolId)
+}
+
+func (o OctopusUrlBuilder) SubscriptionUrl(subscriptionId string) string {
+	return o.spaceUrl("/configuration/subscriptions/" + subscriptionId)
+}
+
+// UserUrl returns the link to a user. Users are not scoped to a space.
+func (o OctopusUrlBuilder) UserUrl(userId string) string {
+	return o.url + "/app#/configuration/users/" + userId
+}
+
+// TeamUrl returns the link to a team. Teams are linked through the configuration pages, which include teams in every
+// space.
+func (o OctopusUrlBuilder) TeamUrl(teamId string) string {
+	return o.url + "/app#/configuration/teams/" + teamId
+}
+
+// UserRoleUrl returns the link to a user role. User roles are not scoped to a space.
+func (o OctopusUrlBuilder) UserRoleUrl(userRoleId string) string {
+	return o.url + "/app#/configuration/roles/" + userRoleId
+}
+
+// ResourceUrl returns the link to a resource based on the resource types used by OctopusCheckFinding, or an
+// empty string if the resource type has no page in the portal.
+func (o OctopusUrlBuilder) ResourceUrl(resourceType string, resourceId string) string {
+	if resourceId == "" {
+		return ""
+	}
+
+	switch resourceType {
+	case ProjectResource:
+		return o.ProjectUrl(resourceId)
+	case ProjectGroupResource:
+		return o.ProjectGroupUrl(resourceId)
+	case LibraryVariableSetResource:
+		return o.LibraryVariableSetUrl(resourceId)
+	case TargetResource:
+		return o.TargetUrl(resourceId)
+	case EnvironmentResource:
+		return o.EnvironmentUrl(resourceId)
+	case AccountResource:
+		return o.AccountUrl(resourceId)
+	case TenantResource:
+		return o.TenantUrl(resourceId)
+	case FeedResource:
+		return o.FeedUrl(resourceId)
+	case CertificateResource:
+		return o.CertificateUrl(resourceId)
+	case LifecycleResource:
+		return o.LifecycleUrl(resourceId)
+	case SubscriptionResource:
+		return o.SubscriptionUrl(resourceId)
+	case WorkerPoolResource:
+		return o.WorkerPoolUrl(resourceId)
+	case UserResource:
+		return o.UserUrl(resourceId)
+	case TeamResource:
+		return o.TeamUrl(resourceId)
+	case UserRoleResource:
+		return o.UserRoleUrl(resourceId)
+	}
+
+	return ""
+}
+
+func (o OctopusUrlBuilder) spaceUrl(path string) string {
+	return o.url + "/app#/" + o.space + path
+}
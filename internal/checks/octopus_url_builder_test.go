@@ -0,0 +1,31 @@
+package checks
+
+import "testing"
+
+func TestUrlBuilderTrimsTrailingSlash(t *testing.T) {
+	builder := NewOctopusUrlBuilder("https://example.octopus.app/", "Spaces-1")
+
+	if builder.ProjectUrl("Projects-1") != "https://example.octopus.app/app#/Spaces-1/projects/Projects-1/deployments" {
+		t.Fatal("Should have built the project URL")
+	}
+}
+
+func TestUrlBuilderResourceUrl(t *testing.T) {
+	builder := NewOctopusUrlBuilder("https://example.octopus.app", "Spaces-1")
+
+	if builder.ResourceUrl(TargetResource, "Machines-1") != "https://example.octopus.app/app#/Spaces-1/infrastructure/machines/Machines-1/settings" {
+		t.Fatal("Should have built the target URL")
+	}
+
+	if builder.ResourceUrl(UserResource, "Users-1") != "https://example.octopus.app/app#/configuration/users/Users-1" {
+		t.Fatal("User URLs must not include the space")
+	}
+
+	if builder.ResourceUrl(VariableResource, "Variables-1") != "" {
+		t.Fatal("Resources without a page in the portal must not have a URL")
+	}
+
+	if builder.ResourceUrl(ProjectResource, "") != "" {
+		t.Fatal("Resources without an ID must not have a URL")
+	}
+}
@@ -1,34 +1,40 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 )
 
+const OctoLintDefaultProjectGroupChildCount = "OctoLintDefaultProjectGroupChildCount"
+
 const maxProjectsInDefaultGroup = 10
 
 // OctopusDefaultProjectGroupCountCheck checks to see if the default project group contains too many projects. This is
 // usually an indication that additional projects groups should be created to organize the dashboard.
 type OctopusDefaultProjectGroupCountCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusDefaultProjectGroupCountCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDefaultProjectGroupCountCheck {
-	return OctopusDefaultProjectGroupCountCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDefaultProjectGroupCountCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusDefaultProjectGroupCountCheck {
+	return OctopusDefaultProjectGroupCountCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusDefaultProjectGroupCountCheck) Id() string {
-	return "OctoLintDefaultProjectGroupChildCount"
+	return OctoLintDefaultProjectGroupChildCount
 }
 
-func (o OctopusDefaultProjectGroupCountCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusDefaultProjectGroupCountCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -39,43 +45,52 @@ func (o OctopusDefaultProjectGroupCountCheck) Execute(concurrency int) (checks.O
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	resource, err := o.client.ProjectGroups.GetByName("Default Project Group")
+	projectGroups, err := o.client.GetProjectGroups(ctx)
 
 	if err != nil {
-		apiError, ok := err.(*core.APIError)
-		if (ok && apiError.StatusCode == 404) || err.Error() == "cannot find the item" {
-			return checks.NewOctopusCheckResultImpl(
-				"The default project group was not found",
-				o.Id(),
-				"",
-				checks.Ok,
-				checks.Organization), nil
-		}
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	if resource != nil {
+	resource, found := lo.Find(projectGroups, func(item *projectgroups.ProjectGroup) bool {
+		return item.Name == "Default Project Group"
+	})
+
+	if !found {
+		return checks.NewOctopusCheckResultImpl(
+			"The default project group was not found",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Ok,
+			checks.Organization), nil
+	}
 
-		projects, err := o.client.ProjectGroups.GetProjects(resource)
+	projects, err := o.client.GetProjectGroupProjects(ctx, resource)
 
-		if err != nil {
-			return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
-		}
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
+	}
 
-		if len(projects) > maxProjectsInDefaultGroup {
-			return checks.NewOctopusCheckResultImpl(
-				"The default project group contains "+fmt.Sprint(len(projects))+" projects. You may want to organize these projects into additional project groups.",
-				o.Id(),
-				"",
-				checks.Warning,
-				checks.Organization), nil
-		}
+	if len(projects) > o.config.CheckInt(o.Id(), "maxProjectsInDefaultGroup", maxProjectsInDefaultGroup) {
+		return checks.NewOctopusCheckResultWithFindings(
+			"The default project group contains "+fmt.Sprint(len(projects))+" projects. You may want to organize these projects into additional project groups.",
+			o.Id(),
+			checks.WikiLink(o.Id()),
+			checks.Warning,
+			checks.Organization,
+			[]checks.OctopusCheckFinding{{
+				ResourceType: checks.ProjectGroupResource,
+				ResourceId:   resource.ID,
+				ResourceName: resource.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Details:      fmt.Sprint(len(projects)) + " projects",
+				Link:         o.urlBuilder.ProjectGroupUrl(resource.ID),
+			}}), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"The number of projects in the default project group is OK",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
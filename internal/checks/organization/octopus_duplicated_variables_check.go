@@ -3,13 +3,12 @@ package organization
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/mathext"
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
@@ -31,21 +30,23 @@ type projectVar struct {
 // OctopusDuplicatedVariablesCheck checks for variables with the same value across projects. This may be an indication
 // that library variable sets should be used to capture shared values.
 type OctopusDuplicatedVariablesCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 	mu           sync.Mutex
 }
 
-func NewOctopusDuplicatedVariablesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) *OctopusDuplicatedVariablesCheck {
-	return &OctopusDuplicatedVariablesCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDuplicatedVariablesCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) *OctopusDuplicatedVariablesCheck {
+	return &OctopusDuplicatedVariablesCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o *OctopusDuplicatedVariablesCheck) Id() string {
 	return OctoLintDuplicatedVariables
 }
 
-func (o *OctopusDuplicatedVariablesCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o *OctopusDuplicatedVariablesCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -56,35 +57,48 @@ func (o *OctopusDuplicatedVariablesCheck) Execute(concurrency int) (checks.Octop
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	maxProjects := o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxDuplicateVariableProjects)
+	maxVariables := o.config.CheckInt(o.Id(), "maxVariables", o.config.MaxDuplicateVariables)
+
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxDuplicateVariableProjects)
+		maxProjects)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
 	goroutineErrors := threadsafe.NewSlice[error]()
 
 	projectVars := map[*projects2.Project]variables.VariableSet{}
+	progress := checks.Progress(ctx)
+	if maxProjects != 0 {
+		progress.AddTotal(mathext.MinInt(len(projects), maxProjects))
+	} else {
+		progress.AddTotal(len(projects))
+	}
+
 	for i, p := range projects {
 		i := i
 		p := p
 
-		if o.config.MaxDuplicateVariableProjects != 0 && i >= o.config.MaxDuplicateVariableProjects {
+		if maxProjects != 0 && i >= maxProjects {
 			break
 		}
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
 
-			variableSet, err := o.client.Variables.GetAll(p.ID)
+			variableSet, err := o.cache.GetVariables(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -118,7 +132,7 @@ OuterLoop:
 	for index1 := 0; index1 < len(projects); index1++ {
 		project1 := projects[index1]
 		for _, variable1 := range projectVars[project1].Variables {
-			if o.config.MaxDuplicateVariables != 0 && len(duplicateVars) >= o.config.MaxDuplicateVariables {
+			if maxVariables != 0 && len(duplicateVars) >= maxVariables {
 				break OuterLoop
 			}
 
@@ -143,23 +157,34 @@ OuterLoop:
 	}
 
 	if len(duplicateVars) > 0 {
-		messages := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for _, variable := range duplicateVars {
-			messages = append(messages, variable.project1.Name+"/"+variable.variable1.Name+" == "+variable.project2.Name+"/"+variable.variable2.Name)
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.VariableResource,
+				ResourceId:   variable.variable1.ID,
+				ResourceName: variable.variable1.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				ParentType:   checks.ProjectResource,
+				ParentId:     variable.project1.ID,
+				ParentName:   variable.project1.Name,
+				Details:      "duplicated by " + variable.project2.Name + "/" + variable.variable2.Name,
+				Link:         o.urlBuilder.ProjectVariablesUrl(variable.project1.ID),
+			})
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following variables are duplicated between projects. Consider moving these into library variable sets:\n"+strings.Join(messages, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following variables are duplicated between projects. Consider moving these into library variable sets:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no duplicated variables",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -3,8 +3,7 @@ package organization
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -12,27 +11,28 @@ import (
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"strings"
 )
 
 const OctoLintEmptyProject = "OctoLintEmptyProject"
 
 // OctopusEmptyProjectCheck checks for projects with no steps and no runbooks.
 type OctopusEmptyProjectCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusEmptyProjectCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusEmptyProjectCheck {
-	return OctopusEmptyProjectCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusEmptyProjectCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusEmptyProjectCheck {
+	return OctopusEmptyProjectCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusEmptyProjectCheck) Id() string {
 	return OctoLintEmptyProject
 }
 
-func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusEmptyProjectCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -43,33 +43,47 @@ func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckR
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxEmptyProjectCheckProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxEmptyProjectCheckProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	runbooks, err := o.client.Runbooks.GetAll()
+	runbooks, err := o.client.GetRunbooks(ctx)
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	emptyProjects := threadsafe.NewSlice[string]()
+	emptyProjects := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
+
+			stepCount, err := o.stepsInDeploymentProcess(ctx, p)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				}
+				return nil
+			}
 
-			stepCount, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			runbookCount, err := o.runbooksInProject(ctx, p, runbooks)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -78,8 +92,14 @@ func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckR
 				return nil
 			}
 
-			if runbooksInProject(p.ID, runbooks) == 0 && stepCount == 0 {
-				emptyProjects.Append(p.Name)
+			if runbookCount == 0 && stepCount == 0 {
+				emptyProjects.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectResource,
+					ResourceId:   p.ID,
+					ResourceName: p.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Link:         o.urlBuilder.ProjectUrl(p.ID),
+				})
 			}
 
 			return nil
@@ -96,38 +116,51 @@ func (o OctopusEmptyProjectCheck) Execute(concurrency int) (checks.OctopusCheckR
 	}
 
 	if emptyProjects.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects have no runbooks and no deployment process:\n"+strings.Join(emptyProjects.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following projects have no runbooks and no deployment process:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			emptyProjects.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no empty projects",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func runbooksInProject(projectID string, runbooks []*runbooks.Runbook) int {
+// runbooksInProject counts the runbooks in the project. Runbooks stored in Git are not included in the list of all
+// runbooks, so the runbooks of version controlled projects are read from the project.
+func (o OctopusEmptyProjectCheck) runbooksInProject(ctx context.Context, project *projects.Project, runbooks []*runbooks.Runbook) (int, error) {
+	if project.IsVersionControlled {
+		projectRunbooks, err := o.cache.GetProjectRunbooks(ctx, project)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return len(projectRunbooks), nil
+	}
+
 	count := 0
 	for _, r := range runbooks {
-		if r.ProjectID == projectID {
+		if r.ProjectID == project.ID {
 			count++
 		}
 	}
-	return count
+	return count, nil
 }
 
-func (o OctopusEmptyProjectCheck) stepsInDeploymentProcess(deploymentProcessID string) (int, error) {
-	if deploymentProcessID == "" {
+func (o OctopusEmptyProjectCheck) stepsInDeploymentProcess(ctx context.Context, project *projects.Project) (int, error) {
+	if project.DeploymentProcessID == "" {
 		return 0, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
 
 	if err != nil {
 		return 0, err
@@ -1,11 +1,11 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
 )
@@ -14,20 +14,22 @@ const OctopusEnvironmentCountCheckName = "OctoLintEnvironmentCount"
 
 // OctopusEnvironmentCountCheck checks to see if too many environments have been created in a space.
 type OctopusEnvironmentCountCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusEnvironmentCountCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusEnvironmentCountCheck {
-	return OctopusEnvironmentCountCheck{client: client, errorHandler: errorHandler, config: config}
+func NewOctopusEnvironmentCountCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusEnvironmentCountCheck {
+	return OctopusEnvironmentCountCheck{client: client, cache: cache, errorHandler: errorHandler, config: config, urlBuilder: urlBuilder}
 }
 
 func (o OctopusEnvironmentCountCheck) Id() string {
 	return OctopusEnvironmentCountCheckName
 }
 
-func (o OctopusEnvironmentCountCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusEnvironmentCountCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -38,22 +40,19 @@ func (o OctopusEnvironmentCountCheck) Execute(concurrency int) (checks.OctopusCh
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	query := environments.EnvironmentsQuery{
-		PartialName: "",
-		Skip:        0,
-		Take:        1000,
-	}
-	resources, err := o.client.Environments.Get(query)
+	maxEnvironments := o.config.CheckInt(o.Id(), "maxEnvironments", o.config.MaxEnvironments)
+
+	resources, err := o.client.GetEnvironments(ctx, 1000)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	if len(resources.Items) > o.config.MaxEnvironments {
+	if len(resources) > maxEnvironments {
 		return checks.NewOctopusCheckResultImpl(
-			"The recommended maximum number of environments is "+fmt.Sprint(o.config.MaxEnvironments)+". You have at least "+fmt.Sprint(len(resources.Items)),
+			"The recommended maximum number of environments is "+fmt.Sprint(maxEnvironments)+". You have at least "+fmt.Sprint(len(resources)),
 			o.Id(),
-			"https://octopus.com/docs/getting-started/best-practices/environments-and-deployment-targets-and-roles#environments",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
 			checks.Organization), nil
 	}
@@ -61,7 +60,7 @@ func (o OctopusEnvironmentCountCheck) Execute(concurrency int) (checks.OctopusCh
 	return checks.NewOctopusCheckResultImpl(
 		"The number of environments in the space is OK",
 		o.Id(),
-		"https://octopus.com/docs/getting-started/best-practices/environments-and-deployment-targets-and-roles#environments",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,9 +1,11 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
@@ -27,9 +29,10 @@ func TestNormalEnvironmentCount(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusEnvironmentCountCheck(newSpaceClient, &config.OctolintConfig{MaxEnvironments: 10}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusEnvironmentCountCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{MaxEnvironments: 10}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -60,9 +63,10 @@ func TestExcessiveEnvironmentCount(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusEnvironmentCountCheck(newSpaceClient, &config.OctolintConfig{MaxEnvironments: 10}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusEnvironmentCountCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{MaxEnvironments: 10}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
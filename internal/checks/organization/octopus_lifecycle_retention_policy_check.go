@@ -1,31 +1,32 @@
 package organization
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
-	"strings"
 )
 
 type OctopusLifecycleRetentionPolicyCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusLifecycleRetentionPolicyCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusLifecycleRetentionPolicyCheck {
-	return OctopusLifecycleRetentionPolicyCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusLifecycleRetentionPolicyCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusLifecycleRetentionPolicyCheck {
+	return OctopusLifecycleRetentionPolicyCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusLifecycleRetentionPolicyCheck) Id() string {
 	return "OctoRecLifecycleRetention"
 }
 
-func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusLifecycleRetentionPolicyCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -36,15 +37,18 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.O
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	lifecycles, err := o.client.Lifecycles.GetAll()
+	lifecycles, err := o.cache.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	keepsForever := []string{}
-	for i, l := range lifecycles {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(lifecycles))*100) + "% complete")
+	keepsForever := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(lifecycles))
+
+	for _, l := range lifecycles {
+		progress.Complete(1)
 
 		phaseKeepsForever, err := o.anyPhasesKeepForever(l.Phases)
 
@@ -58,23 +62,30 @@ func (o OctopusLifecycleRetentionPolicyCheck) Execute(concurrency int) (checks.O
 		lifecycleKeepsForever := l.ReleaseRetentionPolicy.ShouldKeepForever || l.TentacleRetentionPolicy.ShouldKeepForever
 
 		if lifecycleKeepsForever || phaseKeepsForever {
-			keepsForever = append(keepsForever, l.Name)
+			keepsForever = append(keepsForever, checks.OctopusCheckFinding{
+				ResourceType: checks.LifecycleResource,
+				ResourceId:   l.ID,
+				ResourceName: l.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Link:         o.urlBuilder.LifecycleUrl(l.ID),
+			})
 		}
 	}
 
 	if len(keepsForever) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following lifecycles have retention policies that keep releases or files forever:\n"+strings.Join(keepsForever, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following lifecycles have retention policies that keep releases or files forever:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			keepsForever), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no lifecycles with retention policies that keep releases or files forever",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
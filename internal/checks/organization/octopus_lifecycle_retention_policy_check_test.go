@@ -1,9 +1,11 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
@@ -47,9 +49,10 @@ func TestLifecyclesMeetRecommendations(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusLifecycleRetentionPolicyCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		// Assert
 		if result.Severity() != checks.Ok {
@@ -81,9 +84,10 @@ func TestLifecycleKeepsReleasesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusLifecycleRetentionPolicyCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -119,9 +123,10 @@ func TestLifecycleKeepsFilesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusLifecycleRetentionPolicyCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -157,9 +162,10 @@ func TestLifecyclePhaseKeepsReleasesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusLifecycleRetentionPolicyCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -195,9 +201,10 @@ func TestLifecyclePhaseKeepsFilesForever(t *testing.T) {
 			return err
 		}
 
-		check := NewOctopusLifecycleRetentionPolicyCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusLifecycleRetentionPolicyCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -3,8 +3,6 @@ package organization
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -13,27 +11,28 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
-	"strings"
 )
 
 const OctoLintProjectGroupsWithExclusiveEnvironments = "OctoLintProjectGroupsWithExclusiveEnvironments"
 
 // OctopusProjectGroupsWithExclusiveEnvironmentsCheck checks to see if the project groups contain projects that have mutually exclusive environments.
 type OctopusProjectGroupsWithExclusiveEnvironmentsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectGroupsWithExclusiveEnvironmentsCheck {
-	return OctopusProjectGroupsWithExclusiveEnvironmentsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusProjectGroupsWithExclusiveEnvironmentsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectGroupsWithExclusiveEnvironmentsCheck {
+	return OctopusProjectGroupsWithExclusiveEnvironmentsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Id() string {
 	return OctoLintProjectGroupsWithExclusiveEnvironments
 }
 
-func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,42 +43,46 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	allProjectGroups, err := o.client.ProjectGroups.GetAll()
+	allProjectGroups, err := o.client.GetProjectGroups(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allProjects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	allProjects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxExclusiveEnvironmentsProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxExclusiveEnvironmentsProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allLifecycles, err := o.client.Lifecycles.GetAll()
+	allLifecycles, err := o.cache.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	projectGroupsWithExclusiveEnvs := threadsafe.NewSlice[string]()
+	projectGroupsWithExclusiveEnvs := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 
-	for i, pg := range allProjectGroups {
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allProjectGroups))
+
+	for _, pg := range allProjectGroups {
 
-		i := i
 		pg := pg
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjectGroups))*100) + "% complete")
+			defer progress.Complete(1)
 
 			// Find the groups of environments captured in the default lifecyles of the projects in the project group
 			envGroups := [][]string{}
@@ -110,8 +113,15 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 				}
 
 				// if none of the environments from this lifecycle are found in any other lifecycles, we have an project with exclusive environments
-				if allExclusive && !projectGroupsWithExclusiveEnvs.Contains(pg.Name) {
-					projectGroupsWithExclusiveEnvs.Append(pg.Name)
+				if allExclusive {
+					projectGroupsWithExclusiveEnvs.Append(checks.OctopusCheckFinding{
+						ResourceType: checks.ProjectGroupResource,
+						ResourceId:   pg.ID,
+						ResourceName: pg.Name,
+						SpaceId:      o.client.GetSpaceID(),
+						Link:         o.urlBuilder.ProjectGroupUrl(pg.ID),
+					})
+					break
 				}
 			}
 
@@ -124,18 +134,19 @@ func (o OctopusProjectGroupsWithExclusiveEnvironmentsCheck) Execute(concurrency
 	}
 
 	if projectGroupsWithExclusiveEnvs.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following project groups contain projects with mutually exclusive environments in their default lifecycle:\n"+strings.Join(projectGroupsWithExclusiveEnvs.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following project groups contain projects with mutually exclusive environments in their default lifecycle:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			projectGroupsWithExclusiveEnvs.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no project groups with mutually exclusive lifecycles",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,37 +1,38 @@
 package organization
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
-	"strings"
 )
 
 const OctoLintProjectSpecificEnvs = "OctoLintProjectSpecificEnvs"
 
 // OctopusProjectSpecificEnvironmentCheck checks to see if any project variables are unused.
 type OctopusProjectSpecificEnvironmentCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectSpecificEnvironmentCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectSpecificEnvironmentCheck {
-	return OctopusProjectSpecificEnvironmentCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusProjectSpecificEnvironmentCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectSpecificEnvironmentCheck {
+	return OctopusProjectSpecificEnvironmentCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusProjectSpecificEnvironmentCheck) Id() string {
 	return OctoLintProjectSpecificEnvs
 }
 
-func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectSpecificEnvironmentCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -42,39 +43,41 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxProjectSpecificEnvironmentProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxProjectSpecificEnvironmentProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allLifecycles, err := o.client.Lifecycles.GetAll()
+	allLifecycles, err := o.cache.GetLifecycles(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allEnvironments, err := client_wrapper.GetEnvironments(o.config.MaxProjectSpecificEnvironmentEnvironments, o.client, o.client.GetSpaceID())
+	allEnvironments, err := o.cache.GetEnvironments(ctx, o.config.CheckInt(o.Id(), "maxEnvironments", o.config.MaxProjectSpecificEnvironmentEnvironments))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allChannels, err := o.client.Channels.GetAll()
+	allChannels, err := o.client.GetChannels(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
 	// count the number of times an environment is referenced by a project
-	environmentCount := map[string][]string{}
-	for i, p := range projects {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+	environmentCount := map[string][]*projects2.Project{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
+		progress.Complete(1)
 
 		projectEnvironments := []string{}
 
@@ -120,16 +123,16 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 			}
 
 			if _, ok := environmentCount[env]; !ok {
-				environmentCount[env] = []string{}
+				environmentCount[env] = []*projects2.Project{}
 			}
-			environmentCount[env] = append(environmentCount[env], p.Name)
+			environmentCount[env] = append(environmentCount[env], p)
 			processedEnvironments = append(processedEnvironments, env)
 		}
 
 	}
 
 	// filter down to allEnvironments that have one project
-	singleProjectEnvironments := map[string]string{}
+	singleProjectEnvironments := map[string]*projects2.Project{}
 	for env, envProjects := range environmentCount {
 		if len(envProjects) == 1 {
 			singleProjectEnvironments[env] = envProjects[0]
@@ -137,27 +140,37 @@ func (o OctopusProjectSpecificEnvironmentCheck) Execute(concurrency int) (checks
 	}
 
 	if len(singleProjectEnvironments) > 0 {
-		messages := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for env, envProject := range singleProjectEnvironments {
 			environment := o.getEnvironmentById(allEnvironments, env)
 			if environment == nil {
 				continue
 			}
-			messages = append(messages, environment.Name+" ("+envProject+")")
+			findings = append(findings, checks.OctopusCheckFinding{
+				ResourceType: checks.EnvironmentResource,
+				ResourceId:   environment.ID,
+				ResourceName: environment.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				ParentType:   checks.ProjectResource,
+				ParentId:     envProject.ID,
+				ParentName:   envProject.Name,
+				Link:         o.urlBuilder.EnvironmentUrl(environment.ID),
+			})
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following environments are used by a single project:\n"+strings.Join(messages, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following environments are used by a single project:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no single project environments",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
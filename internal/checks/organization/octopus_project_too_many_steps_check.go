@@ -4,15 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"strings"
 )
 
 const maxStepCount = 20
@@ -20,20 +19,22 @@ const OctoLintTooManySteps = "OctoLintTooManySteps"
 
 // OctopusProjectTooManyStepsCheck checks to see if any project has too many steps.
 type OctopusProjectTooManyStepsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusProjectTooManyStepsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusProjectTooManyStepsCheck {
-	return OctopusProjectTooManyStepsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusProjectTooManyStepsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusProjectTooManyStepsCheck {
+	return OctopusProjectTooManyStepsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusProjectTooManyStepsCheck) Id() string {
 	return OctoLintTooManySteps
 }
 
-func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusProjectTooManyStepsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,33 +45,39 @@ func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.Octopu
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	maxSteps := o.config.CheckInt(o.Id(), "maxSteps", maxStepCount)
+
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxProjectStepsProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxProjectStepsProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	complexProjects := threadsafe.NewSlice[string]()
+	complexProjects := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 
-		i := i
 		p := p
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			defer progress.Complete(1)
 
-			stepCount, err := o.stepsInDeploymentProcess(p.DeploymentProcessID)
+			stepCount, err := o.stepsInDeploymentProcess(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -79,8 +86,15 @@ func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.Octopu
 				return nil
 			}
 
-			if stepCount >= maxStepCount {
-				complexProjects.Append(p.Name)
+			if stepCount >= maxSteps {
+				complexProjects.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectResource,
+					ResourceId:   p.ID,
+					ResourceName: p.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Details:      fmt.Sprint(stepCount) + " steps",
+					Link:         o.urlBuilder.ProjectUrl(p.ID),
+				})
 			}
 
 			return nil
@@ -97,32 +111,33 @@ func (o OctopusProjectTooManyStepsCheck) Execute(concurrency int) (checks.Octopu
 	}
 
 	if complexProjects.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects have 20 or more steps:\n"+strings.Join(complexProjects.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following projects have "+fmt.Sprint(maxSteps)+" or more steps:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			complexProjects.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no projects with too many steps",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o OctopusProjectTooManyStepsCheck) stepsInDeploymentProcess(deploymentProcessID string) (int, error) {
-	if deploymentProcessID == "" {
+func (o OctopusProjectTooManyStepsCheck) stepsInDeploymentProcess(ctx context.Context, project *projects.Project) (int, error) {
+	if project.DeploymentProcessID == "" {
 		return 0, nil
 	}
 
-	resource, err := o.client.DeploymentProcesses.GetByID(deploymentProcessID)
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
 
 	if err != nil {
 		// If we can't find the deployment process, assume zero steps
-		if err.(*core.APIError).StatusCode == 404 {
+		if apiError, ok := err.(*core.APIError); ok && apiError.StatusCode == 404 {
 			return 0, nil
 		}
 		return 0, err
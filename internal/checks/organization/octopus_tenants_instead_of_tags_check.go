@@ -1,9 +1,8 @@
 package organization
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
@@ -18,20 +17,22 @@ const OctoLintDirectTenantReferences = "OctoLintDirectTenantReferences"
 
 // OctopusTenantsInsteadOfTagsCheck checks to see if any common groups of tenants are found against common resources like accounts, targets etc
 type OctopusTenantsInsteadOfTagsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusTenantsInsteadOfTagsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusTenantsInsteadOfTagsCheck {
-	return OctopusTenantsInsteadOfTagsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusTenantsInsteadOfTagsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusTenantsInsteadOfTagsCheck {
+	return OctopusTenantsInsteadOfTagsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusTenantsInsteadOfTagsCheck) Id() string {
 	return OctoLintDirectTenantReferences
 }
 
-func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusTenantsInsteadOfTagsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -42,53 +43,56 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	allTenants, err := client_wrapper.GetTenants(o.config.MaxTenantTagsTenants, o.client, o.client.GetSpaceID())
+	allTenants, err := o.cache.GetTenants(ctx, o.config.CheckInt(o.Id(), "maxTenants", o.config.MaxTenantTagsTenants))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allAccounts, err := o.client.Accounts.GetAll()
+	allAccounts, err := o.client.GetAccounts(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allCertificates, err := o.client.Certificates.GetAll()
+	allCertificates, err := o.client.GetCertificates(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxTenantTagsTargets, o.client, o.client.GetSpaceID())
+	allMachines, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", o.config.MaxTenantTagsTargets))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allAccounts) + len(allCertificates) + len(allMachines))
+
 	tenantReferenceCounts := map[string]int{}
-	tenantReferenceSources := map[string][]string{}
-	for i, a := range allAccounts {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allAccounts))*33) + "% complete")
+	tenantReferenceSources := map[string][]checks.OctopusCheckFinding{}
+	for _, a := range allAccounts {
+		progress.Complete(1)
 
 		if a.GetTenantedDeploymentMode() == core.TenantedDeploymentModeTenantedOrUntenanted {
-			o.addTenants(a.GetTenantIDs(), "Account - "+a.GetName(), tenantReferenceCounts, tenantReferenceSources)
+			o.addTenants(a.GetTenantIDs(), checks.AccountResource, a.GetID(), a.GetName(), tenantReferenceCounts, tenantReferenceSources)
 		}
 	}
 
-	for i, c := range allCertificates {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allCertificates))*33) + "% complete")
+	for _, c := range allCertificates {
+		progress.Complete(1)
 
 		if c.TenantedDeploymentMode == core.TenantedDeploymentModeTenantedOrUntenanted {
-			o.addTenants(c.TenantIDs, "Certificate - "+c.Name, tenantReferenceCounts, tenantReferenceSources)
+			o.addTenants(c.TenantIDs, checks.CertificateResource, c.ID, c.Name, tenantReferenceCounts, tenantReferenceSources)
 		}
 	}
 
-	for i, m := range allMachines {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*33) + "% complete")
+	for _, m := range allMachines {
+		progress.Complete(1)
 
 		if m.TenantedDeploymentMode == core.TenantedDeploymentModeTenantedOrUntenanted {
-			o.addTenants(m.TenantIDs, "Target - "+m.Name, tenantReferenceCounts, tenantReferenceSources)
+			o.addTenants(m.TenantIDs, checks.TargetResource, m.ID, m.Name, tenantReferenceCounts, tenantReferenceSources)
 		}
 	}
 
@@ -103,28 +107,33 @@ func (o OctopusTenantsInsteadOfTagsCheck) Execute(concurrency int) (checks.Octop
 	if len(multipleTenantReferences) > 0 {
 
 		// We have to convert the comma separated list of tenant IDs into a comma separated list of tenant names
-		groupedTenants := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for _, groupedTenant := range multipleTenantReferences {
 			splitTenants := strings.Split(groupedTenant, ",")
 			splitTenantNames := []string{}
 			for _, splitTenant := range splitTenants {
 				splitTenantNames = append(splitTenantNames, o.getTenantNameById(allTenants, splitTenant))
 			}
-			groupedTenants = append(groupedTenants, strings.Join(splitTenantNames, ", ")+" ("+strings.Join(tenantReferenceSources[groupedTenant], ", ")+")")
+
+			for _, source := range tenantReferenceSources[groupedTenant] {
+				source.Details = strings.Join(splitTenantNames, ", ")
+				findings = append(findings, source)
+			}
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following groups of tenants have been directly referenced more than once, and may be better grouped as tenant tags:\n"+strings.Join(groupedTenants, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following resources reference groups of tenants that have been directly referenced more than once, and may be better grouped as tenant tags:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No duplicate groups of tenants were found",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -139,13 +148,15 @@ func (o OctopusTenantsInsteadOfTagsCheck) getTenantNameById(tenants []*tenants.T
 	return ""
 }
 
-func (o OctopusTenantsInsteadOfTagsCheck) addTenants(tenantIds []string, source string, tenantReferences map[string]int, tenantReferenceSources map[string][]string) {
+func (o OctopusTenantsInsteadOfTagsCheck) addTenants(tenantIds []string, resourceType string, resourceId string, resourceName string, tenantReferences map[string]int, tenantReferenceSources map[string][]checks.OctopusCheckFinding) {
 	if len(tenantIds) <= 1 {
 		return
 	}
 
-	slices.Sort(tenantIds)
-	tenants := strings.Join(tenantIds, ",")
+	// The tenant IDs belong to cached resources, so sort a copy
+	sortedTenantIds := slices.Clone(tenantIds)
+	slices.Sort(sortedTenantIds)
+	tenants := strings.Join(sortedTenantIds, ",")
 
 	if _, ok := tenantReferences[tenants]; !ok {
 		tenantReferences[tenants] = 0
@@ -153,7 +164,13 @@ func (o OctopusTenantsInsteadOfTagsCheck) addTenants(tenantIds []string, source
 	tenantReferences[tenants]++
 
 	if _, ok := tenantReferenceSources[tenants]; !ok {
-		tenantReferenceSources[tenants] = []string{}
+		tenantReferenceSources[tenants] = []checks.OctopusCheckFinding{}
 	}
-	tenantReferenceSources[tenants] = append(tenantReferenceSources[tenants], source)
+	tenantReferenceSources[tenants] = append(tenantReferenceSources[tenants], checks.OctopusCheckFinding{
+		ResourceType: resourceType,
+		ResourceId:   resourceId,
+		ResourceName: resourceName,
+		SpaceId:      o.client.GetSpaceID(),
+		Link:         o.urlBuilder.ResourceUrl(resourceType, resourceId),
+	})
 }
@@ -3,8 +3,6 @@ package organization
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -12,29 +10,30 @@ import (
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"strings"
 	"time"
 )
 
-const maxHealthCheckTime = time.Hour * 24 * 30
+const maxDaysSinceHealthCheck = 30
 const OctoLintUnhealthyTargets = "OctoLintUnhealthyTargets"
 
 // OctopusUnhealthyTargetCheck find targets that have not been healthy in the last 30 days.
 type OctopusUnhealthyTargetCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusUnhealthyTargetCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnhealthyTargetCheck {
-	return OctopusUnhealthyTargetCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnhealthyTargetCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusUnhealthyTargetCheck {
+	return OctopusUnhealthyTargetCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusUnhealthyTargetCheck) Id() string {
 	return OctoLintUnhealthyTargets
 }
 
-func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusUnhealthyTargetCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -45,31 +44,38 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	allMachines, err := client_wrapper.GetMachines(o.config.MaxUnhealthyTargets, o.client, o.client.GetSpaceID())
+	maxTimeSinceHealthy := time.Hour * 24 * time.Duration(o.config.CheckInt(o.Id(), "maxDaysSinceHealthy", maxDaysSinceHealthCheck))
+
+	allMachines, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", o.config.MaxUnhealthyTargets))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	unhealthyMachines := threadsafe.NewSlice[string]()
+	unhealthyMachines := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, m := range allMachines {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allMachines))
+
+	for _, m := range allMachines {
 		m := m
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allMachines))*100) + "% complete")
+			defer progress.Complete(1)
 
 			wasEverHealthy := true
 			if m.HealthStatus == "Unhealthy" {
 				wasEverHealthy = false
 
-				targetEvents, err := o.client.Events.Get(events.EventsQuery{
+				targetEvents, err := o.client.GetEvents(ctx, events.EventsQuery{
 					Regarding: m.ID,
 				})
 
@@ -81,7 +87,7 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 				}
 
 				for _, e := range targetEvents.Items {
-					if e.Category == "MachineHealthy" && time.Now().Sub(e.Occurred) < maxHealthCheckTime {
+					if e.Category == "MachineHealthy" && o.client.Now().Sub(e.Occurred) < maxTimeSinceHealthy {
 						wasEverHealthy = true
 						break
 					}
@@ -89,7 +95,13 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 			}
 
 			if !wasEverHealthy {
-				unhealthyMachines.Append(m.Name)
+				unhealthyMachines.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.TargetResource,
+					ResourceId:   m.ID,
+					ResourceName: m.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Link:         o.urlBuilder.TargetUrl(m.ID),
+				})
 			}
 
 			return nil
@@ -106,18 +118,19 @@ func (o OctopusUnhealthyTargetCheck) Execute(concurrency int) (checks.OctopusChe
 	}
 
 	if unhealthyMachines.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following targets have not been healthy in the last 30 days:\n"+strings.Join(unhealthyMachines.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following targets have not been healthy in the last 30 days:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unhealthyMachines.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no targets that were unhealthy for all of the last 30 days",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"context"
 	"errors"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
@@ -58,9 +59,10 @@ func TestUnhealthyTargets(t *testing.T) {
 			}
 		}
 
-		check := NewOctopusUnhealthyTargetCheck(newSpaceClient, &config.OctolintConfig{}, checks.OctopusClientPermissiveErrorHandler{})
+		dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+		check := NewOctopusUnhealthyTargetCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder(container.URI, newSpaceId), checks.OctopusClientPermissiveErrorHandler{})
 
-		result, err := check.Execute(2)
+		result, err := check.Execute(context.Background(), 2)
 
 		if err != nil {
 			return err
@@ -76,7 +78,7 @@ func TestUnhealthyTargets(t *testing.T) {
 }
 
 func startHealthCheck(newSpaceClient *client.Client) error {
-	machines, err := client_wrapper.GetMachines(0, newSpaceClient, newSpaceClient.GetSpaceID())
+	machines, err := client_wrapper.GetMachines(context.Background(), 0, newSpaceClient, newSpaceClient.GetSpaceID())
 
 	if err != nil {
 		return err
@@ -106,7 +108,7 @@ func startHealthCheck(newSpaceClient *client.Client) error {
 }
 
 func checkMachinesUnhealthy(newSpaceClient *client.Client) (bool, error) {
-	machines, err := client_wrapper.GetMachines(0, newSpaceClient, newSpaceClient.GetSpaceID())
+	machines, err := client_wrapper.GetMachines(context.Background(), 0, newSpaceClient, newSpaceClient.GetSpaceID())
 
 	if err != nil {
 		return false, err
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -12,7 +11,6 @@ import (
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"strings"
 	"time"
 )
 
@@ -20,20 +18,22 @@ const OctopusUnusedProjectsCheckName = "OctoLintUnusedProjects"
 
 // OctopusUnusedProjectsCheck find projects that have not had a deployment in the last 30 days
 type OctopusUnusedProjectsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusUnusedProjectsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedProjectsCheck {
-	return OctopusUnusedProjectsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedProjectsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedProjectsCheck {
+	return OctopusUnusedProjectsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusUnusedProjectsCheck) Id() string {
 	return OctopusUnusedProjectsCheckName
 }
 
-func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusUnusedProjectsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,29 +44,36 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	maxDaysSinceLastTask := o.config.CheckInt(o.Id(), "maxDaysSinceLastTask", o.config.MaxDaysSinceLastTask)
+
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxUnusedProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxUnusedProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	unusedProjects := threadsafe.NewSlice[string]()
+	unusedProjects := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, project := range projects {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, project := range projects {
 		project := project
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
 
 			// Ignore disabled projects
 			if project.IsDisabled {
@@ -75,7 +82,7 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 
 			projectHasTask := false
 
-			tasks, err := o.client.Tasks.Get(tasks.TasksQuery{
+			tasks, err := o.client.GetTasks(ctx, tasks.TasksQuery{
 				Project: project.ID,
 				Skip:    0,
 				Take:    100,
@@ -87,14 +94,20 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 			}
 
 			for _, task := range tasks.Items {
-				if task.StartTime != nil && task.StartTime.After(time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask))) {
+				if task.StartTime != nil && task.StartTime.After(o.client.Now().Add(-time.Hour*24*time.Duration(maxDaysSinceLastTask))) {
 					projectHasTask = true
 					break
 				}
 			}
 
 			if !projectHasTask {
-				unusedProjects.Append(project.Name)
+				unusedProjects.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectResource,
+					ResourceId:   project.ID,
+					ResourceName: project.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Link:         o.urlBuilder.ProjectUrl(project.ID),
+				})
 			}
 
 			return nil
@@ -110,21 +123,22 @@ func (o OctopusUnusedProjectsCheck) Execute(concurrency int) (checks.OctopusChec
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
-	daysString := fmt.Sprintf("%d", o.config.MaxDaysSinceLastTask)
+	daysString := fmt.Sprintf("%d", maxDaysSinceLastTask)
 
 	if unusedProjects.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects have not had any tasks in "+daysString+" days:\n"+strings.Join(unusedProjects.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following projects have not had any tasks in "+daysString+" days:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedProjects.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no projects that have not had any tasks in the last "+daysString+" days",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -3,12 +3,7 @@ package organization
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -16,30 +11,31 @@ import (
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"regexp"
 	"strings"
 	"time"
 )
 
-const maxTimeSinceLastMachineDeployment = time.Hour * 24 * 30
+const maxDaysSinceLastMachineDeployment = 30
 const OctoLintUnusedTargets = "OctoLintUnusedTargets"
 
 // OctopusUnusedTargetsCheck checks to see if any targets have not been used in a month
 type OctopusUnusedTargetsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusUnusedTargetsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedTargetsCheck {
-	return OctopusUnusedTargetsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedTargetsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedTargetsCheck {
+	return OctopusUnusedTargetsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusUnusedTargetsCheck) Id() string {
 	return OctoLintUnusedTargets
 }
 
-func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusUnusedTargetsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -50,29 +46,35 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := client_wrapper.GetMachines(o.config.MaxUnusedTargets, o.client, o.client.GetSpaceID())
+	maxTimeSinceLastDeployment := time.Hour * 24 * time.Duration(o.config.CheckInt(o.Id(), "maxDaysSinceLastDeployment", maxDaysSinceLastMachineDeployment))
+
+	targets, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", o.config.MaxUnusedTargets))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	unusedMachines := threadsafe.NewSlice[string]()
+	unusedMachines := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	linksTemplate := regexp.MustCompile(`\{.+\}`)
-	for i, m := range targets {
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(targets))
+
+	for _, m := range targets {
 
-		i := i
 		m := m
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
 
-			tasksLink := linksTemplate.ReplaceAllString(m.Links["TasksTemplate"], "")
-			tasks, err := newclient.Get[resources.Resources[tasks.Task]](o.client.HttpSession(), tasksLink+"?type=Deployment")
+			tasks, err := o.client.GetMachineDeploymentTasks(ctx, m)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -82,15 +84,21 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 			}
 
 			recentTask := false
-			for _, t := range tasks.Items {
-				if t.CompletedTime != nil && time.Now().Sub(*t.CompletedTime) < maxTimeSinceLastMachineDeployment {
+			for _, t := range tasks {
+				if t.CompletedTime != nil && o.client.Now().Sub(*t.CompletedTime) < maxTimeSinceLastDeployment {
 					recentTask = true
 					break
 				}
 			}
 
 			if !recentTask {
-				unusedMachines.Append(m.Name)
+				unusedMachines.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.TargetResource,
+					ResourceId:   m.ID,
+					ResourceName: m.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Link:         o.urlBuilder.TargetUrl(m.ID),
+				})
 			}
 
 			return nil
@@ -107,18 +115,19 @@ func (o OctopusUnusedTargetsCheck) Execute(concurrency int) (checks.OctopusCheck
 	}
 
 	if unusedMachines.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following targets have not performed a deployment in 30 days:\n"+strings.Join(unusedMachines.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following targets have not performed a deployment in 30 days:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedMachines.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unused targets",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
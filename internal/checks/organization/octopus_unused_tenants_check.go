@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -12,7 +11,6 @@ import (
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"strings"
 	"time"
 )
 
@@ -20,20 +18,22 @@ const OctopusUnusedTenantsCheckName = "OctoLintUnusedTenants"
 
 // OctopusUnusedTenantsCheck find projects that have not had a deployment in the last 30 days
 type OctopusUnusedTenantsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusUnusedTenantsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedTenantsCheck {
-	return OctopusUnusedTenantsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedTenantsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusUnusedTenantsCheck {
+	return OctopusUnusedTenantsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusUnusedTenantsCheck) Id() string {
 	return OctopusUnusedTenantsCheckName
 }
 
-func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusUnusedTenantsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,27 +44,32 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	tenants, err := client_wrapper.GetTenants(
-		o.config.MaxUnusedTenants,
-		o.client,
-		o.client.GetSpaceID())
+	maxDaysSinceLastTask := o.config.CheckInt(o.Id(), "maxDaysSinceLastTask", o.config.MaxDaysSinceLastTask)
+
+	tenants, err := o.cache.GetTenants(ctx, o.config.CheckInt(o.Id(), "maxTenants", o.config.MaxUnusedTenants))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
-	unusedTenants := threadsafe.NewSlice[string]()
+	unusedTenants := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, tenant := range tenants {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(tenants))
+
+	for _, tenant := range tenants {
 		tenant := tenant
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(tenants))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
 
 			// Ignore disabled projects
 			if tenant.IsDisabled {
@@ -73,7 +78,7 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 
 			tenantHasTask := false
 
-			tasks, err := o.client.Tasks.Get(tasks.TasksQuery{
+			tasks, err := o.client.GetTasks(ctx, tasks.TasksQuery{
 				Tenant: tenant.ID,
 				Skip:   0,
 				Take:   100,
@@ -85,14 +90,20 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 			}
 
 			for _, task := range tasks.Items {
-				if task.StartTime != nil && task.StartTime.After(time.Now().Add(-time.Hour*24*time.Duration(o.config.MaxDaysSinceLastTask))) {
+				if task.StartTime != nil && task.StartTime.After(o.client.Now().Add(-time.Hour*24*time.Duration(maxDaysSinceLastTask))) {
 					tenantHasTask = true
 					break
 				}
 			}
 
 			if !tenantHasTask {
-				unusedTenants.Append(tenant.Name)
+				unusedTenants.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.TenantResource,
+					ResourceId:   tenant.ID,
+					ResourceName: tenant.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Link:         o.urlBuilder.TenantUrl(tenant.ID),
+				})
 			}
 
 			return nil
@@ -108,21 +119,22 @@ func (o OctopusUnusedTenantsCheck) Execute(concurrency int) (checks.OctopusCheck
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, goroutineErrors.Values()[0])
 	}
 
-	daysString := fmt.Sprintf("%d", o.config.MaxDaysSinceLastTask)
+	daysString := fmt.Sprintf("%d", maxDaysSinceLastTask)
 
 	if unusedTenants.Length() > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following tenants have not had any tasks in "+daysString+" days:\n"+strings.Join(unusedTenants.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following tenants have not had any tasks in "+daysString+" days:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			unusedTenants.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no tenants that have not had any tasks in the last "+daysString+" days",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
@@ -3,13 +3,8 @@ package organization
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	projects2 "github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -17,32 +12,31 @@ import (
 	"github.com/hayageek/threadsafe"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"regexp"
 	"strings"
 	"sync"
 )
 
 const OctoLintUnusedVariables = "OctoLintUnusedVariables"
 
-var linkOptions = regexp.MustCompile(`\{.*?}`)
-
 // OctopusUnusedVariablesCheck checks to see if any project variables are unused.
 type OctopusUnusedVariablesCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 	mu           sync.Mutex
 }
 
-func NewOctopusUnusedVariablesCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) *OctopusUnusedVariablesCheck {
-	return &OctopusUnusedVariablesCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnusedVariablesCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) *OctopusUnusedVariablesCheck {
+	return &OctopusUnusedVariablesCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o *OctopusUnusedVariablesCheck) Id() string {
 	return OctoLintUnusedVariables
 }
 
-func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o *OctopusUnusedVariablesCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -53,31 +47,36 @@ func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCh
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxUnusedVariablesProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxUnusedVariablesProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Organization, err)
 	}
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
 	unusedVars := map[*projects2.Project][]*variables.Variable{}
 	goroutineErrors := threadsafe.NewSlice[error]()
 
-	for i, p := range projects {
-		i := i
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 		p := p
 
 		g.Go(func() error {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			variableSet, err := o.client.Variables.GetAll(p.ID)
+			defer progress.Complete(1)
+
+			variableSet, err := o.cache.GetVariables(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -86,7 +85,7 @@ func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCh
 				return nil
 			}
 
-			deploymentSteps, err := o.getDeploymentSteps(p)
+			deploymentSteps, err := o.getDeploymentSteps(ctx, p)
 
 			if err != nil {
 				if !o.errorHandler.ShouldContinue(err) {
@@ -128,34 +127,42 @@ func (o *OctopusUnusedVariablesCheck) Execute(concurrency int) (checks.OctopusCh
 	}
 
 	if len(unusedVars) > 0 {
-		messages := []string{}
+		findings := []checks.OctopusCheckFinding{}
 		for p, variables := range unusedVars {
-			if len(variables) != 0 {
-				for _, variable := range variables {
-					messages = append(messages, p.Name+": "+variable.Name)
-				}
+			for _, variable := range variables {
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.VariableResource,
+					ResourceId:   variable.ID,
+					ResourceName: variable.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					ParentType:   checks.ProjectResource,
+					ParentId:     p.ID,
+					ParentName:   p.Name,
+					Link:         o.urlBuilder.ProjectVariablesUrl(p.ID),
+				})
 			}
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following variables may be unused (note there are edge cases octolint can't detect, so double check these before deleting them): \n"+strings.Join(messages, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following variables may be unused (note there are edge cases octolint can't detect, so double check these before deleting them):",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Organization), nil
+			checks.Organization,
+			findings), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unused variables",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Organization), nil
 }
 
-func (o *OctopusUnusedVariablesCheck) getDeploymentSteps(p *projects2.Project) ([]*deployments.DeploymentStep, error) {
+func (o *OctopusUnusedVariablesCheck) getDeploymentSteps(ctx context.Context, p *projects2.Project) ([]*deployments.DeploymentStep, error) {
 	deploymentProcesses := []*deployments.DeploymentStep{}
-	deploymentProcess, err := o.client.DeploymentProcesses.GetByID(p.DeploymentProcessID)
+	deploymentProcess, err := o.cache.GetDeploymentProcess(ctx, p)
 
 	if err != nil {
 		if !o.errorHandler.ShouldContinue(err) {
@@ -167,27 +174,25 @@ func (o *OctopusUnusedVariablesCheck) getDeploymentSteps(p *projects2.Project) (
 		}
 	}
 
-	if link, ok := p.Links["Runbooks"]; ok {
-		runbooks, err := newclient.Get[resources.Resources[runbooks.Runbook]](o.client.HttpSession(), linkOptions.ReplaceAllString(link, ""))
+	runbooks, err := o.cache.GetProjectRunbooks(ctx, p)
+
+	if err != nil {
+		if !o.errorHandler.ShouldContinue(err) {
+			return nil, err
+		}
+	}
+
+	for _, runbook := range runbooks {
+		runbookProcess, err := o.cache.GetRunbookProcess(ctx, p, runbook)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
 				return nil, err
 			}
-		}
-
-		for _, runbook := range runbooks.Items {
-			runbookProcess, err := o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID)
-
-			if err != nil {
-				if !o.errorHandler.ShouldContinue(err) {
-					return nil, err
-				}
-				continue
-			} else {
-				if runbookProcess != nil && runbookProcess.Steps != nil {
-					deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
-				}
+			continue
+		} else {
+			if runbookProcess != nil && runbookProcess.Steps != nil {
+				deploymentProcesses = append(deploymentProcesses, runbookProcess.Steps...)
 			}
 		}
 	}
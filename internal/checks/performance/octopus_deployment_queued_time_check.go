@@ -1,11 +1,12 @@
 package performance
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
@@ -35,22 +36,22 @@ func (d deploymentInfo) toFixed(precision int) float64 {
 
 // OctopusDeploymentQueuedTimeCheck checks to see if any deployments were queued for a long period of time
 type OctopusDeploymentQueuedTimeCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
-	url          string
-	space        string
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusDeploymentQueuedTimeCheck(client *client.Client, config *config.OctolintConfig, url string, space string, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedTimeCheck {
-	return OctopusDeploymentQueuedTimeCheck{config: config, client: client, url: url, space: space, errorHandler: errorHandler}
+func NewOctopusDeploymentQueuedTimeCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedTimeCheck {
+	return OctopusDeploymentQueuedTimeCheck{config: config, client: client, cache: cache, urlBuilder: urlBuilder, errorHandler: errorHandler}
 }
 
 func (o OctopusDeploymentQueuedTimeCheck) Id() string {
 	return OctoLintDeploymentQueuedTime
 }
 
-func (o OctopusDeploymentQueuedTimeCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusDeploymentQueuedTimeCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -61,10 +62,12 @@ func (o OctopusDeploymentQueuedTimeCheck) Execute(concurrency int) (checks.Octop
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	resource, err := o.client.Events.Get(events.EventsQuery{
+	maxQueueTime := o.config.CheckInt(o.Id(), "maxQueueTimeMinutes", maxQueueTimeMinutes)
+
+	resource, err := o.client.GetEvents(ctx, events.EventsQuery{
 		EventCategories: []string{"DeploymentQueued", "DeploymentStarted"},
 		Skip:            0,
-		Take:            o.config.MaxDeploymentTasks,
+		Take:            o.config.CheckInt(o.Id(), "maxTasks", o.config.MaxDeploymentTasks),
 	})
 
 	if err != nil {
@@ -73,15 +76,18 @@ func (o OctopusDeploymentQueuedTimeCheck) Execute(concurrency int) (checks.Octop
 
 	deployments := []deploymentInfo{}
 	if resource != nil {
-		for i, r := range resource.Items {
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(resource.Items))*100) + "% complete")
+		progress := checks.Progress(ctx)
+		progress.AddTotal(len(resource.Items))
+
+		for _, r := range resource.Items {
+			progress.Complete(1)
 
 			if r.Category == "DeploymentQueued" {
 				queuedDeploymentId := o.getDeploymentFromRelatedDocs(r)
 				for _, r2 := range resource.Items {
 					if r2.Category == "DeploymentStarted" && queuedDeploymentId == o.getDeploymentFromRelatedDocs(r2) {
 						queueTime := r2.Occurred.Sub(r.Occurred)
-						if queueTime.Minutes() > maxQueueTimeMinutes {
+						if queueTime.Minutes() > float64(maxQueueTime) {
 							deployments = append(deployments, deploymentInfo{
 								deploymentId: queuedDeploymentId,
 								duration:     queueTime.Minutes(),
@@ -94,34 +100,54 @@ func (o OctopusDeploymentQueuedTimeCheck) Execute(concurrency int) (checks.Octop
 		}
 	}
 
-	deploymentLinks := lo.Map(deployments, func(item deploymentInfo, index int) string {
-		deployment, err := o.client.Deployments.GetByID(item.deploymentId)
+	deploymentFindings := lo.Map(deployments, func(item deploymentInfo, index int) checks.OctopusCheckFinding {
+		finding := checks.OctopusCheckFinding{
+			ResourceType: checks.DeploymentResource,
+			ResourceId:   item.deploymentId,
+			ResourceName: item.deploymentId,
+			SpaceId:      o.client.GetSpaceID(),
+			Details:      item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m",
+		}
+
+		// The deployment is only used to build the link, so skip it if the check has run out of time
+		if ctx.Err() != nil {
+			return finding
+		}
+
+		deployment, err := o.client.GetDeployment(ctx, item.deploymentId)
 
 		if err != nil {
-			return item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
+			return finding
 		}
 
-		return o.url + "/app#/" + o.space + "/projects/" + deployment.ProjectID + "/deployments/releases/" + deployment.ReleaseID +
-			"/deployments/" + item.deploymentId + " (" + item.queuedAt.Format(time.RFC822) + " " + fmt.Sprint(item.toFixed(1)) + "m)"
+		finding.ParentType = checks.ProjectResource
+		finding.ParentId = deployment.ProjectID
+		finding.Link = o.urlBuilder.DeploymentUrl(deployment.ProjectID, deployment.ReleaseID, item.deploymentId)
+
+		return finding
 	})
 
-	if len(deployments) >= maxQueuedTasks {
-		return checks.NewOctopusCheckResultImpl(
-			fmt.Sprint("Found "+fmt.Sprint(len(deployments)))+" deployments that were queued for longer than "+fmt.Sprint(maxQueueTimeMinutes)+" minutes. Consider increasing the task cap or adding a HA node to reduce task queue times:\n"+
-				strings.Join(deploymentLinks, "\n"),
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(deployments) >= o.config.CheckInt(o.Id(), "maxQueuedTasks", maxQueuedTasks) {
+		return checks.NewOctopusCheckResultWithFindings(
+			fmt.Sprint("Found "+fmt.Sprint(len(deployments)))+" deployments that were queued for longer than "+fmt.Sprint(maxQueueTime)+" minutes. Consider increasing the task cap or adding a HA node to reduce task queue times:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Performance), nil
+			checks.Performance,
+			deploymentFindings), nil
 	}
 
-	return checks.NewOctopusCheckResultImpl(
-		"Found "+fmt.Sprint(len(deployments))+" deployment tasks that were queued for longer than "+fmt.Sprint(maxQueueTimeMinutes)+" minutes:\n"+
-			strings.Join(deploymentLinks, ", "),
+	return checks.NewOctopusCheckResultWithFindings(
+		"Found "+fmt.Sprint(len(deployments))+" deployment tasks that were queued for longer than "+fmt.Sprint(maxQueueTime)+" minutes:",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
-		checks.Performance), nil
+		checks.Performance,
+		deploymentFindings), nil
 }
 
 func (o OctopusDeploymentQueuedTimeCheck) getDeploymentFromRelatedDocs(event *events.Event) string {
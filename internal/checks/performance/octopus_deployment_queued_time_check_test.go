@@ -1,7 +1,9 @@
 package performance
 
 import (
+	"context"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
 	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/test"
@@ -1748,9 +1750,10 @@ func TestLongTaskQueue(t *testing.T) {
 
 	// Act
 	newSpaceClient, err := octoclient.CreateClient(server.URL, "Spaces-1", test.ApiKey)
-	check := NewOctopusDeploymentQueuedTimeCheck(newSpaceClient, &config.OctolintConfig{}, "http://test.app", "Spaces-1", checks.OctopusClientPermissiveErrorHandler{})
+	dataSource := client_wrapper.NewLiveDataSource(newSpaceClient, "")
+	check := NewOctopusDeploymentQueuedTimeCheck(dataSource, client_wrapper.NewOctopusClientCache(dataSource), &config.OctolintConfig{}, checks.NewOctopusUrlBuilder("http://test.app", "Spaces-1"), checks.OctopusClientPermissiveErrorHandler{})
 
-	result, err := check.Execute(2)
+	result, err := check.Execute(context.Background(), 2)
 
 	if err != nil {
 		t.Fatal("Check produced an error")
@@ -0,0 +1,252 @@
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/google/cel-go/cel"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+	"os"
+	"strings"
+)
+
+// The resource types that rules can inspect
+const (
+	ProjectRule    = "project"
+	ActionRule     = "action"
+	VariableRule   = "variable"
+	TargetRule     = "target"
+	TenantRule     = "tenant"
+	LifecycleRule  = "lifecycle"
+	FeedRule       = "feed"
+	AccountRule    = "account"
+	WorkerPoolRule = "workerpool"
+)
+
+// ResourceTypes lists the resource types that rules can inspect.
+var ResourceTypes = []string{ProjectRule, ActionRule, VariableRule, TargetRule, TenantRule, LifecycleRule, FeedRule, AccountRule, WorkerPoolRule}
+
+// resourceTypeAliases maps alternative names to the resource types
+var resourceTypeAliases = map[string]string{
+	"step":        ActionRule,
+	"machine":     TargetRule,
+	"worker_pool": WorkerPoolRule,
+}
+
+// ruleSeverityNames lists the severities a rule can report
+var ruleSeverityNames = []string{checks.ErrorSeverityName, checks.WarningSeverityName, checks.InfoSeverityName}
+
+// ruleCategories lists the categories a rule can report
+var ruleCategories = []string{checks.Organization, checks.Naming, checks.Security, checks.Performance, checks.Optimization}
+
+// The variables that can be referenced by the filter and condition expressions. The resource variable is the
+// resource being inspected. The other variables are the resources related to it, and are null for resource types
+// that have no such relationship.
+const (
+	resourceVariable          = "resource"
+	projectVariable           = "project"
+	stepVariable              = "step"
+	deploymentProcessVariable = "deploymentProcess"
+	lifecycleVariable         = "lifecycle"
+	workerPoolVariable        = "workerPool"
+)
+
+var ruleEnvironment, ruleEnvironmentErr = cel.NewEnv(
+	cel.Variable(resourceVariable, cel.DynType),
+	cel.Variable(projectVariable, cel.DynType),
+	cel.Variable(stepVariable, cel.DynType),
+	cel.Variable(deploymentProcessVariable, cel.DynType),
+	cel.Variable(lifecycleVariable, cel.DynType),
+	cel.Variable(workerPoolVariable, cel.DynType),
+)
+
+// OctopusRule is a check defined in YAML. The condition is a CEL expression that must be true for every resource of the
+// rule's resource type, and the resources where it is false are reported as findings:
+//
+//	rules:
+//	  - id: MyOrgProjectDescription
+//	    resource: project
+//	    description: Every project must have a description
+//	    condition: resource.Description != ""
+//
+// The optional filter is a CEL expression that selects the resources the rule applies to. Resources are exposed to
+// the expressions with the same field names as the Octopus REST API.
+type OctopusRule struct {
+	Id          string `yaml:"id"`
+	Resource    string `yaml:"resource"`
+	Description string `yaml:"description"`
+	Severity    string `yaml:"severity"`
+	Category    string `yaml:"category"`
+	Link        string `yaml:"link"`
+	Filter      string `yaml:"filter"`
+	Condition   string `yaml:"condition"`
+
+	severity  int
+	filter    cel.Program
+	condition cel.Program
+	variables []string
+}
+
+type octopusRuleFile struct {
+	Rules []*OctopusRule `yaml:"rules"`
+}
+
+// LoadOctopusRules reads and compiles the rules in each file. Rule IDs must be unique across all the files.
+func LoadOctopusRules(paths []string) ([]*OctopusRule, error) {
+	allRules := []*OctopusRule{}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+
+		if err != nil {
+			return nil, errors.New("failed to read the rules file " + path + ": " + err.Error())
+		}
+
+		fileRules, err := ParseOctopusRules(path, content)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range fileRules {
+			if slices.IndexFunc(allRules, func(item *OctopusRule) bool { return item.Id == rule.Id }) != -1 {
+				return nil, errors.New("the rule " + rule.Id + " in " + path + " has the same ID as another rule")
+			}
+
+			allRules = append(allRules, rule)
+		}
+	}
+
+	return allRules, nil
+}
+
+// ParseOctopusRules reads and compiles the rules in a YAML document. The source is only used in error messages.
+func ParseOctopusRules(source string, content []byte) ([]*OctopusRule, error) {
+	if ruleEnvironmentErr != nil {
+		return nil, ruleEnvironmentErr
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	ruleFile := octopusRuleFile{}
+	if err := decoder.Decode(&ruleFile); err != nil {
+		return nil, errors.New("the rules file " + source + " is not valid: " + err.Error())
+	}
+
+	for _, rule := range ruleFile.Rules {
+		if err := rule.compile(); err != nil {
+			return nil, errors.New("the rules file " + source + " is not valid: " + err.Error())
+		}
+	}
+
+	return ruleFile.Rules, nil
+}
+
+// compile validates the rule, applies the defaults, and compiles the expressions.
+func (r *OctopusRule) compile() error {
+	r.Id = strings.TrimSpace(r.Id)
+
+	if r.Id == "" {
+		return errors.New("every rule must have an id")
+	}
+
+	r.Resource = strings.ToLower(strings.TrimSpace(r.Resource))
+	if resourceType, ok := resourceTypeAliases[r.Resource]; ok {
+		r.Resource = resourceType
+	}
+
+	if slices.Index(ResourceTypes, r.Resource) == -1 {
+		return errors.New("the resource \"" + r.Resource + "\" of the rule " + r.Id + " is not supported. Supported values are " + strings.Join(ResourceTypes, ", "))
+	}
+
+	if r.Severity == "" {
+		r.Severity = checks.WarningSeverityName
+	}
+
+	if slices.Index(ruleSeverityNames, strings.ToLower(r.Severity)) == -1 {
+		return errors.New("the severity \"" + r.Severity + "\" of the rule " + r.Id + " is not supported. Supported values are " + strings.Join(ruleSeverityNames, ", "))
+	}
+
+	r.severity, _ = checks.StringToSeverity(strings.ToLower(r.Severity))
+
+	if r.Category == "" {
+		r.Category = checks.Organization
+	}
+
+	if slices.Index(ruleCategories, r.Category) == -1 {
+		return errors.New("the category \"" + r.Category + "\" of the rule " + r.Id + " is not supported. Supported values are " + strings.Join(ruleCategories, ", "))
+	}
+
+	if strings.TrimSpace(r.Condition) == "" {
+		return errors.New("the rule " + r.Id + " does not have a condition")
+	}
+
+	var err error
+	var variables []string
+	r.condition, variables, err = compileExpression(r.Condition)
+
+	if err != nil {
+		return errors.New("the condition of the rule " + r.Id + " does not compile: " + err.Error())
+	}
+
+	r.variables = variables
+
+	if strings.TrimSpace(r.Filter) != "" {
+		r.filter, variables, err = compileExpression(r.Filter)
+
+		if err != nil {
+			return errors.New("the filter of the rule " + r.Id + " does not compile: " + err.Error())
+		}
+
+		r.variables = append(r.variables, variables...)
+	}
+
+	return nil
+}
+
+// references returns true if the filter or condition of the rule references the variable. Related resources that
+// require additional API requests are only read when they are referenced.
+func (r *OctopusRule) references(variable string) bool {
+	return slices.Index(r.variables, variable) != -1
+}
+
+// InspectsOcl returns true if the rule only reads the resources stored in the OCL files of a version controlled
+// project, which are the project, its deployment process, and its variables.
+func (r *OctopusRule) InspectsOcl() bool {
+	return slices.Index([]string{ProjectRule, ActionRule, VariableRule}, r.Resource) != -1 &&
+		!r.references(lifecycleVariable) &&
+		!r.references(workerPoolVariable)
+}
+
+// compileExpression compiles a filter or condition, returning the program and the variables the expression
+// references.
+func compileExpression(expression string) (cel.Program, []string, error) {
+	ast, issues := ruleEnvironment.Compile(expression)
+
+	if issues != nil && issues.Err() != nil {
+		return nil, nil, issues.Err()
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, nil, errors.New("the expression must return true or false")
+	}
+
+	checkedExpr, err := cel.AstToCheckedExpr(ast)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := []string{}
+	for _, reference := range checkedExpr.ReferenceMap {
+		if reference.Name != "" {
+			variables = append(variables, reference.Name)
+		}
+	}
+
+	program, err := ruleEnvironment.Program(ast)
+
+	return program, variables, err
+}
@@ -0,0 +1,539 @@
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/defaults"
+	"github.com/google/cel-go/cel"
+	"github.com/hayageek/threadsafe"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"reflect"
+	"strings"
+)
+
+// OctopusRuleCheck evaluates a rule against every resource of the rule's resource type, and reports the resources
+// that do not comply with the rule.
+type OctopusRuleCheck struct {
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
+	errorHandler checks.OctopusClientErrorHandler
+	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
+	rule         *OctopusRule
+}
+
+func NewOctopusRuleCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler, rule *OctopusRule) OctopusRuleCheck {
+	return OctopusRuleCheck{
+		client:       client,
+		cache:        cache,
+		errorHandler: errorHandler,
+		config:       config,
+		urlBuilder:   urlBuilder,
+		rule:         rule,
+	}
+}
+
+func (o OctopusRuleCheck) Id() string {
+	return o.rule.Id
+}
+
+// ruleSubject is a resource the rule is evaluated against. The variables are passed to the filter and condition, and
+// the finding is reported if the resource does not comply with the rule.
+type ruleSubject struct {
+	variables map[string]any
+	finding   checks.OctopusCheckFinding
+}
+
+func (o OctopusRuleCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	if o.client == nil {
+		return nil, errors.New("octoclient is nil")
+	}
+
+	zap.L().Debug("Starting check " + o.Id())
+
+	defer func() {
+		zap.L().Debug("Ended check " + o.Id())
+	}()
+
+	subjects, err := o.subjects(ctx, concurrency)
+
+	if err != nil {
+		return o.errorHandler.HandleError(o.Id(), o.rule.Category, err)
+	}
+
+	findings := []checks.OctopusCheckFinding{}
+
+	for _, subject := range subjects {
+		complies, err := o.evaluate(subject)
+
+		// A rule that can not be evaluated is reported as a check that failed to run, rather than as an issue
+		if err != nil {
+			return checks.NewOctopusCheckResultImpl(
+				"The rule "+o.Id()+" could not be evaluated against "+subject.finding.ResourceName+": "+err.Error(),
+				o.Id(),
+				o.rule.Link,
+				checks.Error,
+				checks.GeneralError), nil
+		}
+
+		if !complies {
+			findings = append(findings, subject.finding)
+		}
+	}
+
+	if len(findings) > 0 {
+		return checks.NewOctopusCheckResultWithFindings(
+			o.rule.Description+". The following resources do not comply:",
+			o.Id(),
+			o.rule.Link,
+			o.rule.severity,
+			o.rule.Category,
+			findings), nil
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		"All resources comply with the rule: "+o.rule.Description,
+		o.Id(),
+		o.rule.Link,
+		checks.Ok,
+		o.rule.Category), nil
+}
+
+// evaluate returns true if the resource is excluded by the filter, or if the condition is true.
+func (o OctopusRuleCheck) evaluate(subject ruleSubject) (bool, error) {
+	if o.rule.filter != nil {
+		included, err := evaluateProgram(o.rule.filter, subject.variables)
+
+		if err != nil {
+			return false, errors.New("the filter failed: " + err.Error())
+		}
+
+		if !included {
+			return true, nil
+		}
+	}
+
+	complies, err := evaluateProgram(o.rule.condition, subject.variables)
+
+	if err != nil {
+		return false, errors.New("the condition failed: " + err.Error())
+	}
+
+	return complies, nil
+}
+
+func evaluateProgram(program cel.Program, variables map[string]any) (bool, error) {
+	value, _, err := program.Eval(variables)
+
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.Value().(bool)
+
+	if !ok {
+		return false, errors.New("the expression returned " + value.Type().TypeName() + " instead of true or false")
+	}
+
+	return result, nil
+}
+
+// subjects returns the resources of the rule's resource type, along with the related resources referenced by the
+// rule.
+func (o OctopusRuleCheck) subjects(ctx context.Context, concurrency int) ([]ruleSubject, error) {
+	switch o.rule.Resource {
+	case ProjectRule, ActionRule, VariableRule:
+		return o.projectSubjects(ctx, concurrency)
+	case TargetRule:
+		allTargets, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", defaults.MaxRuleTargets))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.Map(allTargets, func(item *machines.DeploymentTarget, index int) ruleSubject {
+			return o.subject(item, checks.TargetResource, item.ID, item.Name)
+		}), nil
+	case TenantRule:
+		allTenants, err := o.cache.GetTenants(ctx, o.config.CheckInt(o.Id(), "maxTenants", defaults.MaxRuleTenants))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.Map(allTenants, func(item *tenants.Tenant, index int) ruleSubject {
+			return o.subject(item, checks.TenantResource, item.ID, item.Name)
+		}), nil
+	case LifecycleRule:
+		allLifecycles, err := o.cache.GetLifecycles(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.Map(allLifecycles, func(item *lifecycles.Lifecycle, index int) ruleSubject {
+			return o.subject(item, checks.LifecycleResource, item.ID, item.Name)
+		}), nil
+	case FeedRule:
+		allFeeds, err := o.client.GetFeeds(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.Map(allFeeds, func(item *feeds.FeedResource, index int) ruleSubject {
+			return o.subject(item, checks.FeedResource, item.ID, item.Name)
+		}), nil
+	case AccountRule:
+		allAccounts, err := o.client.GetAccounts(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.Map(allAccounts, func(item *accounts.AccountResource, index int) ruleSubject {
+			return o.subject(item, checks.AccountResource, item.ID, item.Name)
+		}), nil
+	case WorkerPoolRule:
+		allWorkerPools, err := o.client.GetWorkerPools(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return lo.Map(allWorkerPools, func(item *workerpools.WorkerPoolListResult, index int) ruleSubject {
+			return o.subject(item, checks.WorkerPoolResource, item.ID, item.Name)
+		}), nil
+	}
+
+	return nil, errors.New("the resource " + o.rule.Resource + " is not supported")
+}
+
+// subject creates a subject for a resource with no related resources.
+func (o OctopusRuleCheck) subject(resource any, resourceType string, id string, name string) ruleSubject {
+	return ruleSubject{
+		variables: o.variables(resource, nil, nil, nil, nil, nil),
+		finding: checks.OctopusCheckFinding{
+			ResourceType: resourceType,
+			ResourceId:   id,
+			ResourceName: name,
+			SpaceId:      o.client.GetSpaceID(),
+			Link:         o.urlBuilder.ResourceUrl(resourceType, id),
+		},
+	}
+}
+
+// projectSubjects returns the projects, the actions in their deployment processes, or their variables. The
+// deployment process and lifecycle of a project are only read if the rule references them.
+func (o OctopusRuleCheck) projectSubjects(ctx context.Context, concurrency int) ([]ruleSubject, error) {
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
+		o.config.ExcludeProjectsExcept,
+		o.config.ExcludeProjects,
+		o.config.CheckInt(o.Id(), "maxProjects", defaults.MaxRuleProjects))
+
+	if err != nil {
+		return nil, err
+	}
+
+	allLifecycles := []*lifecycles.Lifecycle{}
+	if o.rule.references(lifecycleVariable) {
+		allLifecycles, err = o.cache.GetLifecycles(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	allWorkerPools := []*workerpools.WorkerPoolListResult{}
+	if o.rule.Resource == ActionRule && o.rule.references(workerPoolVariable) {
+		allWorkerPools, err = o.client.GetWorkerPools(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+
+	subjects := threadsafe.NewSlice[ruleSubject]()
+	goroutineErrors := threadsafe.NewSlice[error]()
+
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
+		p := p
+
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			defer progress.Complete(1)
+
+			projectSubjects, err := o.projectResourceSubjects(ctx, p, allLifecycles, allWorkerPools)
+
+			if err != nil {
+				if !o.errorHandler.ShouldContinue(err) {
+					goroutineErrors.Append(err)
+				}
+				return nil
+			}
+
+			for _, subject := range projectSubjects {
+				subjects.Append(subject)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Treat the first error as the root cause
+	if goroutineErrors.Length() > 0 {
+		return nil, goroutineErrors.Values()[0]
+	}
+
+	return subjects.Values(), nil
+}
+
+func (o OctopusRuleCheck) projectResourceSubjects(ctx context.Context, project *projects.Project, allLifecycles []*lifecycles.Lifecycle, allWorkerPools []*workerpools.WorkerPoolListResult) ([]ruleSubject, error) {
+	lifecycle, _ := lo.Find(allLifecycles, func(item *lifecycles.Lifecycle) bool {
+		return item.ID == project.LifecycleID
+	})
+
+	switch o.rule.Resource {
+	case VariableRule:
+		variableSet, err := o.cache.GetVariables(ctx, project)
+
+		if err != nil {
+			return nil, err
+		}
+
+		subjects := []ruleSubject{}
+		for _, v := range variableSet.Variables {
+			subjects = append(subjects, ruleSubject{
+				variables: o.variables(v, project, nil, nil, lifecycle, nil),
+				finding:   o.projectFinding(project, checks.VariableResource, v.ID, v.Name, o.urlBuilder.ProjectVariablesUrl(project.ID)),
+			})
+		}
+
+		return subjects, nil
+	case ActionRule:
+		deploymentProcess, err := o.deploymentProcess(ctx, project)
+
+		if err != nil || deploymentProcess == nil {
+			return nil, err
+		}
+
+		subjects := []ruleSubject{}
+		for _, s := range deploymentProcess.Steps {
+			for _, a := range s.Actions {
+				workerPool, _ := lo.Find(allWorkerPools, func(item *workerpools.WorkerPoolListResult) bool {
+					return item.ID == a.WorkerPool
+				})
+
+				subjects = append(subjects, ruleSubject{
+					variables: o.variables(a, project, s, deploymentProcess, lifecycle, workerPool),
+					finding:   o.projectFinding(project, checks.ActionResource, a.ID, a.Name, o.urlBuilder.ProjectProcessUrl(project.ID)),
+				})
+			}
+		}
+
+		return subjects, nil
+	}
+
+	var deploymentProcess *deployments.DeploymentProcess
+	if o.rule.references(deploymentProcessVariable) {
+		var err error
+		deploymentProcess, err = o.deploymentProcess(ctx, project)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []ruleSubject{{
+		variables: o.variables(project, project, nil, deploymentProcess, lifecycle, nil),
+		finding: checks.OctopusCheckFinding{
+			ResourceType: checks.ProjectResource,
+			ResourceId:   project.ID,
+			ResourceName: project.Name,
+			SpaceId:      o.client.GetSpaceID(),
+			Link:         o.urlBuilder.ProjectUrl(project.ID),
+		},
+	}}, nil
+}
+
+func (o OctopusRuleCheck) projectFinding(project *projects.Project, resourceType string, id string, name string, link string) checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{
+		ResourceType: resourceType,
+		ResourceId:   id,
+		ResourceName: name,
+		SpaceId:      o.client.GetSpaceID(),
+		ParentType:   checks.ProjectResource,
+		ParentId:     project.ID,
+		ParentName:   project.Name,
+		Link:         link,
+	}
+}
+
+func (o OctopusRuleCheck) deploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if project.DeploymentProcessID == "" {
+		return nil, nil
+	}
+
+	resource, err := o.cache.GetDeploymentProcess(ctx, project)
+
+	if err != nil {
+		// If we can't find the deployment process, assume zero steps
+		if apiError, ok := err.(*core.APIError); ok && apiError.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// variables builds the variables passed to the filter and condition. Resources that are nil are passed as null.
+func (o OctopusRuleCheck) variables(resource any, project *projects.Project, step *deployments.DeploymentStep, deploymentProcess *deployments.DeploymentProcess, lifecycle *lifecycles.Lifecycle, workerPool *workerpools.WorkerPoolListResult) map[string]any {
+	return map[string]any{
+		resourceVariable:          toMap(resource),
+		projectVariable:           toMap(project),
+		stepVariable:              toMap(step),
+		deploymentProcessVariable: toMap(deploymentProcess),
+		lifecycleVariable:         toMap(lifecycle),
+		workerPoolVariable:        toMap(workerPool),
+	}
+}
+
+// toMap converts a resource to the fields returned by the Octopus REST API, which is how the resources are exposed to
+// the expressions. A resource that is nil, or can not be converted, is returned as nil.
+func toMap(resource any) any {
+	if resource == nil {
+		return nil
+	}
+
+	content, err := json.Marshal(resource)
+
+	if err != nil {
+		zap.L().Error("Failed to convert resource to JSON: " + err.Error())
+		return nil
+	}
+
+	var result any
+	if err := json.Unmarshal(content, &result); err != nil {
+		zap.L().Error("Failed to convert resource from JSON: " + err.Error())
+		return nil
+	}
+
+	return addOmittedFields(result, reflect.TypeOf(resource))
+}
+
+// addOmittedFields adds the fields that were omitted from the JSON because they were empty. Without them, a condition
+// like resource.Description != "" fails to evaluate for resources without a description, rather than being false.
+func addOmittedFields(value any, valueType reflect.Type) any {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.Struct:
+		if fields, ok := value.(map[string]any); ok {
+			addOmittedStructFields(fields, valueType)
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := value.([]any); ok {
+			for i, item := range items {
+				items[i] = addOmittedFields(item, valueType.Elem())
+			}
+		}
+	case reflect.Map:
+		if items, ok := value.(map[string]any); ok {
+			for key, item := range items {
+				items[key] = addOmittedFields(item, valueType.Elem())
+			}
+		}
+	}
+
+	return value
+}
+
+func addOmittedStructFields(fields map[string]any, structType reflect.Type) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if name == "-" {
+			continue
+		}
+
+		// The fields of embedded structs are promoted to the parent
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Pointer {
+				embeddedType = embeddedType.Elem()
+			}
+
+			if embeddedType.Kind() == reflect.Struct {
+				addOmittedStructFields(fields, embeddedType)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if existing, ok := fields[name]; ok {
+			fields[name] = addOmittedFields(existing, field.Type)
+		} else {
+			fields[name] = zeroValue(field.Type)
+		}
+	}
+}
+
+// zeroValue returns the value of an empty field, as it would have been returned by the JSON conversion.
+func zeroValue(valueType reflect.Type) any {
+	switch valueType.Kind() {
+	case reflect.String:
+		return ""
+	case reflect.Bool:
+		return false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return float64(0)
+	case reflect.Slice, reflect.Array:
+		return []any{}
+	case reflect.Map:
+		return map[string]any{}
+	}
+
+	return nil
+}
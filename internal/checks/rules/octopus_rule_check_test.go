@@ -0,0 +1,187 @@
+package rules
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"testing"
+)
+
+// fakeDataSource returns a fixed set of projects. Methods not implemented here panic, as the embedded interface is nil.
+type fakeDataSource struct {
+	client_wrapper.OctopusDataSource
+	projects           []*projects.Project
+	deploymentProcess  *deployments.DeploymentProcess
+	lifecycles         []*lifecycles.Lifecycle
+	workerPools        []*workerpools.WorkerPoolListResult
+	deploymentRequests int
+}
+
+func (f *fakeDataSource) GetSpaceID() string {
+	return "Spaces-1"
+}
+
+func (f *fakeDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	return f.projects, nil
+}
+
+func (f *fakeDataSource) GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	f.deploymentRequests++
+	return f.deploymentProcess, nil
+}
+
+func (f *fakeDataSource) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	return f.lifecycles, nil
+}
+
+func (f *fakeDataSource) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	return f.workerPools, nil
+}
+
+func newFakeDataSource() *fakeDataSource {
+	described := projects.NewProject("Described", "Lifecycles-1", "ProjectGroups-1")
+	described.ID = "Projects-1"
+	described.Description = "A project with a description"
+	described.DeploymentProcessID = "deploymentprocess-Projects-1"
+
+	undescribed := projects.NewProject("Undescribed", "Lifecycles-2", "ProjectGroups-1")
+	undescribed.ID = "Projects-2"
+	undescribed.DeploymentProcessID = "deploymentprocess-Projects-2"
+
+	production := lifecycles.NewLifecycle("Production")
+	production.ID = "Lifecycles-1"
+
+	development := lifecycles.NewLifecycle("Development")
+	development.ID = "Lifecycles-2"
+
+	action := deployments.NewDeploymentAction("Run a script", "Octopus.Script")
+	action.ID = "Actions-1"
+	action.WorkerPool = "WorkerPools-1"
+
+	step := deployments.NewDeploymentStep("Run a script")
+	step.Actions = []*deployments.DeploymentAction{action}
+
+	return &fakeDataSource{
+		projects:          []*projects.Project{described, undescribed},
+		deploymentProcess: &deployments.DeploymentProcess{Steps: []*deployments.DeploymentStep{step}},
+		lifecycles:        []*lifecycles.Lifecycle{production, development},
+		workerPools: []*workerpools.WorkerPoolListResult{
+			{ID: "WorkerPools-1", Name: "Hosted Windows"},
+		},
+	}
+}
+
+func executeRule(t *testing.T, dataSource *fakeDataSource, rule string) checks.OctopusCheckResult {
+	rules, err := ParseOctopusRules("test", []byte(rule))
+
+	if err != nil {
+		t.Fatalf("Failed to parse the rule: %v", err)
+	}
+
+	check := NewOctopusRuleCheck(
+		dataSource,
+		client_wrapper.NewOctopusClientCache(dataSource),
+		&config.OctolintConfig{},
+		checks.NewOctopusUrlBuilder("https://example.org", "Spaces-1"),
+		checks.OctopusClientPermissiveErrorHandler{},
+		rules[0])
+
+	result, err := check.Execute(context.Background(), 1)
+
+	if err != nil {
+		t.Fatalf("Failed to execute the rule: %v", err)
+	}
+
+	return result
+}
+
+func TestProjectRule(t *testing.T) {
+	dataSource := newFakeDataSource()
+	result := executeRule(t, dataSource, `
+rules:
+  - id: ProjectDescription
+    resource: project
+    description: Every project must have a description
+    link: https://example.org/rules
+    condition: resource.Description != ""
+`)
+
+	if result.Severity() != checks.Warning || len(result.Findings()) != 1 || result.Findings()[0].ResourceId != "Projects-2" {
+		t.Fatalf("Expected the project without a description to be reported, got %s", result.Description())
+	}
+
+	if result.Link() != "https://example.org/rules" || result.Findings()[0].Link == "" {
+		t.Fatal("Expected the rule and project links to be reported")
+	}
+
+	if dataSource.deploymentRequests != 0 {
+		t.Fatal("Expected the deployment process to not be read when the rule does not reference it")
+	}
+}
+
+func TestProjectRuleWithFilter(t *testing.T) {
+	result := executeRule(t, newFakeDataSource(), `
+rules:
+  - id: ProductionManualIntervention
+    resource: project
+    description: Projects deployed to production must have a manual intervention
+    severity: error
+    filter: lifecycle.Name.contains("Production")
+    condition: deploymentProcess.Steps.exists(s, s.Actions.exists(a, a.ActionType == "Octopus.Manual"))
+`)
+
+	if result.Severity() != checks.Error || len(result.Findings()) != 1 || result.Findings()[0].ResourceId != "Projects-1" {
+		t.Fatalf("Expected only the production project to be reported, got %s", result.Description())
+	}
+}
+
+func TestActionRule(t *testing.T) {
+	result := executeRule(t, newFakeDataSource(), `
+rules:
+  - id: HostedWindows
+    resource: action
+    description: Steps must not use the hosted Windows worker pool
+    condition: workerPool == null || workerPool.Name != "Hosted Windows"
+`)
+
+	if len(result.Findings()) != 2 || result.Findings()[0].ResourceType != checks.ActionResource || result.Findings()[0].ParentType != checks.ProjectResource {
+		t.Fatalf("Expected the action in each project to be reported, got %s", result.Description())
+	}
+}
+
+func TestRuleThatPasses(t *testing.T) {
+	result := executeRule(t, newFakeDataSource(), `
+rules:
+  - id: ProjectName
+    resource: project
+    description: Every project must have a name
+    condition: resource.Name != ""
+`)
+
+	if result.Severity() != checks.Ok || len(result.Findings()) != 0 {
+		t.Fatalf("Expected the rule to pass, got %s", result.Description())
+	}
+}
+
+func TestRuleThatFailsToEvaluate(t *testing.T) {
+	result := executeRule(t, newFakeDataSource(), `
+rules:
+  - id: MissingField
+    resource: project
+    description: Every project must have a field that does not exist
+    condition: resource.DoesNotExist == "value"
+`)
+
+	if result.Severity() != checks.Error || len(result.Findings()) != 0 {
+		t.Fatalf("Expected the rule to report an error, got %s", result.Description())
+	}
+
+	if !checks.IsCheckFailure(result) {
+		t.Fatal("Expected the rule to be reported as a check that failed to run")
+	}
+}
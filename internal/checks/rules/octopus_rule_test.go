@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseOctopusRules("test", []byte(`
+rules:
+  - id: ProjectDescription
+    resource: project
+    description: Every project must have a description
+    condition: resource.Description != ""
+  - id: HostedWindows
+    resource: step
+    description: Steps must not use the hosted Windows worker pool
+    severity: Error
+    category: Security
+    filter: resource.ActionType != "Octopus.Manual"
+    condition: workerPool == null || workerPool.Name != "Hosted Windows"
+`))
+
+	if err != nil {
+		t.Fatalf("Failed to parse the rules: %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(rules))
+	}
+
+	if rules[0].Severity != checks.WarningSeverityName || rules[0].severity != checks.Warning || rules[0].Category != checks.Organization {
+		t.Fatal("Expected the default severity and category to be applied")
+	}
+
+	if rules[1].Resource != ActionRule || rules[1].severity != checks.Error || rules[1].filter == nil {
+		t.Fatal("Expected the step alias, severity, and filter to be applied")
+	}
+
+	if !rules[1].references(workerPoolVariable) || rules[0].references(workerPoolVariable) {
+		t.Fatal("Expected only the second rule to reference the worker pool")
+	}
+}
+
+func TestParseInvalidRules(t *testing.T) {
+	invalidRules := map[string]string{
+		"id":        "rules:\n  - resource: project\n    condition: true",
+		"resource":  "rules:\n  - id: Rule\n    resource: space\n    condition: true",
+		"severity":  "rules:\n  - id: Rule\n    resource: project\n    severity: critical\n    condition: true",
+		"category":  "rules:\n  - id: Rule\n    resource: project\n    category: Style\n    condition: true",
+		"condition": "rules:\n  - id: Rule\n    resource: project",
+		"compile":   "rules:\n  - id: Rule\n    resource: project\n    condition: resource.Name ==",
+		"bool":      "rules:\n  - id: Rule\n    resource: project\n    condition: '\"text\"'",
+		"unknown":   "rules:\n  - id: Rule\n    resource: project\n    condition: resource.Name == tenant.Name",
+		"field":     "rules:\n  - id: Rule\n    resource: project\n    conditon: true",
+	}
+
+	for name, rule := range invalidRules {
+		if _, err := ParseOctopusRules("test", []byte(rule)); err == nil {
+			t.Fatalf("Expected the %s rule to be invalid", name)
+		}
+	}
+}
+
+func TestLoadRulesWithDuplicateIds(t *testing.T) {
+	dir := t.TempDir()
+	rule := "rules:\n  - id: Rule\n    resource: project\n    condition: true"
+
+	for _, name := range []string{"first.yaml", "second.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(rule), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := LoadOctopusRules([]string{filepath.Join(dir, "first.yaml"), filepath.Join(dir, "second.yaml")})
+
+	if err == nil || !strings.Contains(err.Error(), "same ID") {
+		t.Fatalf("Expected the duplicate rule ID to be reported, got %v", err)
+	}
+}
@@ -3,20 +3,17 @@ package security
 import (
 	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/hayageek/threadsafe"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	"strings"
-	"time"
 )
 
 const OctoLintDeploymentQueuedByAdmin = "OctoLintDeploymentQueuedByAdmin"
@@ -24,20 +21,22 @@ const OctoLintDeploymentQueuedByAdmin = "OctoLintDeploymentQueuedByAdmin"
 // OctopusDeploymentQueuedByAdminCheck checks to see if any deployments were initiated by someone from the admin teams.
 // This usually means that a more specific and limited user should be created to perform deployments.
 type OctopusDeploymentQueuedByAdminCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusDeploymentQueuedByAdminCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedByAdminCheck {
-	return OctopusDeploymentQueuedByAdminCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDeploymentQueuedByAdminCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusDeploymentQueuedByAdminCheck {
+	return OctopusDeploymentQueuedByAdminCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusDeploymentQueuedByAdminCheck) Id() string {
 	return OctoLintDeploymentQueuedByAdmin
 }
 
-func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusDeploymentQueuedByAdminCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -48,46 +47,50 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	projects, err := client_wrapper.GetProjectsWithFilter(
-		o.client,
-		o.client.GetSpaceID(),
+	projects, err := o.cache.GetProjectsWithFilter(
+		ctx,
 		o.config.ExcludeProjectsExcept,
 		o.config.ExcludeProjects,
-		o.config.MaxDeploymentsByAdminProjects)
+		o.config.CheckInt(o.Id(), "maxProjects", o.config.MaxDeploymentsByAdminProjects))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	teams, err := o.getAdminTeams()
+	adminUsernames, err := o.getAdminUsernames(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	now := time.Now()
+	now := o.client.Now()
 	fromDate := now.AddDate(0, -3, 0)
 	from := fromDate.Format("2006-01-02")
 
-	g, _ := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(concurrency)
 
 	goroutineErrors := threadsafe.NewSlice[error]()
-	projectsDeployedByAdmins := threadsafe.NewSlice[string]()
+	projectsDeployedByAdmins := threadsafe.NewSlice[checks.OctopusCheckFinding]()
 
-	for i, p := range projects {
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(projects))
+
+	for _, p := range projects {
 
-		i := i
 		p := p
 
 		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 
-			zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(projects))*100) + "% complete")
+			defer progress.Complete(1)
 
 			projectId := p.ID
 			usersWhoDeployedProject := []string{}
 
-			resource, err := o.client.Events.Get(events.EventsQuery{
+			resource, err := o.client.GetEvents(ctx, events.EventsQuery{
 				EventCategories: []string{"DeploymentQueued"},
 				Projects:        []string{projectId},
 				Skip:            0,
@@ -108,31 +111,21 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 						continue
 					}
 
-					user, err := o.client.Users.Get(users.UsersQuery{
-						Filter: r.Username,
-						Skip:   0,
-						Take:   1,
-					})
-
-					if err != nil {
-						if !o.errorHandler.ShouldContinue(err) {
-							goroutineErrors.Append(err)
-						}
-						return nil
-					}
-
-					for _, u := range user.Items {
-						for _, t := range teams {
-							if slices.Index(t.MemberUserIDs, u.ID) != -1 && slices.Index(usersWhoDeployedProject, u.Username) == -1 {
-								usersWhoDeployedProject = append(usersWhoDeployedProject, u.Username)
-							}
-						}
+					if slices.Index(adminUsernames, r.Username) != -1 && slices.Index(usersWhoDeployedProject, r.Username) == -1 {
+						usersWhoDeployedProject = append(usersWhoDeployedProject, r.Username)
 					}
 				}
 			}
 
 			if len(usersWhoDeployedProject) != 0 {
-				projectsDeployedByAdmins.Append(p.Name + " (" + strings.Join(usersWhoDeployedProject, ",") + ")")
+				projectsDeployedByAdmins.Append(checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectResource,
+					ResourceId:   p.ID,
+					ResourceName: p.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Details:      strings.Join(usersWhoDeployedProject, ","),
+					Link:         o.urlBuilder.ProjectUrl(p.ID),
+				})
 			}
 
 			return nil
@@ -149,41 +142,52 @@ func (o OctopusDeploymentQueuedByAdminCheck) Execute(concurrency int) (checks.Oc
 	}
 
 	if projectsDeployedByAdmins.Length() != 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following projects were deployed by admins. Consider creating a limited user account to perform deployments:\n"+strings.Join(projectsDeployedByAdmins.Values(), "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following projects were deployed by admins. Consider creating a limited user account to perform deployments:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			projectsDeployedByAdmins.Values()), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No deployments were found",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
 
-func (o OctopusDeploymentQueuedByAdminCheck) getAdminTeams() ([]*teams.Team, error) {
+// getAdminUsernames returns the usernames of the members of the administrator teams.
+func (o OctopusDeploymentQueuedByAdminCheck) getAdminUsernames(ctx context.Context) ([]string, error) {
 	adminTeams := []string{"Octopus Administrators", "Space Managers", "Octopus Managers"}
 
-	teamResources := []*teams.Team{}
-	for _, adminTeam := range adminTeams {
-		team, err := o.client.Teams.Get(teams.TeamsQuery{
-			IDs:           nil,
-			IncludeSystem: true,
-			PartialName:   adminTeam,
-			Skip:          0,
-			Take:          1,
-		})
+	allTeams, err := o.client.GetTeams(ctx)
 
-		if err != nil {
-			return nil, err
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		teamResources = append(teamResources, team.Items...)
+	allUsers, err := o.client.GetUsers(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	teamResources := lo.Filter(allTeams, func(item *teams.Team, index int) bool {
+		return slices.Index(adminTeams, item.Name) != -1
+	})
+
+	adminUsernames := []string{}
+	for _, u := range allUsers {
+		for _, t := range teamResources {
+			if slices.Index(t.MemberUserIDs, u.ID) != -1 {
+				adminUsernames = append(adminUsernames, u.Username)
+				break
+			}
+		}
 	}
 
-	return teamResources, nil
+	return adminUsernames, nil
 }
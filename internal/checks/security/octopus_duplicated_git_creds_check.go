@@ -1,49 +1,34 @@
 package security
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/credentials"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
-	"strings"
 )
 
-// CustomProject is the simplest representation of a project and its version controlled settings
-type CustomProject struct {
-	PersistenceSettings CustomPersistenceSettings `json:"PersistenceSettings"`
-	Name                string                    `json:"Name"`
-}
-
-type CustomPersistenceSettings struct {
-	Type        string             `json:"Type"`
-	Credentials *CustomCredentials `json:"Credentials"`
-}
-
-type CustomCredentials struct {
-	Type     string  `json:"Type"`
-	Username *string `json:"Username"`
-}
-
 // OctopusDuplicatedGitCredentialsCheck reports on any perpetual api keys
 type OctopusDuplicatedGitCredentialsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusDuplicatedGitCredentialsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusDuplicatedGitCredentialsCheck {
-	return OctopusDuplicatedGitCredentialsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusDuplicatedGitCredentialsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusDuplicatedGitCredentialsCheck {
+	return OctopusDuplicatedGitCredentialsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusDuplicatedGitCredentialsCheck) Id() string {
 	return "OctoLintSharedGitUsername"
 }
 
-func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusDuplicatedGitCredentialsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -54,34 +39,31 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.O
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	url := o.client.HttpSession().BaseURL.String() + "/api/" + o.client.GetSpaceID() + "/Projects?take=1000"
-	allProjects, err := newclient.Get[resources.Resources[CustomProject]](o.client.HttpSession(), url)
+	allProjects, err := o.client.GetProjects(ctx, 1000)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
 	gitUsernameCounts := map[string]int{}
-	gitUsernameProjects := map[string][]string{}
-	for i, p := range allProjects.Items {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allProjects.Items))*100) + "% complete")
-
-		if p.PersistenceSettings.Type == "VersionControlled" &&
-			p.PersistenceSettings.Credentials.Type == "UsernamePassword" &&
-			p.PersistenceSettings.Credentials.Username != nil {
-			if _, ok := gitUsernameCounts[*p.PersistenceSettings.Credentials.Username]; !ok {
-				gitUsernameCounts[*p.PersistenceSettings.Credentials.Username] = 0
-			}
-			gitUsernameCounts[*p.PersistenceSettings.Credentials.Username]++
+	gitUsernameProjects := map[string][]*projects.Project{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allProjects))
 
-			if _, ok := gitUsernameProjects[*p.PersistenceSettings.Credentials.Username]; !ok {
-				gitUsernameProjects[*p.PersistenceSettings.Credentials.Username] = []string{}
-			}
-			gitUsernameProjects[*p.PersistenceSettings.Credentials.Username] = append(gitUsernameProjects[*p.PersistenceSettings.Credentials.Username], p.Name)
+	for _, p := range allProjects {
+		progress.Complete(1)
+
+		username := gitUsername(p)
+
+		if username == "" {
+			continue
 		}
+
+		gitUsernameCounts[username]++
+		gitUsernameProjects[username] = append(gitUsernameProjects[username], p)
 	}
 
-	duplicatedGitCredentials := map[string][]string{}
+	duplicatedGitCredentials := map[string][]*projects.Project{}
 	for u, c := range gitUsernameCounts {
 		if c > 1 {
 			duplicatedGitCredentials[u] = gitUsernameProjects[u]
@@ -89,23 +71,51 @@ func (o OctopusDuplicatedGitCredentialsCheck) Execute(concurrency int) (checks.O
 	}
 
 	if len(duplicatedGitCredentials) != 0 {
-		message := []string{}
-		for u, p := range duplicatedGitCredentials {
-			message = append(message, u+" ("+strings.Join(p, ", ")+")")
+		findings := []checks.OctopusCheckFinding{}
+		for u, sharedProjects := range duplicatedGitCredentials {
+			for _, p := range sharedProjects {
+				findings = append(findings, checks.OctopusCheckFinding{
+					ResourceType: checks.ProjectResource,
+					ResourceId:   p.ID,
+					ResourceName: p.Name,
+					SpaceId:      o.client.GetSpaceID(),
+					Details:      u,
+					Link:         o.urlBuilder.ProjectUrl(p.ID),
+				})
+			}
 		}
 
-		return checks.NewOctopusCheckResultImpl(
-			"The following Git usernames have been reused across the following projects:\n"+strings.Join(message, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following projects share Git usernames with other projects:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			findings), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No Git usernames have been resued",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
+
+// gitUsername returns the username a version controlled project uses to access its Git repository, or an empty string
+// if the project does not authenticate with a username and password.
+func gitUsername(project *projects.Project) string {
+	gitPersistenceSettings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings)
+
+	if !ok {
+		return ""
+	}
+
+	usernamePassword, ok := gitPersistenceSettings.Credential().(*credentials.UsernamePassword)
+
+	if !ok {
+		return ""
+	}
+
+	return usernamePassword.Username
+}
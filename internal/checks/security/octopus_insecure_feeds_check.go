@@ -1,32 +1,38 @@
 package security
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 	"strings"
 )
 
+// feedTypesWithUrls are the feed types that are configured with the URL of an external repository
+var feedTypesWithUrls = []feeds.FeedType{"ArtifactoryGeneric", "NuGet", "Maven", "Helm", "GitHub", "Docker"}
+
 // OctopusInsecureFeedsCheck checks to see if any targets have not been used in a month
 type OctopusInsecureFeedsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInsecureFeedsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureFeedsCheck {
-	return OctopusInsecureFeedsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusInsecureFeedsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureFeedsCheck {
+	return OctopusInsecureFeedsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusInsecureFeedsCheck) Id() string {
 	return "OctoLintInsecureFeedsTargets"
 }
 
-func (o OctopusInsecureFeedsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInsecureFeedsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -37,73 +43,48 @@ func (o OctopusInsecureFeedsCheck) Execute(concurrency int) (checks.OctopusCheck
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := o.client.Feeds.GetAll()
+	targets, err := o.client.GetFeeds(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	insecureFeeds := []string{}
-	for i, m := range targets {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(targets))*100) + "% complete")
-
-		if m.GetFeedType() == "ArtifactoryGeneric" {
-			typedFeed := m.(*feeds.ArtifactoryGenericFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "NuGet" {
-			typedFeed := m.(*feeds.NuGetFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Maven" {
-			typedFeed := m.(*feeds.MavenFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
-
-		if m.GetFeedType() == "Helm" {
-			typedFeed := m.(*feeds.HelmFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
+	insecureFeeds := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(targets))
 
-		if m.GetFeedType() == "GitHub" {
-			typedFeed := m.(*feeds.GitHubRepositoryFeed)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
-		}
+	for _, m := range targets {
+		progress.Complete(1)
 
-		if m.GetFeedType() == "Docker" {
-			typedFeed := m.(*feeds.DockerContainerRegistry)
-			if strings.HasPrefix(typedFeed.FeedURI, "http://") {
-				insecureFeeds = append(insecureFeeds, m.GetName())
-			}
+		if slices.Contains(feedTypesWithUrls, m.GetFeedType()) && strings.HasPrefix(m.FeedURI, "http://") {
+			insecureFeeds = append(insecureFeeds, o.feedFinding(m))
 		}
-
 	}
 
 	if len(insecureFeeds) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following feeds use an insecure HTTP endpoint:\n"+strings.Join(insecureFeeds, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following feeds use an insecure HTTP endpoint:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			insecureFeeds), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no insecure feeds",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
+
+func (o OctopusInsecureFeedsCheck) feedFinding(feed *feeds.FeedResource) checks.OctopusCheckFinding {
+	return checks.OctopusCheckFinding{
+		ResourceType: checks.FeedResource,
+		ResourceId:   feed.GetID(),
+		ResourceName: feed.GetName(),
+		SpaceId:      o.client.GetSpaceID(),
+		Link:         o.urlBuilder.FeedUrl(feed.GetID()),
+	}
+}
@@ -1,9 +1,8 @@
 package security
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
@@ -17,20 +16,22 @@ const OctoLintInsecureK8sTargets = "OctoLintInsecureK8sTargets"
 
 // OctopusInsecureK8sCheck checks to see if any targets have not been used in a month
 type OctopusInsecureK8sCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInsecureK8sCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureK8sCheck {
-	return OctopusInsecureK8sCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusInsecureK8sCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureK8sCheck {
+	return OctopusInsecureK8sCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusInsecureK8sCheck) Id() string {
 	return OctoLintInsecureK8sTargets
 }
 
-func (o OctopusInsecureK8sCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInsecureK8sCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -41,7 +42,7 @@ func (o OctopusInsecureK8sCheck) Execute(concurrency int) (checks.OctopusCheckRe
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	targets, err := client_wrapper.GetMachines(o.config.MaxInsecureK8sTargets, o.client, o.client.GetSpaceID())
+	targets, err := o.cache.GetMachines(ctx, o.config.CheckInt(o.Id(), "maxTargets", o.config.MaxInsecureK8sTargets))
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
@@ -51,30 +52,40 @@ func (o OctopusInsecureK8sCheck) Execute(concurrency int) (checks.OctopusCheckRe
 		return item.Endpoint != nil && item.Endpoint.GetCommunicationStyle() == "Kubernetes"
 	})
 
-	insecureMachines := []string{}
-	for i, m := range k8sTargets {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(k8sTargets))*100) + "% complete")
+	insecureMachines := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(k8sTargets))
+
+	for _, m := range k8sTargets {
+		progress.Complete(1)
 
 		k8sEndpoint := m.Endpoint.(*machines.KubernetesEndpoint)
 		if k8sEndpoint.SkipTLSVerification || (k8sEndpoint.ClusterURL != nil && strings.HasPrefix(k8sEndpoint.ClusterURL.String(), "http://")) {
-			insecureMachines = append(insecureMachines, m.Name)
+			insecureMachines = append(insecureMachines, checks.OctopusCheckFinding{
+				ResourceType: checks.TargetResource,
+				ResourceId:   m.ID,
+				ResourceName: m.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Link:         o.urlBuilder.TargetUrl(m.ID),
+			})
 		}
 
 	}
 
 	if len(insecureMachines) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following Kubernetes targets skip TLS validation or use an insecure HTTP endpoint:\n"+strings.Join(insecureMachines, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following Kubernetes targets skip TLS validation or use an insecure HTTP endpoint:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			insecureMachines), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no insecure Kubernetes targets",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
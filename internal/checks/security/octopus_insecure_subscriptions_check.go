@@ -1,12 +1,10 @@
 package security
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services/api"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
 	"strings"
@@ -14,20 +12,22 @@ import (
 
 // OctopusInsecureSubscriptionsCheck checks to see if any targets have not been used in a month
 type OctopusInsecureSubscriptionsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusInsecureSubscriptionsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureSubscriptionsCheck {
-	return OctopusInsecureSubscriptionsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusInsecureSubscriptionsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusInsecureSubscriptionsCheck {
+	return OctopusInsecureSubscriptionsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusInsecureSubscriptionsCheck) Id() string {
 	return "OctoLintInsecureWebhookUrls"
 }
 
-func (o OctopusInsecureSubscriptionsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusInsecureSubscriptionsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -38,45 +38,45 @@ func (o OctopusInsecureSubscriptionsCheck) Execute(concurrency int) (checks.Octo
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	collection := resources.Resources[*OctopusSubscription]{}
-	_, err := api.ApiGet(o.client.Subscriptions.GetClient(), &collection, o.client.Subscriptions.BasePath+"?skip=0&take=2147483647")
+	subscriptions, err := o.client.GetSubscriptions(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	insecureItems := []string{}
-	for i, m := range collection.Items {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(collection.Items))*100) + "% complete")
+	insecureItems := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(subscriptions))
+
+	for _, m := range subscriptions {
+		progress.Complete(1)
 
 		if m.EventNotificationSubscription != nil && strings.HasPrefix(m.EventNotificationSubscription.WebhookURI, "http://") {
-			insecureItems = append(insecureItems, m.Name)
+			insecureItems = append(insecureItems, checks.OctopusCheckFinding{
+				ResourceType: checks.SubscriptionResource,
+				ResourceId:   m.Id,
+				ResourceName: m.Name,
+				SpaceId:      o.client.GetSpaceID(),
+				Link:         o.urlBuilder.SubscriptionUrl(m.Id),
+			})
 		}
 
 	}
 
 	if len(insecureItems) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following subscriptions use an insecure HTTP webhook URL:\n"+strings.Join(insecureItems, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following subscriptions use an insecure HTTP webhook URL:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			insecureItems), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no insecure subscriptions",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
-
-type OctopusSubscription struct {
-	Name                          string
-	EventNotificationSubscription *OctopusEventNotificationSubscription
-}
-
-type OctopusEventNotificationSubscription struct {
-	WebhookURI string
-}
@@ -1,45 +1,32 @@
 package security
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
-	"regexp"
-	"strings"
-	"time"
 )
 
-type APIKeyKey struct {
-	Hint *string
-}
-
-// APIKey is used because the go client_wrapper has an invalid APIKey value that prevents the usual functions for querying users keys
-type APIKey struct {
-	APIKey  APIKeyKey  `json:"ApiKey,omitempty"`
-	Expires *time.Time `json:"Expires,omitempty"`
-}
-
 // OctopusPerpetualApiKeysCheck reports on any perpetual api keys
 type OctopusPerpetualApiKeysCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusPerpetualApiKeysCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusPerpetualApiKeysCheck {
-	return OctopusPerpetualApiKeysCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusPerpetualApiKeysCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusPerpetualApiKeysCheck {
+	return OctopusPerpetualApiKeysCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusPerpetualApiKeysCheck) Id() string {
 	return "OctoLintPerpetualApiKeys"
 }
 
-func (o OctopusPerpetualApiKeysCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusPerpetualApiKeysCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -50,19 +37,24 @@ func (o OctopusPerpetualApiKeysCheck) Execute(concurrency int) (checks.OctopusCh
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	users, err := o.client.Users.GetAll()
+	users, err := o.client.GetUsers(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	linksTemplate := regexp.MustCompile(`\{.+\}`)
-	perpetualApiKeys := []string{}
-	for i, u := range users {
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(users))*100) + "% complete")
+	perpetualApiKeys := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(users))
+
+	for _, u := range users {
+		progress.Complete(1)
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-		apiKeysLink := linksTemplate.ReplaceAllString(u.Links["ApiKeys"], "")
-		keys, err := newclient.Get[resources.Resources[APIKey]](o.client.HttpSession(), apiKeysLink)
+		keys, err := o.client.GetUserApiKeys(ctx, u)
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -71,26 +63,35 @@ func (o OctopusPerpetualApiKeysCheck) Execute(concurrency int) (checks.OctopusCh
 			continue
 		}
 
-		for _, k := range keys.Items {
+		for _, k := range keys {
 			if k.Expires == nil && k.APIKey.Hint != nil && u.Username != "guest" {
-				perpetualApiKeys = append(perpetualApiKeys, *k.APIKey.Hint+"... ("+u.Username+")")
+				perpetualApiKeys = append(perpetualApiKeys, checks.OctopusCheckFinding{
+					ResourceType: checks.ApiKeyResource,
+					ResourceId:   k.ID,
+					ResourceName: *k.APIKey.Hint + "...",
+					ParentType:   checks.UserResource,
+					ParentId:     u.ID,
+					ParentName:   u.Username,
+					Link:         o.urlBuilder.UserUrl(u.ID),
+				})
 			}
 		}
 	}
 
 	if len(perpetualApiKeys) != 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following API keys do not expire:\n"+strings.Join(perpetualApiKeys, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following API keys do not expire:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			perpetualApiKeys), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"No perpetual API keys found",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
@@ -1,39 +1,39 @@
 package security
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
-	"net/url"
-	"strings"
 	"time"
 )
 
-const maxTimeSinceAccountEdit = time.Hour * 24 * 90
+const OctoLintUnrotatedAccounts = "OctoLintUnrotatedAccounts"
+
+const maxDaysSinceAccountEdit = 90
 
 // OctopusUnrotatedAccountsCheck checks to see if any targets have not been used in a month
 type OctopusUnrotatedAccountsCheck struct {
-	client       *client.Client
+	client       client_wrapper.OctopusDataSource
+	cache        *client_wrapper.OctopusClientCache
 	errorHandler checks.OctopusClientErrorHandler
 	config       *config.OctolintConfig
+	urlBuilder   checks.OctopusUrlBuilder
 }
 
-func NewOctopusUnrotatedAccountsCheck(client *client.Client, config *config.OctolintConfig, errorHandler checks.OctopusClientErrorHandler) OctopusUnrotatedAccountsCheck {
-	return OctopusUnrotatedAccountsCheck{config: config, client: client, errorHandler: errorHandler}
+func NewOctopusUnrotatedAccountsCheck(client client_wrapper.OctopusDataSource, cache *client_wrapper.OctopusClientCache, config *config.OctolintConfig, urlBuilder checks.OctopusUrlBuilder, errorHandler checks.OctopusClientErrorHandler) OctopusUnrotatedAccountsCheck {
+	return OctopusUnrotatedAccountsCheck{config: config, client: client, cache: cache, errorHandler: errorHandler, urlBuilder: urlBuilder}
 }
 
 func (o OctopusUnrotatedAccountsCheck) Id() string {
-	return "OctoLintUnrotatedAccounts"
+	return OctoLintUnrotatedAccounts
 }
 
-func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o OctopusUnrotatedAccountsCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	if o.client == nil {
 		return nil, errors.New("octoclient is nil")
 	}
@@ -44,20 +44,23 @@ func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusC
 		zap.L().Debug("Ended check " + o.Id())
 	}()
 
-	now := time.Now()
-	start := now.Add(maxTimeSinceAccountEdit * -1)
+	now := o.client.Now()
+	start := now.Add(-time.Hour * 24 * time.Duration(o.config.CheckInt(o.Id(), "maxDaysSinceEdit", maxDaysSinceAccountEdit)))
 	end := now
 
-	allAccounts, err := o.GetAll(o.client, o.client.GetSpaceID())
+	allAccounts, err := o.client.GetAccounts(ctx)
 
 	if err != nil {
 		return o.errorHandler.HandleError(o.Id(), checks.Security, err)
 	}
 
-	uneditedAccounts := []string{}
-	for i, m := range allAccounts {
+	uneditedAccounts := []checks.OctopusCheckFinding{}
+	progress := checks.Progress(ctx)
+	progress.AddTotal(len(allAccounts))
+
+	for _, m := range allAccounts {
 
-		zap.L().Debug(o.Id() + " " + fmt.Sprintf("%.2f", float32(i+1)/float32(len(allAccounts))*100) + "% complete")
+		progress.Complete(1)
 
 		// Skip OIDC accounts
 		if m.GetAccountType() == "AmazonWebServicesOidcAccount" {
@@ -68,7 +71,15 @@ func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusC
 			continue
 		}
 
-		audits, err := newclient.Get[resources.Resources[OctopusAudit]](o.client.HttpSession(), "/api/events?regardingAny="+m.GetID()+"&from="+url.QueryEscape(start.Format("2006-01-02T15:04:05-0700"))+"&to="+url.QueryEscape(end.Format("2006-01-02T15:04:05-0700")))
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		audits, err := o.client.GetEvents(ctx, events.EventsQuery{
+			RegardingAny: m.GetID(),
+			From:         start.Format("2006-01-02T15:04:05-0700"),
+			To:           end.Format("2006-01-02T15:04:05-0700"),
+		})
 
 		if err != nil {
 			if !o.errorHandler.ShouldContinue(err) {
@@ -85,34 +96,31 @@ func (o OctopusUnrotatedAccountsCheck) Execute(concurrency int) (checks.OctopusC
 		}
 
 		if !recentEdit {
-			uneditedAccounts = append(uneditedAccounts, m.GetName())
+			uneditedAccounts = append(uneditedAccounts, checks.OctopusCheckFinding{
+				ResourceType: checks.AccountResource,
+				ResourceId:   m.GetID(),
+				ResourceName: m.GetName(),
+				SpaceId:      o.client.GetSpaceID(),
+				Link:         o.urlBuilder.AccountUrl(m.GetID()),
+			})
 		}
 
 	}
 
 	if len(uneditedAccounts) > 0 {
-		return checks.NewOctopusCheckResultImpl(
-			"The following accounts have not been updated in 90 days:\n"+strings.Join(uneditedAccounts, "\n"),
+		return checks.NewOctopusCheckResultWithFindings(
+			"The following accounts have not been updated in 90 days:",
 			o.Id(),
-			"",
+			checks.WikiLink(o.Id()),
 			checks.Warning,
-			checks.Security), nil
+			checks.Security,
+			uneditedAccounts), nil
 	}
 
 	return checks.NewOctopusCheckResultImpl(
 		"There are no unedited accounts",
 		o.Id(),
-		"",
+		checks.WikiLink(o.Id()),
 		checks.Ok,
 		checks.Security), nil
 }
-
-type OctopusAudit struct {
-	Category           string
-	RelatedDocumentIds []string
-}
-
-func (o OctopusUnrotatedAccountsCheck) GetAll(client newclient.Client, spaceID string) ([]*accounts.AccountResource, error) {
-	items, err := newclient.GetAll[accounts.AccountResource](client, "/api/{spaceId}/accounts", spaceID)
-	return items, err
-}
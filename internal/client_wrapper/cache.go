@@ -0,0 +1,167 @@
+package client_wrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"go.uber.org/zap"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// OctopusClientCache memoizes the resources returned by the Octopus API. A new cache is created for each run and
+// shared by all the checks, so each resource is fetched once per run. Errors are cached too, so a resource that
+// could not be read is reported consistently by every check.
+//
+// The returned resources are shared between checks, and must not be modified.
+type OctopusClientCache struct {
+	dataSource OctopusDataSource
+	mu         sync.Mutex
+	entries    map[string]*cacheEntry
+	hits       atomic.Int64
+	misses     atomic.Int64
+}
+
+type cacheEntry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+func NewOctopusClientCache(dataSource OctopusDataSource) *OctopusClientCache {
+	return &OctopusClientCache{dataSource: dataSource, entries: map[string]*cacheEntry{}}
+}
+
+// Hits returns the number of requests served from the cache.
+func (c *OctopusClientCache) Hits() int64 {
+	return c.hits.Load()
+}
+
+// Misses returns the number of requests that were passed through to the Octopus API.
+func (c *OctopusClientCache) Misses() int64 {
+	return c.misses.Load()
+}
+
+// LogStatistics writes the cache hit and miss counts to the verbose logs.
+func (c *OctopusClientCache) LogStatistics() {
+	zap.L().Debug("Cache hits for " + c.dataSource.GetSpaceID() + ": " + fmt.Sprint(c.Hits()) + ", cache misses: " + fmt.Sprint(c.Misses()))
+}
+
+func (c *OctopusClientCache) GetProjectsWithFilter(ctx context.Context, excludeProjectsExcept config.StringSliceArgs, excludeProjects config.StringSliceArgs, maxItems int) ([]*projects.Project, error) {
+	key := "Projects:" + strings.Join(excludeProjectsExcept, ",") + ":" + strings.Join(excludeProjects, ",") + ":" + fmt.Sprint(maxItems)
+	return getCached(ctx, c, key, func() ([]*projects.Project, error) {
+		return GetProjectsWithFilter(ctx, c.dataSource, excludeProjectsExcept, excludeProjects, maxItems)
+	})
+}
+
+func (c *OctopusClientCache) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	return getCached(ctx, c, "Machines:"+fmt.Sprint(limit), func() ([]*machines.DeploymentTarget, error) {
+		return c.dataSource.GetMachines(ctx, limit)
+	})
+}
+
+func (c *OctopusClientCache) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	return getCached(ctx, c, "Environments:"+fmt.Sprint(limit), func() ([]*environments.Environment, error) {
+		return c.dataSource.GetEnvironments(ctx, limit)
+	})
+}
+
+func (c *OctopusClientCache) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	return getCached(ctx, c, "Tenants:"+fmt.Sprint(limit), func() ([]*tenants.Tenant, error) {
+		return c.dataSource.GetTenants(ctx, limit)
+	})
+}
+
+func (c *OctopusClientCache) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	return getCached(ctx, c, "Lifecycles", func() ([]*lifecycles.Lifecycle, error) {
+		return c.dataSource.GetLifecycles(ctx)
+	})
+}
+
+func (c *OctopusClientCache) GetVariables(ctx context.Context, project *projects.Project) (variables.VariableSet, error) {
+	return getCached(ctx, c, "Variables:"+project.ID, func() (variables.VariableSet, error) {
+		return c.dataSource.GetVariables(ctx, project)
+	})
+}
+
+func (c *OctopusClientCache) GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	return getCached(ctx, c, "DeploymentProcess:"+project.ID, func() (*deployments.DeploymentProcess, error) {
+		return c.dataSource.GetDeploymentProcess(ctx, project)
+	})
+}
+
+func (c *OctopusClientCache) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	return getCached(ctx, c, "ProjectRunbooks:"+project.ID, func() ([]*runbooks.Runbook, error) {
+		return c.dataSource.GetProjectRunbooks(ctx, project)
+	})
+}
+
+func (c *OctopusClientCache) GetRunbookProcess(ctx context.Context, project *projects.Project, runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error) {
+	return getCached(ctx, c, "RunbookProcess:"+project.ID+":"+runbook.ID, func() (*runbooks.RunbookProcess, error) {
+		return c.dataSource.GetRunbookProcess(ctx, project, runbook)
+	})
+}
+
+// getCached returns the value saved against the key, calling fetch to populate the cache the first time the key
+// is requested. Concurrent requests for the same key wait for the first fetch to complete.
+//
+// A fetch that was cancelled by the context of the check that triggered it is not cached, as another check may
+// still have time to complete the request.
+func getCached[T any](ctx context.Context, c *OctopusClientCache, key string, fetch func() (T, error)) (T, error) {
+	var empty T
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return empty, err
+		}
+
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		if !ok {
+			entry = &cacheEntry{}
+			c.entries[key] = entry
+		}
+		c.mu.Unlock()
+
+		if ok {
+			c.hits.Add(1)
+		} else {
+			c.misses.Add(1)
+		}
+
+		fetched := false
+		entry.once.Do(func() {
+			fetched = true
+			entry.value, entry.err = fetch()
+		})
+
+		if errors.Is(entry.err, context.Canceled) || errors.Is(entry.err, context.DeadlineExceeded) {
+			c.mu.Lock()
+			if c.entries[key] == entry {
+				delete(c.entries, key)
+			}
+			c.mu.Unlock()
+
+			// The fetch was cancelled by another check, so try again with this context
+			if !fetched {
+				continue
+			}
+		}
+
+		if entry.err != nil {
+			return empty, entry.err
+		}
+
+		return entry.value.(T), nil
+	}
+}
@@ -0,0 +1,132 @@
+package client_wrapper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestCacheHitsAndMisses(t *testing.T) {
+	cache := NewOctopusClientCache(nil)
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"Projects-1"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := getCached(context.Background(), cache, "Projects", fetch)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(value) != 1 || value[0] != "Projects-1" {
+			t.Fatalf("unexpected value %v", value)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("fetch should have been called once, but was called %d times", calls)
+	}
+
+	if cache.Hits() != 2 || cache.Misses() != 1 {
+		t.Fatalf("expected 2 hits and 1 miss, got %d hits and %d misses", cache.Hits(), cache.Misses())
+	}
+}
+
+func TestCacheSeparatesKeys(t *testing.T) {
+	cache := NewOctopusClientCache(nil)
+
+	first, _ := getCached(context.Background(), cache, "Variables:Projects-1", func() (string, error) { return "first", nil })
+	second, _ := getCached(context.Background(), cache, "Variables:Projects-2", func() (string, error) { return "second", nil })
+
+	if first != "first" || second != "second" {
+		t.Fatalf("unexpected values %s and %s", first, second)
+	}
+
+	if cache.Misses() != 2 {
+		t.Fatalf("expected 2 misses, got %d", cache.Misses())
+	}
+}
+
+func TestCacheErrors(t *testing.T) {
+	cache := NewOctopusClientCache(nil)
+	calls := 0
+	fetch := func() (*string, error) {
+		calls++
+		return nil, errors.New("forbidden")
+	}
+
+	for i := 0; i < 2; i++ {
+		value, err := getCached(context.Background(), cache, "Lifecycles", fetch)
+
+		if err == nil || value != nil {
+			t.Fatal("the error should have been returned")
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("fetch should have been called once, but was called %d times", calls)
+	}
+}
+
+func TestCacheConcurrentRequests(t *testing.T) {
+	cache := NewOctopusClientCache(nil)
+	var mu sync.Mutex
+	calls := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = getCached(context.Background(), cache, "Machines", func() (int, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				return 1, nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("fetch should have been called once, but was called %d times", calls)
+	}
+
+	if cache.Hits()+cache.Misses() != 10 {
+		t.Fatalf("expected 10 requests, got %d", cache.Hits()+cache.Misses())
+	}
+}
+
+func TestCacheCancelledFetch(t *testing.T) {
+	cache := NewOctopusClientCache(nil)
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := getCached(cancelledCtx, cache, "Tenants", func() (int, error) { return 1, nil })
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatal("the cancelled context should have returned an error")
+	}
+
+	// A fetch that was cancelled by another context is not cached
+	_, err = getCached(context.Background(), cache, "Environments", func() (int, error) { return 0, context.DeadlineExceeded })
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("the cancelled fetch should have returned an error")
+	}
+
+	calls := 0
+	value, err := getCached(context.Background(), cache, "Environments", func() (int, error) {
+		calls++
+		return 2, nil
+	})
+
+	if err != nil || value != 2 || calls != 1 {
+		t.Fatal("the cancelled fetch should not have been cached")
+	}
+}
@@ -0,0 +1,24 @@
+package client_wrapper
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"net/http"
+	"net/url"
+)
+
+// NewHttpClient creates the HTTP client used for all requests to the Octopus API. Each retry is sent through the
+// supplied transport, so retries are subject to any rate limits.
+func NewHttpClient(transport http.RoundTripper, retryPolicy RetryPolicy) *http.Client {
+	return &http.Client{Transport: NewRetryTransport(transport, retryPolicy)}
+}
+
+// CreateClient creates an Octopus client that sends requests with the supplied HTTP client.
+func CreateClient(httpClient *http.Client, octopusUrl string, spaceId string, apiKey string) (*client.Client, error) {
+	apiUrl, err := url.Parse(octopusUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.NewClient(httpClient, apiUrl, apiKey, spaceId)
+}
@@ -0,0 +1,86 @@
+package client_wrapper
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"time"
+)
+
+// OctopusDataSource provides the resources read by the checks. The checks read everything through this interface,
+// rather than the Octopus client, so they can be run against a live server or a snapshot saved by an earlier run.
+//
+// A data source is scoped to a space, or to the whole server when the space ID is empty. The resources of version
+// controlled projects are read from a single Git reference chosen when the data source is created.
+type OctopusDataSource interface {
+	// GetSpaceID returns the ID of the space the resources are read from.
+	GetSpaceID() string
+	// Now returns the time the checks are run at. Checks use this time, rather than the system clock, so a
+	// snapshot reports the same results as the run that saved it.
+	Now() time.Time
+
+	// GetProjects returns up to limit projects, or all the projects if limit is 0.
+	GetProjects(ctx context.Context, limit int) ([]*projects.Project, error)
+	// GetProjectByName returns the project with the exact name, or an empty slice if there is no such project.
+	GetProjectByName(ctx context.Context, name string) ([]*projects.Project, error)
+	GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error)
+	GetProjectGroupProjects(ctx context.Context, projectGroup *projectgroups.ProjectGroup) ([]*projects.Project, error)
+	GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error)
+	GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error)
+	// GetMachineDeploymentTasks returns the most recent deployment tasks that ran on the machine.
+	GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error)
+	GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error)
+	GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error)
+	GetChannels(ctx context.Context) ([]*channels.Channel, error)
+	GetFeeds(ctx context.Context) ([]*feeds.FeedResource, error)
+	GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error)
+	GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error)
+	GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error)
+	GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error)
+	GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error)
+	// GetProjectRunbooks returns the runbooks of the project, including runbooks stored in Git.
+	GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error)
+	// GetVariables returns the project variables. The variables of a version controlled project are read from Git,
+	// and include the sensitive variables, which are always stored in the database.
+	GetVariables(ctx context.Context, project *projects.Project) (variables.VariableSet, error)
+	// GetDeploymentProcess returns the deployment process of the project, which is read from Git for a version
+	// controlled project.
+	GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error)
+	GetRunbookProcess(ctx context.Context, project *projects.Project, runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error)
+	GetDeployment(ctx context.Context, deploymentId string) (*deployments.Deployment, error)
+	GetEvents(ctx context.Context, query events.EventsQuery) (*resources.Resources[*events.Event], error)
+	GetTasks(ctx context.Context, query tasks.TasksQuery) (*resources.Resources[*tasks.Task], error)
+
+	GetUsers(ctx context.Context) ([]*users.User, error)
+	GetUserApiKeys(ctx context.Context, user *users.User) ([]*APIKey, error)
+	// GetTeams returns all the teams, including the built in system teams.
+	GetTeams(ctx context.Context) ([]*teams.Team, error)
+	GetTeamScopedUserRoles(ctx context.Context, team *teams.Team) ([]*userroles.ScopedUserRole, error)
+	GetUserRoles(ctx context.Context) ([]*userroles.UserRole, error)
+	GetAuthentication(ctx context.Context) (*authentication.Authentication, error)
+	GetLicenseStatus(ctx context.Context) (*LicenseStatus, error)
+	GetServerNodes(ctx context.Context) ([]*octopusservernodes.OctopusServerNodeResource, error)
+	GetConfigurationSections(ctx context.Context) ([]*configuration.ConfigurationSection, error)
+	GetConfigurationValues(ctx context.Context, section *configuration.ConfigurationSection) (map[string]any, error)
+}
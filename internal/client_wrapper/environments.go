@@ -1,11 +1,16 @@
 package client_wrapper
 
 import (
+	"context"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 )
 
-func GetEnvironments(limit int, client newclient.Client, spaceID string) ([]*environments.Environment, error) {
+func GetEnvironments(ctx context.Context, limit int, client newclient.Client, spaceID string) ([]*environments.Environment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if limit == 0 {
 		return environments.GetAll(client, spaceID)
 	}
@@ -0,0 +1,297 @@
+package client_wrapper
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	"regexp"
+	"sync"
+)
+
+// ResourceFilters holds the include and exclude filters of each resource type. A resource is read by the checks if it
+// matches any of the include filters of its type, or its type has no include filters, and it does not match any of
+// the exclude filters of its type.
+type ResourceFilters struct {
+	include map[string][]resourceMatcher
+	exclude map[string][]resourceMatcher
+}
+
+type resourceMatcher struct {
+	kind  string
+	value string
+	regex *regexp.Regexp
+}
+
+// filteredResource holds the properties of a resource that filters match against.
+type filteredResource struct {
+	name string
+	// tags are the canonical names of the tenant tags assigned to the resource
+	tags []string
+	// projectGroup is the name and ID of the group of a project
+	projectGroup []string
+}
+
+// NewResourceFilters creates the filters passed to the include and exclude arguments. The excludeProjects,
+// excludeProjectsRegex, and excludeProjectsExcept arguments are converted to project filters.
+func NewResourceFilters(octolintConfig *config.OctolintConfig) (*ResourceFilters, error) {
+	include, err := config.ParseResourceFilters(octolintConfig.Include)
+
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := config.ParseResourceFilters(octolintConfig.Exclude)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range octolintConfig.ExcludeProjectsExcept {
+		include = append(include, config.ResourceFilter{Resource: config.ProjectFilterResource, Kind: config.NameFilter, Value: name})
+	}
+
+	for _, name := range octolintConfig.ExcludeProjects {
+		exclude = append(exclude, config.ResourceFilter{Resource: config.ProjectFilterResource, Kind: config.NameFilter, Value: name})
+	}
+
+	for _, regex := range octolintConfig.ExcludeProjectsRegex {
+		exclude = append(exclude, config.ResourceFilter{Resource: config.ProjectFilterResource, Kind: config.RegexFilter, Value: regex})
+	}
+
+	filters := &ResourceFilters{
+		include: map[string][]resourceMatcher{},
+		exclude: map[string][]resourceMatcher{},
+	}
+
+	if err := filters.add(filters.include, include); err != nil {
+		return nil, err
+	}
+
+	if err := filters.add(filters.exclude, exclude); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+func (f *ResourceFilters) add(matchers map[string][]resourceMatcher, filters []config.ResourceFilter) error {
+	for _, filter := range filters {
+		// Empty names are ignored, matching the behaviour of the excludeProjectsExcept argument
+		if filter.Value == "" {
+			continue
+		}
+
+		matcher := resourceMatcher{kind: filter.Kind, value: filter.Value}
+
+		if filter.Kind == config.RegexFilter {
+			regex, err := regexp.Compile(filter.Value)
+
+			if err != nil {
+				return err
+			}
+
+			matcher.regex = regex
+		}
+
+		matchers[filter.Resource] = append(matchers[filter.Resource], matcher)
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if there are no filters.
+func (f *ResourceFilters) IsEmpty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// hasFilter returns true if any filter of the resource type has the kind.
+func (f *ResourceFilters) hasFilter(resourceType string, kind string) bool {
+	hasKind := func(item resourceMatcher) bool {
+		return item.kind == kind
+	}
+
+	return lo.ContainsBy(f.include[resourceType], hasKind) || lo.ContainsBy(f.exclude[resourceType], hasKind)
+}
+
+// sourceLimit returns the number of resources to read from the wrapped data source. Filtering a truncated list would
+// only test the first resources, so every resource is read when the resource type has filters, and the limit is
+// applied after filtering.
+func (f *ResourceFilters) sourceLimit(resourceType string, limit int) int {
+	if len(f.include[resourceType]) != 0 || len(f.exclude[resourceType]) != 0 {
+		return 0
+	}
+
+	return limit
+}
+
+// includes returns true if the resource is read by the checks.
+func (f *ResourceFilters) includes(resourceType string, resource filteredResource) bool {
+	if include := f.include[resourceType]; len(include) != 0 && !lo.ContainsBy(include, func(item resourceMatcher) bool { return item.matches(resource) }) {
+		return false
+	}
+
+	return !lo.ContainsBy(f.exclude[resourceType], func(item resourceMatcher) bool { return item.matches(resource) })
+}
+
+func (m resourceMatcher) matches(resource filteredResource) bool {
+	switch m.kind {
+	case config.NameFilter:
+		return resource.name == m.value
+	case config.RegexFilter:
+		return m.regex.MatchString(resource.name)
+	case config.TagFilter:
+		return slices.Index(resource.tags, m.value) != -1
+	case config.ProjectGroupFilter:
+		return slices.Index(resource.projectGroup, m.value) != -1
+	}
+
+	return false
+}
+
+// FilteredDataSource removes the resources that are excluded by the filters from the results of another data source.
+// It is the data source passed to the checks, so every check reads the same resources.
+type FilteredDataSource struct {
+	OctopusDataSource
+	filters *ResourceFilters
+
+	projectGroupsMutex sync.Mutex
+	projectGroups      map[string]string
+}
+
+// NewFilteredDataSource applies the filters to a data source. The data source is returned as is if there are no
+// filters.
+func NewFilteredDataSource(dataSource OctopusDataSource, filters *ResourceFilters) OctopusDataSource {
+	if filters == nil || filters.IsEmpty() {
+		return dataSource
+	}
+
+	return &FilteredDataSource{OctopusDataSource: dataSource, filters: filters}
+}
+
+func (o *FilteredDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	result, err := o.OctopusDataSource.GetProjects(ctx, o.filters.sourceLimit(config.ProjectFilterResource, limit))
+	return o.filterProjects(ctx, result, err, limit)
+}
+
+func (o *FilteredDataSource) GetProjectByName(ctx context.Context, name string) ([]*projects.Project, error) {
+	result, err := o.OctopusDataSource.GetProjectByName(ctx, name)
+	return o.filterProjects(ctx, result, err, 0)
+}
+
+func (o *FilteredDataSource) GetProjectGroupProjects(ctx context.Context, projectGroup *projectgroups.ProjectGroup) ([]*projects.Project, error) {
+	result, err := o.OctopusDataSource.GetProjectGroupProjects(ctx, projectGroup)
+	return o.filterProjects(ctx, result, err, 0)
+}
+
+func (o *FilteredDataSource) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	result, err := o.OctopusDataSource.GetMachines(ctx, o.filters.sourceLimit(config.TargetFilterResource, limit))
+	return filterResources(o.filters, config.TargetFilterResource, result, err, limit, func(item *machines.DeploymentTarget) filteredResource {
+		return filteredResource{name: item.Name, tags: item.TenantTags}
+	})
+}
+
+func (o *FilteredDataSource) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	result, err := o.OctopusDataSource.GetTenants(ctx, o.filters.sourceLimit(config.TenantFilterResource, limit))
+	return filterResources(o.filters, config.TenantFilterResource, result, err, limit, func(item *tenants.Tenant) filteredResource {
+		return filteredResource{name: item.Name, tags: item.TenantTags}
+	})
+}
+
+func (o *FilteredDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	result, err := o.OctopusDataSource.GetEnvironments(ctx, o.filters.sourceLimit(config.EnvironmentFilterResource, limit))
+	return filterResources(o.filters, config.EnvironmentFilterResource, result, err, limit, func(item *environments.Environment) filteredResource {
+		return filteredResource{name: item.Name}
+	})
+}
+
+func (o *FilteredDataSource) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	result, err := o.OctopusDataSource.GetLifecycles(ctx)
+	return filterResources(o.filters, config.LifecycleFilterResource, result, err, 0, func(item *lifecycles.Lifecycle) filteredResource {
+		return filteredResource{name: item.Name}
+	})
+}
+
+func (o *FilteredDataSource) GetFeeds(ctx context.Context) ([]*feeds.FeedResource, error) {
+	result, err := o.OctopusDataSource.GetFeeds(ctx)
+	return filterResources(o.filters, config.FeedFilterResource, result, err, 0, func(item *feeds.FeedResource) filteredResource {
+		return filteredResource{name: item.Name}
+	})
+}
+
+func (o *FilteredDataSource) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	result, err := o.OctopusDataSource.GetAccounts(ctx)
+	return filterResources(o.filters, config.AccountFilterResource, result, err, 0, func(item *accounts.AccountResource) filteredResource {
+		return filteredResource{name: item.Name, tags: item.TenantTags}
+	})
+}
+
+func (o *FilteredDataSource) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	result, err := o.OctopusDataSource.GetWorkerPools(ctx)
+	return filterResources(o.filters, config.WorkerPoolFilterResource, result, err, 0, func(item *workerpools.WorkerPoolListResult) filteredResource {
+		return filteredResource{name: item.Name}
+	})
+}
+
+func (o *FilteredDataSource) filterProjects(ctx context.Context, result []*projects.Project, err error, limit int) ([]*projects.Project, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	projectGroups := map[string]string{}
+	if o.filters.hasFilter(config.ProjectFilterResource, config.ProjectGroupFilter) {
+		projectGroups, err = o.projectGroupNames(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return filterResources(o.filters, config.ProjectFilterResource, result, nil, limit, func(item *projects.Project) filteredResource {
+		return filteredResource{name: item.Name, projectGroup: []string{item.ProjectGroupID, projectGroups[item.ProjectGroupID]}}
+	})
+}
+
+// projectGroupNames maps the IDs of the project groups to their names. The project groups are only read once.
+func (o *FilteredDataSource) projectGroupNames(ctx context.Context) (map[string]string, error) {
+	o.projectGroupsMutex.Lock()
+	defer o.projectGroupsMutex.Unlock()
+
+	if o.projectGroups != nil {
+		return o.projectGroups, nil
+	}
+
+	projectGroups, err := o.OctopusDataSource.GetProjectGroups(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	o.projectGroups = map[string]string{}
+	for _, projectGroup := range projectGroups {
+		o.projectGroups[projectGroup.ID] = projectGroup.Name
+	}
+
+	return o.projectGroups, nil
+}
+
+// filterResources removes the excluded resources, and returns up to limit resources, or all the resources if limit
+// is 0.
+func filterResources[T any](filters *ResourceFilters, resourceType string, result []T, err error, limit int, describe func(item T) filteredResource) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return limitResources(lo.Filter(result, func(item T, index int) bool {
+		return filters.includes(resourceType, describe(item))
+	}), limit), nil
+}
@@ -0,0 +1,246 @@
+package client_wrapper
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/samber/lo"
+	"testing"
+)
+
+// unfilteredDataSource returns a fixed set of resources. Methods not implemented here panic, as the embedded
+// interface is nil.
+type unfilteredDataSource struct {
+	OctopusDataSource
+	projectGroupRequests int
+}
+
+func (o *unfilteredDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	return limitResources([]*projects.Project{
+		{Name: "Web App", ProjectGroupID: "ProjectGroups-1"},
+		{Name: "Test Web App", ProjectGroupID: "ProjectGroups-1"},
+		{Name: "Experiment", ProjectGroupID: "ProjectGroups-2"},
+	}, limit), nil
+}
+
+func (o *unfilteredDataSource) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	o.projectGroupRequests++
+	return []*projectgroups.ProjectGroup{
+		{Name: "Default Project Group", Resource: projectGroupResource("ProjectGroups-1")},
+		{Name: "Sandbox", Resource: projectGroupResource("ProjectGroups-2")},
+	}, nil
+}
+
+func (o *unfilteredDataSource) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	return limitResources([]*machines.DeploymentTarget{
+		newTarget("web-01", "Region/US"),
+		newTarget("web-02", "Region/EU"),
+		newTarget("test-01"),
+	}, limit), nil
+}
+
+func newTarget(name string, tenantTags ...string) *machines.DeploymentTarget {
+	target := &machines.DeploymentTarget{TenantTags: tenantTags}
+	target.Name = name
+	return target
+}
+
+func (o *unfilteredDataSource) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	return limitResources([]*tenants.Tenant{
+		{Name: "Acme", TenantTags: []string{"Region/US"}},
+		{Name: "Globex", TenantTags: []string{"Region/EU"}},
+	}, limit), nil
+}
+
+func (o *unfilteredDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	return limitResources([]*environments.Environment{{Name: "Development"}, {Name: "Production"}}, limit), nil
+}
+
+func projectGroupResource(id string) resources.Resource {
+	resource := resources.Resource{}
+	resource.ID = id
+	return resource
+}
+
+func newFilteredDataSource(t *testing.T, octolintConfig *config.OctolintConfig) (OctopusDataSource, *unfilteredDataSource) {
+	filters, err := NewResourceFilters(octolintConfig)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataSource := &unfilteredDataSource{}
+	return NewFilteredDataSource(dataSource, filters), dataSource
+}
+
+func names[T any](items []T, name func(item T) string) []string {
+	return lo.Map(items, func(item T, index int) string {
+		return name(item)
+	})
+}
+
+func TestNoFilters(t *testing.T) {
+	dataSource, unfiltered := newFilteredDataSource(t, &config.OctolintConfig{})
+
+	if dataSource != unfiltered {
+		t.Fatal("the data source must not be wrapped when there are no filters")
+	}
+}
+
+func TestExcludeProjectsRegex(t *testing.T) {
+	dataSource, unfiltered := newFilteredDataSource(t, &config.OctolintConfig{
+		ExcludeProjects:      config.StringSliceArgs{"Experiment"},
+		ExcludeProjectsRegex: config.StringSliceArgs{"^Test"},
+	})
+
+	allProjects, err := dataSource.GetProjects(context.Background(), 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if projectNames := names(allProjects, func(item *projects.Project) string { return item.Name }); len(projectNames) != 1 || projectNames[0] != "Web App" {
+		t.Fatalf("expected the projects matching the name and regex to be excluded, got %v", projectNames)
+	}
+
+	if unfiltered.projectGroupRequests != 0 {
+		t.Fatal("the project groups must only be read when a project group filter is used")
+	}
+}
+
+func TestIncludeProjectGroup(t *testing.T) {
+	dataSource, _ := newFilteredDataSource(t, &config.OctolintConfig{
+		Include: config.StringSliceArgs{"project:projectGroup=Sandbox"},
+	})
+
+	allProjects, err := dataSource.GetProjects(context.Background(), 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if projectNames := names(allProjects, func(item *projects.Project) string { return item.Name }); len(projectNames) != 1 || projectNames[0] != "Experiment" {
+		t.Fatalf("expected only the projects in the Sandbox group to be included, got %v", projectNames)
+	}
+}
+
+func TestFilterTargetsAndTenants(t *testing.T) {
+	dataSource, _ := newFilteredDataSource(t, &config.OctolintConfig{
+		Include: config.StringSliceArgs{"targets:regex=^web", "tenant:tag=Region/US"},
+		Exclude: config.StringSliceArgs{"machine:tag=Region/EU", "environment=Development"},
+	})
+
+	targets, err := dataSource.GetMachines(context.Background(), 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if targetNames := names(targets, func(item *machines.DeploymentTarget) string { return item.Name }); len(targetNames) != 1 || targetNames[0] != "web-01" {
+		t.Fatalf("expected only the included targets without the excluded tag, got %v", targetNames)
+	}
+
+	allTenants, err := dataSource.GetTenants(context.Background(), 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tenantNames := names(allTenants, func(item *tenants.Tenant) string { return item.Name }); len(tenantNames) != 1 || tenantNames[0] != "Acme" {
+		t.Fatalf("expected only the tenants with the included tag, got %v", tenantNames)
+	}
+
+	allEnvironments, err := dataSource.GetEnvironments(context.Background(), 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if environmentNames := names(allEnvironments, func(item *environments.Environment) string { return item.Name }); len(environmentNames) != 1 || environmentNames[0] != "Production" {
+		t.Fatalf("expected the excluded environment to be removed, got %v", environmentNames)
+	}
+}
+
+func TestIncludeResourcesBeyondLimit(t *testing.T) {
+	dataSource, _ := newFilteredDataSource(t, &config.OctolintConfig{
+		Include: config.StringSliceArgs{"target:regex=^test", "project:projectGroup=Sandbox", "tenant=Globex", "environment:regex=^Prod"},
+	})
+
+	targets, err := dataSource.GetMachines(context.Background(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if targetNames := names(targets, func(item *machines.DeploymentTarget) string { return item.Name }); len(targetNames) != 1 || targetNames[0] != "test-01" {
+		t.Fatalf("expected the included target after the limit to be returned, got %v", targetNames)
+	}
+
+	allProjects, err := dataSource.GetProjects(context.Background(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if projectNames := names(allProjects, func(item *projects.Project) string { return item.Name }); len(projectNames) != 1 || projectNames[0] != "Experiment" {
+		t.Fatalf("expected the included project after the limit to be returned, got %v", projectNames)
+	}
+
+	allTenants, err := dataSource.GetTenants(context.Background(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tenantNames := names(allTenants, func(item *tenants.Tenant) string { return item.Name }); len(tenantNames) != 1 || tenantNames[0] != "Globex" {
+		t.Fatalf("expected the included tenant after the limit to be returned, got %v", tenantNames)
+	}
+
+	allEnvironments, err := dataSource.GetEnvironments(context.Background(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if environmentNames := names(allEnvironments, func(item *environments.Environment) string { return item.Name }); len(environmentNames) != 1 || environmentNames[0] != "Production" {
+		t.Fatalf("expected the included environment after the limit to be returned, got %v", environmentNames)
+	}
+}
+
+func TestLimitAppliedAfterFiltering(t *testing.T) {
+	dataSource, _ := newFilteredDataSource(t, &config.OctolintConfig{
+		Include: config.StringSliceArgs{"target:regex=-0"},
+	})
+
+	targets, err := dataSource.GetMachines(context.Background(), 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("expected the filtered targets to be limited to 2, got %v", len(targets))
+	}
+}
+
+func TestInvalidFilters(t *testing.T) {
+	invalidFilters := []string{
+		"target",
+		"space=Default",
+		"target:size=large",
+		"environment:tag=Region/US",
+		"tenant:projectGroup=Sandbox",
+		"project:regex=[",
+	}
+
+	for _, filter := range invalidFilters {
+		if _, err := NewResourceFilters(&config.OctolintConfig{Exclude: config.StringSliceArgs{filter}}); err == nil {
+			t.Fatalf("expected the filter %s to be invalid", filter)
+		}
+	}
+}
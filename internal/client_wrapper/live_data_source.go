@@ -0,0 +1,441 @@
+package client_wrapper
+
+import (
+	"context"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/client"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/services/api"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"regexp"
+	"time"
+)
+
+// maxTake is the page size used to read every item of a collection in one request
+const maxTake = 2147483647
+
+// linksTemplate matches the URI template parameters in the links returned by the API
+var linksTemplate = regexp.MustCompile(`\{.*?}`)
+
+// LiveDataSource reads the resources from an Octopus server.
+type LiveDataSource struct {
+	client *client.Client
+	gitRef string
+	now    time.Time
+}
+
+// NewLiveDataSource creates a data source that reads from the server the client connects to. The time returned by
+// Now is fixed when the data source is created, so every check in a run uses the same time.
+//
+// The deployment processes, runbooks, and variables of version controlled projects are read from the gitRef branch,
+// tag, or commit. Each project's default branch is used when gitRef is empty.
+func NewLiveDataSource(client *client.Client, gitRef string) *LiveDataSource {
+	return &LiveDataSource{client: client, gitRef: gitRef, now: time.Now()}
+}
+
+func (o *LiveDataSource) GetSpaceID() string {
+	return o.client.GetSpaceID()
+}
+
+func (o *LiveDataSource) Now() time.Time {
+	return o.now
+}
+
+func (o *LiveDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	return GetProjects(ctx, limit, o.client, o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetProjectByName(ctx context.Context, name string) ([]*projects.Project, error) {
+	return GetProjectByName(ctx, name, o.client, o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.ProjectGroups.GetAll()
+}
+
+func (o *LiveDataSource) GetProjectGroupProjects(ctx context.Context, projectGroup *projectgroups.ProjectGroup) ([]*projects.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.ProjectGroups.GetProjects(projectGroup)
+}
+
+func (o *LiveDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	return GetEnvironments(ctx, limit, o.client, o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	return GetMachines(ctx, limit, o.client, o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tasksLink := linksTemplate.ReplaceAllString(machine.Links["TasksTemplate"], "")
+	result, err := newclient.Get[resources.Resources[*tasks.Task]](o.client.HttpSession(), tasksLink+"?type=Deployment")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+func (o *LiveDataSource) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	return GetTenants(ctx, limit, o.client, o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Lifecycles.GetAll()
+}
+
+func (o *LiveDataSource) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Channels.GetAll()
+}
+
+func (o *LiveDataSource) GetFeeds(ctx context.Context) ([]*feeds.FeedResource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newclient.GetAll[feeds.FeedResource](o.client, "/api/{spaceId}/feeds", o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return newclient.GetAll[accounts.AccountResource](o.client, "/api/{spaceId}/accounts", o.client.GetSpaceID())
+}
+
+func (o *LiveDataSource) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Certificates.GetAll()
+}
+
+func (o *LiveDataSource) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.WorkerPools.GetAll()
+}
+
+func (o *LiveDataSource) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	collection := resources.Resources[*OctopusSubscription]{}
+	_, err := api.ApiGet(o.client.Subscriptions.GetClient(), &collection, o.client.Subscriptions.BasePath+"?skip=0&take="+fmt.Sprint(maxTake))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Items, nil
+}
+
+func (o *LiveDataSource) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Runbooks.GetAll()
+}
+
+func (o *LiveDataSource) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if settings, gitRef := o.projectGitRef(project); settings != nil && settings.RunbooksAreInGit() {
+		result, err := runbooks.ListGitRunbooks(o.client, o.client.GetSpaceID(), project.ID, gitRef, "", maxTake)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Items, nil
+	}
+
+	link, ok := project.Links["Runbooks"]
+
+	if !ok {
+		return []*runbooks.Runbook{}, nil
+	}
+
+	result, err := newclient.Get[resources.Resources[*runbooks.Runbook]](o.client.HttpSession(), linksTemplate.ReplaceAllString(link, ""))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+func (o *LiveDataSource) GetVariables(ctx context.Context, project *projects.Project) (variables.VariableSet, error) {
+	if err := ctx.Err(); err != nil {
+		return variables.VariableSet{}, err
+	}
+
+	settings, gitRef := o.projectGitRef(project)
+
+	if settings == nil || !settings.VariablesAreInGit() {
+		return o.client.Variables.GetAll(project.ID)
+	}
+
+	gitVariables, err := o.client.ProjectVariables.GetAllByGitRef(o.client.GetSpaceID(), project.ID, gitRef)
+
+	if err != nil {
+		return variables.VariableSet{}, err
+	}
+
+	// Sensitive variables are never written to Git, so they are read from the database
+	databaseVariables, err := o.client.Variables.GetAll(project.ID)
+
+	if err != nil {
+		return variables.VariableSet{}, err
+	}
+
+	for _, variable := range databaseVariables.Variables {
+		if variable.IsSensitive {
+			gitVariables.Variables = append(gitVariables.Variables, variable)
+		}
+	}
+
+	return *gitVariables, nil
+}
+
+func (o *LiveDataSource) GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if settings, gitRef := o.projectGitRef(project); settings != nil {
+		return deployments.GetDeploymentProcessByGitRef(o.client, o.client.GetSpaceID(), project, gitRef)
+	}
+
+	return o.client.DeploymentProcesses.GetByID(project.DeploymentProcessID)
+}
+
+func (o *LiveDataSource) GetRunbookProcess(ctx context.Context, project *projects.Project, runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if settings, gitRef := o.projectGitRef(project); settings != nil && settings.RunbooksAreInGit() {
+		return runbooks.GetGitRunbookProcess(o.client, o.client.GetSpaceID(), project.ID, runbook.ID, gitRef)
+	}
+
+	return o.client.RunbookProcesses.GetByID(runbook.RunbookProcessID)
+}
+
+// projectGitRef returns the Git settings of a version controlled project, and the Git reference its deployment process,
+// runbooks, and variables are read from. This is the Git reference passed to the data source, or the default branch
+// of the project if no reference was passed. The settings are nil if the project is stored in the database.
+func (o *LiveDataSource) projectGitRef(project *projects.Project) (projects.GitPersistenceSettings, string) {
+	if project.PersistenceSettings == nil || project.PersistenceSettings.Type() != projects.PersistenceSettingsTypeVersionControlled {
+		return nil, ""
+	}
+
+	settings, ok := project.PersistenceSettings.(projects.GitPersistenceSettings)
+
+	if !ok {
+		return nil, ""
+	}
+
+	if o.gitRef != "" {
+		return settings, o.gitRef
+	}
+
+	return settings, settings.DefaultBranch()
+}
+
+func (o *LiveDataSource) GetDeployment(ctx context.Context, deploymentId string) (*deployments.Deployment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Deployments.GetByID(deploymentId)
+}
+
+func (o *LiveDataSource) GetEvents(ctx context.Context, query events.EventsQuery) (*resources.Resources[*events.Event], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Events.Get(query)
+}
+
+func (o *LiveDataSource) GetTasks(ctx context.Context, query tasks.TasksQuery) (*resources.Resources[*tasks.Task], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Tasks.Get(query)
+}
+
+func (o *LiveDataSource) GetUsers(ctx context.Context) ([]*users.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Users.GetAll()
+}
+
+func (o *LiveDataSource) GetUserApiKeys(ctx context.Context, user *users.User) ([]*APIKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	apiKeysLink := linksTemplate.ReplaceAllString(user.Links["ApiKeys"], "")
+	keys, err := newclient.Get[resources.Resources[*APIKey]](o.client.HttpSession(), apiKeysLink)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys.Items, nil
+}
+
+func (o *LiveDataSource) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := o.client.Teams.Get(teams.TeamsQuery{
+		IncludeSystem: true,
+		Take:          maxTake,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+func (o *LiveDataSource) GetTeamScopedUserRoles(ctx context.Context, team *teams.Team) ([]*userroles.ScopedUserRole, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result, err := o.client.Teams.GetScopedUserRoles(*team, core.SkipTakeQuery{Take: maxTake})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+func (o *LiveDataSource) GetUserRoles(ctx context.Context) ([]*userroles.UserRole, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.UserRoles.GetAll()
+}
+
+func (o *LiveDataSource) GetAuthentication(ctx context.Context) (*authentication.Authentication, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.client.Authentication.Get()
+}
+
+func (o *LiveDataSource) GetLicenseStatus(ctx context.Context) (*LicenseStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The go client does not expose the license status
+	return newclient.Get[LicenseStatus](o.client.HttpSession(), "/api/licenses/licenses-current-status")
+}
+
+func (o *LiveDataSource) GetServerNodes(ctx context.Context) ([]*octopusservernodes.OctopusServerNodeResource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The go client does not expose a way to list the server nodes
+	nodes, err := newclient.Get[resources.Resources[*octopusservernodes.OctopusServerNodeResource]](o.client.HttpSession(), "/api/octopusservernodes?take="+fmt.Sprint(maxTake))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes.Items, nil
+}
+
+func (o *LiveDataSource) GetConfigurationSections(ctx context.Context) ([]*configuration.ConfigurationSection, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sections, err := newclient.Get[resources.Resources[*configuration.ConfigurationSection]](o.client.HttpSession(), "/api/configuration")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sections.Items, nil
+}
+
+func (o *LiveDataSource) GetConfigurationValues(ctx context.Context, section *configuration.ConfigurationSection) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	values, err := newclient.Get[map[string]any](o.client.HttpSession(), linksTemplate.ReplaceAllString(section.Links["Values"], ""))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return *values, nil
+}
@@ -0,0 +1,53 @@
+package client_wrapper
+
+import (
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/credentials"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"net/url"
+	"testing"
+)
+
+func newVersionControlledProject() *projects.Project {
+	repoUrl, _ := url.Parse("https://github.com/example/repo.git")
+	project := projects.NewProject("My Project", "Lifecycles-1", "ProjectGroups-1")
+	project.ID = "Projects-1"
+	project.IsVersionControlled = true
+	project.PersistenceSettings = projects.NewGitPersistenceSettings(
+		".octopus",
+		credentials.NewAnonymous(),
+		"main",
+		[]string{},
+		repoUrl)
+	return project
+}
+
+func TestProjectGitRefDefaultsToDefaultBranch(t *testing.T) {
+	settings, gitRef := (&LiveDataSource{}).projectGitRef(newVersionControlledProject())
+
+	if settings == nil || gitRef != "main" {
+		t.Fatalf("Should have read the default branch, got %s", gitRef)
+	}
+}
+
+func TestProjectGitRefOverride(t *testing.T) {
+	settings, gitRef := (&LiveDataSource{gitRef: "refs/heads/feature"}).projectGitRef(newVersionControlledProject())
+
+	if settings == nil || gitRef != "refs/heads/feature" {
+		t.Fatalf("Should have used the Git reference passed to the data source, got %s", gitRef)
+	}
+}
+
+func TestProjectGitRefDatabaseProject(t *testing.T) {
+	project := projects.NewProject("My Project", "Lifecycles-1", "ProjectGroups-1")
+	project.PersistenceSettings = projects.NewDatabasePersistenceSettings()
+
+	settings, gitRef := (&LiveDataSource{gitRef: "main"}).projectGitRef(project)
+
+	if settings != nil || gitRef != "" {
+		t.Fatal("Projects stored in the database should not have a Git reference")
+	}
+
+	if settings, _ := (&LiveDataSource{}).projectGitRef(projects.NewProject("My Project", "Lifecycles-1", "ProjectGroups-1")); settings != nil {
+		t.Fatal("Projects without persistence settings should not have a Git reference")
+	}
+}
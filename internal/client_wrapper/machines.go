@@ -1,11 +1,16 @@
 package client_wrapper
 
 import (
+	"context"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 )
 
-func GetMachines(limit int, client newclient.Client, spaceID string) ([]*machines.DeploymentTarget, error) {
+func GetMachines(ctx context.Context, limit int, client newclient.Client, spaceID string) ([]*machines.DeploymentTarget, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if limit == 0 {
 		return machines.GetAll(client, spaceID)
 	}
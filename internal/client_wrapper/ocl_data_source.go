@@ -0,0 +1,233 @@
+package client_wrapper
+
+import (
+	"context"
+	"errors"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/ocl"
+	"net/http"
+	"time"
+)
+
+// oclProjectId is the ID given to the project read from the OCL files
+const oclProjectId = "Projects-OCL"
+
+// errNotInOcl is returned for the resources that are not stored in the OCL files of a project, like targets and
+// accounts. The checks that read them are not run against OCL files.
+var errNotInOcl = errors.New("the resource is not stored in the OCL files of a project")
+
+// OclDataSource reads the deployment process, runbooks, and variables of a version controlled project from the OCL
+// files in a local Git checkout, so they can be checked without an Octopus server. The project is the only project
+// in the data source.
+type OclDataSource struct {
+	project *projects.Project
+	ocl     *ocl.Project
+	now     time.Time
+}
+
+func NewOclDataSource(project *ocl.Project) *OclDataSource {
+	octopusProject := projects.NewProject(project.Name, "", "")
+	octopusProject.ID = oclProjectId
+	octopusProject.IsVersionControlled = true
+	octopusProject.DeploymentProcessID = project.DeploymentProcess.ID
+	octopusProject.VariableSetID = project.Variables.ID
+	return &OclDataSource{project: octopusProject, ocl: project, now: time.Now()}
+}
+
+// GetSpaceID returns an empty string, as the OCL files do not belong to a space.
+func (o *OclDataSource) GetSpaceID() string {
+	return ""
+}
+
+func (o *OclDataSource) Now() time.Time {
+	return o.now
+}
+
+func (o *OclDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return []*projects.Project{o.project}, nil
+}
+
+func (o *OclDataSource) GetProjectByName(ctx context.Context, name string) ([]*projects.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if name != o.project.Name {
+		return []*projects.Project{}, nil
+	}
+
+	return []*projects.Project{o.project}, nil
+}
+
+func (o *OclDataSource) GetVariables(ctx context.Context, project *projects.Project) (variables.VariableSet, error) {
+	if err := ctx.Err(); err != nil {
+		return variables.VariableSet{}, err
+	}
+
+	return o.ocl.Variables, nil
+}
+
+func (o *OclDataSource) GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.ocl.DeploymentProcess, nil
+}
+
+func (o *OclDataSource) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return o.ocl.Runbooks, nil
+}
+
+func (o *OclDataSource) GetRunbookProcess(ctx context.Context, project *projects.Project, runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	process, ok := o.ocl.RunbookProcesses[runbook.ID]
+
+	if !ok {
+		return nil, &core.APIError{StatusCode: http.StatusNotFound, ErrorMessage: "The runbook " + runbook.ID + " was not found"}
+	}
+
+	return process, nil
+}
+
+func (o *OclDataSource) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetProjectGroupProjects(ctx context.Context, projectGroup *projectgroups.ProjectGroup) ([]*projects.Project, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetFeeds(ctx context.Context) ([]*feeds.FeedResource, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetDeployment(ctx context.Context, deploymentId string) (*deployments.Deployment, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetEvents(ctx context.Context, query events.EventsQuery) (*resources.Resources[*events.Event], error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetTasks(ctx context.Context, query tasks.TasksQuery) (*resources.Resources[*tasks.Task], error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetUsers(ctx context.Context) ([]*users.User, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetUserApiKeys(ctx context.Context, user *users.User) ([]*APIKey, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetTeamScopedUserRoles(ctx context.Context, team *teams.Team) ([]*userroles.ScopedUserRole, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetUserRoles(ctx context.Context) ([]*userroles.UserRole, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetAuthentication(ctx context.Context) (*authentication.Authentication, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetLicenseStatus(ctx context.Context) (*LicenseStatus, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetServerNodes(ctx context.Context) ([]*octopusservernodes.OctopusServerNodeResource, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetConfigurationSections(ctx context.Context) ([]*configuration.ConfigurationSection, error) {
+	return nil, errNotInOcl
+}
+
+func (o *OclDataSource) GetConfigurationValues(ctx context.Context, section *configuration.ConfigurationSection) (map[string]any, error) {
+	return nil, errNotInOcl
+}
@@ -1,6 +1,7 @@
 package client_wrapper
 
 import (
+	"context"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
@@ -8,7 +9,11 @@ import (
 	"github.com/samber/lo"
 )
 
-func GetProjects(limit int, client newclient.Client, spaceID string) ([]*projects.Project, error) {
+func GetProjects(ctx context.Context, limit int, client newclient.Client, spaceID string) ([]*projects.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if limit == 0 {
 		return projects.GetAll(client, spaceID)
 	}
@@ -24,11 +29,15 @@ func GetProjects(limit int, client newclient.Client, spaceID string) ([]*project
 	return result.Items, nil
 }
 
-func GetProjectByName(name string, client newclient.Client, spaceID string) ([]*projects.Project, error) {
+func GetProjectByName(ctx context.Context, name string, client newclient.Client, spaceID string) ([]*projects.Project, error) {
 	if name == "" {
 		return []*projects.Project{}, nil
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	result, err := projects.Get(client, spaceID, projects.ProjectsQuery{
 		PartialName: name,
 	})
@@ -46,12 +55,12 @@ func GetProjectByName(name string, client newclient.Client, spaceID string) ([]*
 	return []*projects.Project{}, nil
 }
 
-func GetProjectsWithFilter(client newclient.Client, spaceID string, excludeProjectsExcept config.StringSliceArgs, excludeProjects config.StringSliceArgs, maxItems int) ([]*projects.Project, error) {
+func GetProjectsWithFilter(ctx context.Context, dataSource OctopusDataSource, excludeProjectsExcept config.StringSliceArgs, excludeProjects config.StringSliceArgs, maxItems int) ([]*projects.Project, error) {
 	if len(excludeProjectsExcept) != 0 {
-		return GetNamedProjects(client, spaceID, excludeProjectsExcept)
+		return GetNamedProjects(ctx, dataSource, excludeProjectsExcept)
 	}
 
-	if allProjects, err := GetProjects(maxItems, client, spaceID); err != nil {
+	if allProjects, err := dataSource.GetProjects(ctx, maxItems); err != nil {
 		return nil, err
 	} else {
 		defaultExcluder := excluder.DefaultExcluder{}
@@ -61,11 +70,11 @@ func GetProjectsWithFilter(client newclient.Client, spaceID string, excludeProje
 	}
 }
 
-func GetNamedProjects(client newclient.Client, spaceID string, excludeProjectsExcept config.StringSliceArgs) ([]*projects.Project, error) {
+func GetNamedProjects(ctx context.Context, dataSource OctopusDataSource, excludeProjectsExcept config.StringSliceArgs) ([]*projects.Project, error) {
 	projects := []*projects.Project{}
 
 	for _, projectName := range excludeProjectsExcept {
-		if project, err := GetProjectByName(projectName, client, spaceID); err != nil {
+		if project, err := dataSource.GetProjectByName(ctx, projectName); err != nil {
 			return nil, err
 		} else {
 			projects = append(projects, project...)
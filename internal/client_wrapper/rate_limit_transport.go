@@ -0,0 +1,96 @@
+package client_wrapper
+
+import (
+	"errors"
+	"golang.org/x/time/rate"
+	"math"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrRequestBudgetExhausted is returned for requests made after the request budget was used up.
+var ErrRequestBudgetExhausted = errors.New("the API request budget was exhausted")
+
+// RequestLimits defines how many requests are sent to the Octopus API. A value of 0 means there is no limit.
+type RequestLimits struct {
+	// MaxRequestsPerSecond is the sustained rate of requests
+	MaxRequestsPerSecond float64
+	// MaxConcurrentRequests is the number of requests that can be in flight at any time
+	MaxConcurrentRequests int
+	// RequestBudget is the total number of requests that can be made during a run
+	RequestBudget int
+}
+
+// RateLimitTransport is a http.RoundTripper that limits the requests sent by all the checks to the Octopus API. The
+// rate is limited by a token bucket, which allows a burst of up to one second's worth of requests. Once the request
+// budget is used up, requests fail immediately with ErrRequestBudgetExhausted.
+type RateLimitTransport struct {
+	transport  http.RoundTripper
+	limiter    *rate.Limiter
+	concurrent chan struct{}
+	budget     int64
+	requests   atomic.Int64
+}
+
+func NewRateLimitTransport(transport http.RoundTripper, limits RequestLimits) *RateLimitTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	rateLimitTransport := RateLimitTransport{transport: transport, budget: int64(limits.RequestBudget)}
+
+	if limits.MaxRequestsPerSecond > 0 {
+		burst := int(math.Max(1, math.Ceil(limits.MaxRequestsPerSecond)))
+		rateLimitTransport.limiter = rate.NewLimiter(rate.Limit(limits.MaxRequestsPerSecond), burst)
+	}
+
+	if limits.MaxConcurrentRequests > 0 {
+		rateLimitTransport.concurrent = make(chan struct{}, limits.MaxConcurrentRequests)
+	}
+
+	return &rateLimitTransport
+}
+
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if requests := t.requests.Add(1); t.budget > 0 && requests > t.budget {
+		return nil, ErrRequestBudgetExhausted
+	}
+
+	if t.concurrent != nil {
+		select {
+		case t.concurrent <- struct{}{}:
+			defer func() { <-t.concurrent }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// Requests returns the number of requests that were attempted, including those rejected by the request budget.
+func (t *RateLimitTransport) Requests() int64 {
+	return t.requests.Load()
+}
+
+// Exhausted returns true once a request has been rejected because the request budget was used up.
+func (t *RateLimitTransport) Exhausted() bool {
+	return t.budget > 0 && t.requests.Load() > t.budget
+}
+
+// IsRequestBudgetError returns true if the request failed because the request budget was used up. The message is
+// also checked, as not every client method wraps the underlying error.
+func IsRequestBudgetError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.Is(err, ErrRequestBudgetExhausted) || strings.Contains(err.Error(), ErrRequestBudgetExhausted.Error())
+}
@@ -0,0 +1,108 @@
+package client_wrapper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewRateLimitTransport(nil, RequestLimits{RequestBudget: 2})
+	httpClient := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if transport.Exhausted() {
+		t.Fatal("the budget should not be exhausted until a request is rejected")
+	}
+
+	_, err := httpClient.Get(server.URL)
+
+	if !errors.Is(err, ErrRequestBudgetExhausted) || !IsRequestBudgetError(err) {
+		t.Fatal("the request should have been rejected")
+	}
+
+	if !transport.Exhausted() || transport.Requests() != 3 {
+		t.Fatal("the budget should have been exhausted")
+	}
+
+	if !IsRequestBudgetError(fmt.Errorf("%s", err.Error())) {
+		t.Fatal("the error message should identify the budget error")
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	inFlight := atomic.Int32{}
+	maxInFlight := atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		for {
+			max := maxInFlight.Load()
+			if current <= max || maxInFlight.CompareAndSwap(max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: NewRateLimitTransport(nil, RequestLimits{MaxConcurrentRequests: 2})}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp, err := httpClient.Get(server.URL); err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight.Load() > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+	}
+}
+
+func TestMaxRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: NewRateLimitTransport(nil, RequestLimits{MaxRequestsPerSecond: 20})}
+
+	// The first 20 requests use the burst, and the next 5 are spread over 250ms
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("the requests should have been rate limited, but took %s", elapsed)
+	}
+}
@@ -0,0 +1,217 @@
+package client_wrapper
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"time"
+)
+
+// RecordingDataSource saves the resources read from another data source in a snapshot. It is created by
+// OctopusSnapshot.Record.
+type RecordingDataSource struct {
+	dataSource OctopusDataSource
+	records    *snapshotRecords
+}
+
+func (o *RecordingDataSource) GetSpaceID() string {
+	return o.dataSource.GetSpaceID()
+}
+
+func (o *RecordingDataSource) Now() time.Time {
+	return o.dataSource.Now()
+}
+
+func (o *RecordingDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	// Every resource is saved, so the snapshot can be replayed with any limit or filter
+	result, err := o.dataSource.GetProjects(ctx, 0)
+	result, err = record(o.records, snapshotKey("Projects"), result, err)
+	return limitResources(result, limit), err
+}
+
+func (o *RecordingDataSource) GetProjectByName(ctx context.Context, name string) ([]*projects.Project, error) {
+	result, err := o.dataSource.GetProjectByName(ctx, name)
+	return record(o.records, snapshotKey("ProjectByName", name), result, err)
+}
+
+func (o *RecordingDataSource) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	result, err := o.dataSource.GetProjectGroups(ctx)
+	return record(o.records, snapshotKey("ProjectGroups"), result, err)
+}
+
+func (o *RecordingDataSource) GetProjectGroupProjects(ctx context.Context, projectGroup *projectgroups.ProjectGroup) ([]*projects.Project, error) {
+	result, err := o.dataSource.GetProjectGroupProjects(ctx, projectGroup)
+	return record(o.records, snapshotKey("ProjectGroupProjects", projectGroup.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	// Every resource is saved, so the snapshot can be replayed with any limit or filter
+	result, err := o.dataSource.GetEnvironments(ctx, 0)
+	result, err = record(o.records, snapshotKey("Environments"), result, err)
+	return limitResources(result, limit), err
+}
+
+func (o *RecordingDataSource) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	// Every resource is saved, so the snapshot can be replayed with any limit or filter
+	result, err := o.dataSource.GetMachines(ctx, 0)
+	result, err = record(o.records, snapshotKey("Machines"), result, err)
+	return limitResources(result, limit), err
+}
+
+func (o *RecordingDataSource) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	result, err := o.dataSource.GetMachineDeploymentTasks(ctx, machine)
+	return record(o.records, snapshotKey("MachineDeploymentTasks", machine.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	// Every resource is saved, so the snapshot can be replayed with any limit or filter
+	result, err := o.dataSource.GetTenants(ctx, 0)
+	result, err = record(o.records, snapshotKey("Tenants"), result, err)
+	return limitResources(result, limit), err
+}
+
+func (o *RecordingDataSource) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	result, err := o.dataSource.GetLifecycles(ctx)
+	return record(o.records, snapshotKey("Lifecycles"), result, err)
+}
+
+func (o *RecordingDataSource) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	result, err := o.dataSource.GetChannels(ctx)
+	return record(o.records, snapshotKey("Channels"), result, err)
+}
+
+func (o *RecordingDataSource) GetFeeds(ctx context.Context) ([]*feeds.FeedResource, error) {
+	result, err := o.dataSource.GetFeeds(ctx)
+	return record(o.records, snapshotKey("Feeds"), result, err)
+}
+
+func (o *RecordingDataSource) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	result, err := o.dataSource.GetAccounts(ctx)
+	return record(o.records, snapshotKey("Accounts"), result, err)
+}
+
+func (o *RecordingDataSource) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	result, err := o.dataSource.GetCertificates(ctx)
+	return record(o.records, snapshotKey("Certificates"), result, err)
+}
+
+func (o *RecordingDataSource) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	result, err := o.dataSource.GetWorkerPools(ctx)
+	return record(o.records, snapshotKey("WorkerPools"), result, err)
+}
+
+func (o *RecordingDataSource) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	result, err := o.dataSource.GetSubscriptions(ctx)
+	return record(o.records, snapshotKey("Subscriptions"), result, err)
+}
+
+func (o *RecordingDataSource) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	result, err := o.dataSource.GetRunbooks(ctx)
+	return record(o.records, snapshotKey("Runbooks"), result, err)
+}
+
+func (o *RecordingDataSource) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	result, err := o.dataSource.GetProjectRunbooks(ctx, project)
+	return record(o.records, snapshotKey("ProjectRunbooks", project.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetVariables(ctx context.Context, project *projects.Project) (variables.VariableSet, error) {
+	result, err := o.dataSource.GetVariables(ctx, project)
+	return record(o.records, snapshotKey("Variables", project.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	result, err := o.dataSource.GetDeploymentProcess(ctx, project)
+	return record(o.records, snapshotKey("DeploymentProcess", project.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetRunbookProcess(ctx context.Context, project *projects.Project, runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error) {
+	result, err := o.dataSource.GetRunbookProcess(ctx, project, runbook)
+	return record(o.records, snapshotKey("RunbookProcess", project.ID, runbook.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetDeployment(ctx context.Context, deploymentId string) (*deployments.Deployment, error) {
+	result, err := o.dataSource.GetDeployment(ctx, deploymentId)
+	return record(o.records, snapshotKey("Deployment", deploymentId), result, err)
+}
+
+func (o *RecordingDataSource) GetEvents(ctx context.Context, query events.EventsQuery) (*resources.Resources[*events.Event], error) {
+	result, err := o.dataSource.GetEvents(ctx, query)
+	return record(o.records, snapshotKey("Events", query), result, err)
+}
+
+func (o *RecordingDataSource) GetTasks(ctx context.Context, query tasks.TasksQuery) (*resources.Resources[*tasks.Task], error) {
+	result, err := o.dataSource.GetTasks(ctx, query)
+	return record(o.records, snapshotKey("Tasks", query), result, err)
+}
+
+func (o *RecordingDataSource) GetUsers(ctx context.Context) ([]*users.User, error) {
+	result, err := o.dataSource.GetUsers(ctx)
+	return record(o.records, snapshotKey("Users"), result, err)
+}
+
+func (o *RecordingDataSource) GetUserApiKeys(ctx context.Context, user *users.User) ([]*APIKey, error) {
+	result, err := o.dataSource.GetUserApiKeys(ctx, user)
+	return record(o.records, snapshotKey("UserApiKeys", user.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	result, err := o.dataSource.GetTeams(ctx)
+	return record(o.records, snapshotKey("Teams"), result, err)
+}
+
+func (o *RecordingDataSource) GetTeamScopedUserRoles(ctx context.Context, team *teams.Team) ([]*userroles.ScopedUserRole, error) {
+	result, err := o.dataSource.GetTeamScopedUserRoles(ctx, team)
+	return record(o.records, snapshotKey("TeamScopedUserRoles", team.ID), result, err)
+}
+
+func (o *RecordingDataSource) GetUserRoles(ctx context.Context) ([]*userroles.UserRole, error) {
+	result, err := o.dataSource.GetUserRoles(ctx)
+	return record(o.records, snapshotKey("UserRoles"), result, err)
+}
+
+func (o *RecordingDataSource) GetAuthentication(ctx context.Context) (*authentication.Authentication, error) {
+	result, err := o.dataSource.GetAuthentication(ctx)
+	return record(o.records, snapshotKey("Authentication"), result, err)
+}
+
+func (o *RecordingDataSource) GetLicenseStatus(ctx context.Context) (*LicenseStatus, error) {
+	result, err := o.dataSource.GetLicenseStatus(ctx)
+	return record(o.records, snapshotKey("LicenseStatus"), result, err)
+}
+
+func (o *RecordingDataSource) GetServerNodes(ctx context.Context) ([]*octopusservernodes.OctopusServerNodeResource, error) {
+	result, err := o.dataSource.GetServerNodes(ctx)
+	return record(o.records, snapshotKey("ServerNodes"), result, err)
+}
+
+func (o *RecordingDataSource) GetConfigurationSections(ctx context.Context) ([]*configuration.ConfigurationSection, error) {
+	result, err := o.dataSource.GetConfigurationSections(ctx)
+	return record(o.records, snapshotKey("ConfigurationSections"), result, err)
+}
+
+func (o *RecordingDataSource) GetConfigurationValues(ctx context.Context, section *configuration.ConfigurationSection) (map[string]any, error) {
+	result, err := o.dataSource.GetConfigurationValues(ctx, section)
+	return record(o.records, snapshotKey("ConfigurationValues", section.ID), result, err)
+}
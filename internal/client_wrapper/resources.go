@@ -0,0 +1,40 @@
+package client_wrapper
+
+import "time"
+
+type APIKeyKey struct {
+	Hint *string
+}
+
+// APIKey is used because the go client_wrapper has an invalid APIKey value that prevents the usual functions for querying users keys
+type APIKey struct {
+	ID      string     `json:"Id,omitempty"`
+	APIKey  APIKeyKey  `json:"ApiKey,omitempty"`
+	Expires *time.Time `json:"Expires,omitempty"`
+}
+
+// OctopusSubscription is used because the go client does not expose the webhook URL of a subscription
+type OctopusSubscription struct {
+	Id                            string
+	Name                          string
+	EventNotificationSubscription *OctopusEventNotificationSubscription
+}
+
+type OctopusEventNotificationSubscription struct {
+	WebhookURI string
+}
+
+// LicenseStatus is used because the go client does not expose the license status
+type LicenseStatus struct {
+	IsCompliant       bool           `json:"IsCompliant"`
+	ComplianceSummary string         `json:"ComplianceSummary,omitempty"`
+	Limits            []LicenseLimit `json:"Limits,omitempty"`
+}
+
+// LicenseLimit is the usage of a single licensed resource, like projects or targets
+type LicenseLimit struct {
+	Name           string `json:"Name,omitempty"`
+	EffectiveValue int    `json:"EffectiveValue"`
+	CurrentUsage   int    `json:"CurrentUsage"`
+	IsUnlimited    bool   `json:"IsUnlimited"`
+}
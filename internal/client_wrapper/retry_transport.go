@@ -0,0 +1,165 @@
+package client_wrapper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"go.uber.org/zap"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxDrainBytes is the amount of a failed response body that is read before the connection is reused
+const maxDrainBytes = 64 * 1024
+
+// RetryPolicy defines how requests that failed with a transient error are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried after the first attempt
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. The delay doubles with each retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest delay between retries, unless the server requests a longer delay with Retry-After.
+	MaxBackoff time.Duration
+}
+
+// RetryTransport is a http.RoundTripper that retries requests that failed with a transient error, like a rate limit
+// or an unavailable server. Retries use exponential backoff with jitter, and honour the Retry-After header.
+//
+// Only GET and HEAD requests are retried, as they are safe to repeat.
+type RetryTransport struct {
+	transport http.RoundTripper
+	policy    RetryPolicy
+}
+
+func NewRetryTransport(transport http.RoundTripper, policy RetryPolicy) RetryTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return RetryTransport{transport: transport, policy: policy}
+}
+
+func (t RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.transport.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+
+		if attempt >= t.policy.MaxRetries || !t.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+
+		if err != nil {
+			zap.L().Debug("Retrying request to " + req.URL.Path + " in " + delay.String() + " after error: " + err.Error())
+		} else {
+			zap.L().Debug("Retrying request to " + req.URL.Path + " in " + delay.String() + " after status code " + fmt.Sprint(resp.StatusCode))
+			// Read the body so the connection can be reused
+			_, _ = io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t RetryTransport) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return IsTransientError(err)
+	}
+
+	return IsTransientStatusCode(resp.StatusCode)
+}
+
+// backoff returns the delay before the next attempt. The Retry-After header takes precedence, otherwise the delay
+// grows exponentially, and is randomised between half and all of the calculated value so concurrent checks do not
+// retry in lockstep.
+func (t RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return retryAfter
+		}
+	}
+
+	delay := t.policy.InitialBackoff
+	for i := 0; i < attempt && delay < t.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if t.policy.MaxBackoff > 0 && delay > t.policy.MaxBackoff {
+		delay = t.policy.MaxBackoff
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of seconds or a HTTP date.
+func parseRetryAfter(retryAfter string, now time.Time) (time.Duration, bool) {
+	retryAfter = strings.TrimSpace(retryAfter)
+
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		if date.Before(now) {
+			return 0, true
+		}
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
+
+// IsTransientStatusCode returns true if the HTTP status code indicates a failure that may succeed if retried.
+func IsTransientStatusCode(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusBadGateway ||
+		statusCode == http.StatusServiceUnavailable ||
+		statusCode == http.StatusGatewayTimeout
+}
+
+// IsTransientError returns true if the error indicates a failure that may succeed if retried, like a rate limit,
+// an unavailable server, or a connection that was reset.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiError *core.APIError
+	if errors.As(err, &apiError) && IsTransientStatusCode(apiError.StatusCode) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	// Some errors only capture the message of the underlying network error
+	return strings.Contains(strings.ToLower(err.Error()), "connection reset by peer")
+}
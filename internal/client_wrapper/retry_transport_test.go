@@ -0,0 +1,153 @@
+package client_wrapper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// newFlakyServer returns a server that responds with the status code for the first failures requests, and then
+// responds with 200.
+func newFlakyServer(failures int32, statusCode int, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(statusCode)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	for _, statusCode := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		requests := atomic.Int32{}
+		server := newFlakyServer(2, statusCode, &requests)
+
+		resp, err := NewHttpClient(nil, testRetryPolicy).Get(server.URL)
+		server.Close()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.StatusCode != http.StatusOK || requests.Load() != 3 {
+			t.Fatalf("status code %d should have been retried, got %d after %d requests", statusCode, resp.StatusCode, requests.Load())
+		}
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	requests := atomic.Int32{}
+	server := newFlakyServer(10, http.StatusServiceUnavailable, &requests)
+	defer server.Close()
+
+	resp, err := NewHttpClient(nil, testRetryPolicy).Get(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusServiceUnavailable || requests.Load() != 4 {
+		t.Fatalf("the last response should have been returned after 4 requests, got %d after %d requests", resp.StatusCode, requests.Load())
+	}
+}
+
+func TestNoRetryForPermanentErrors(t *testing.T) {
+	requests := atomic.Int32{}
+	server := newFlakyServer(10, http.StatusForbidden, &requests)
+	defer server.Close()
+
+	resp, err := NewHttpClient(nil, testRetryPolicy).Get(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusForbidden || requests.Load() != 1 {
+		t.Fatal("permanent errors must not be retried")
+	}
+}
+
+func TestNoRetryForPost(t *testing.T) {
+	requests := atomic.Int32{}
+	server := newFlakyServer(10, http.StatusServiceUnavailable, &requests)
+	defer server.Close()
+
+	_, err := NewHttpClient(nil, testRetryPolicy).Post(server.URL, "application/json", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests.Load() != 1 {
+		t.Fatal("POST requests must not be retried")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	transport := NewRetryTransport(nil, RetryPolicy{MaxRetries: 10, InitialBackoff: time.Second, MaxBackoff: 8 * time.Second})
+
+	for attempt, maxDelay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		delay := transport.backoff(attempt, nil)
+
+		if delay < maxDelay/2 || delay > maxDelay {
+			t.Fatalf("attempt %d should have a delay between %s and %s, got %s", attempt, maxDelay/2, maxDelay, delay)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"20"}}}
+	if transport.backoff(0, resp) != 20*time.Second {
+		t.Fatal("the Retry-After header should take precedence")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if delay, ok := parseRetryAfter("120", now); !ok || delay != 2*time.Minute {
+		t.Fatal("should have parsed the number of seconds")
+	}
+
+	if delay, ok := parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now); !ok || delay != 30*time.Second {
+		t.Fatal("should have parsed the date")
+	}
+
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Fatal("should have ignored the invalid value")
+	}
+
+	if _, ok := parseRetryAfter("", now); ok {
+		t.Fatal("should have ignored the empty value")
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	if !IsTransientError(&core.APIError{StatusCode: http.StatusTooManyRequests}) {
+		t.Fatal("429 should be transient")
+	}
+
+	if !IsTransientError(fmt.Errorf("wrapped: %w", &core.APIError{StatusCode: http.StatusGatewayTimeout})) {
+		t.Fatal("wrapped 504 should be transient")
+	}
+
+	if !IsTransientError(fmt.Errorf("read: %w", syscall.ECONNRESET)) {
+		t.Fatal("connection resets should be transient")
+	}
+
+	if IsTransientError(&core.APIError{StatusCode: http.StatusNotFound}) {
+		t.Fatal("404 should not be transient")
+	}
+
+	if IsTransientError(errors.New("something went wrong")) || IsTransientError(nil) {
+		t.Fatal("other errors should not be transient")
+	}
+}
@@ -0,0 +1,425 @@
+package client_wrapper
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const snapshotVersion = 1
+
+const snapshotManifestFile = "manifest.json"
+const snapshotInstanceFile = "instance.json"
+const snapshotSpacesDir = "spaces"
+
+// ErrNotInSnapshot is returned when a check reads a resource that was not saved in the snapshot. This happens when
+// the snapshot is replayed with different options to the run that saved it.
+var ErrNotInSnapshot = errors.New("the resource was not saved in the snapshot")
+
+// IsNotInSnapshotError returns true if the error was caused by reading a resource that is not in the snapshot.
+func IsNotInSnapshotError(err error) bool {
+	return errors.Is(err, ErrNotInSnapshot)
+}
+
+// OctopusSnapshot holds every resource read by the checks during a run. A snapshot is saved by wrapping the live data
+// sources with RecordingDataSource, and is replayed with SnapshotDataSource to run the checks without a server.
+//
+// A snapshot is saved to a directory, or to a zip file if the path ends in ".zip". The manifest.json file lists the
+// spaces, instance.json holds the server wide resources, and spaces/<space id>.json holds the resources of each space.
+type OctopusSnapshot struct {
+	Version int       `json:"version"`
+	Url     string    `json:"url"`
+	Created time.Time `json:"created"`
+	// GitRef is the Git reference version controlled projects were read from. It is empty if the default branch of
+	// each project was read.
+	GitRef string          `json:"gitRef,omitempty"`
+	Spaces []SnapshotSpace `json:"spaces"`
+
+	mu      sync.Mutex
+	records map[string]*snapshotRecords
+}
+
+// SnapshotSpace is a space saved in the snapshot.
+type SnapshotSpace struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// snapshotRecords are the resources read from one space, or from the server when the space ID is empty.
+type snapshotRecords struct {
+	Now     time.Time                `json:"now"`
+	Entries map[string]snapshotEntry `json:"entries"`
+
+	mu sync.Mutex
+}
+
+// snapshotEntry is the response to a single request. Failed requests are saved too, so the checks report the same
+// errors when the snapshot is replayed.
+type snapshotEntry struct {
+	Value json.RawMessage `json:"value,omitempty"`
+	// Error is the error returned by the Octopus API
+	Error *core.APIError `json:"error,omitempty"`
+	// Message is the text of any other error
+	Message string `json:"message,omitempty"`
+}
+
+// NewOctopusSnapshot creates an empty snapshot of the server at the URL. The gitRef is the Git reference version
+// controlled projects are read from.
+func NewOctopusSnapshot(url string, gitRef string) *OctopusSnapshot {
+	return &OctopusSnapshot{
+		Version: snapshotVersion,
+		Url:     url,
+		Created: time.Now().UTC(),
+		GitRef:  gitRef,
+		Spaces:  []SnapshotSpace{},
+		records: map[string]*snapshotRecords{},
+	}
+}
+
+// Record returns a data source that saves the resources read from the supplied data source in the snapshot. The
+// space name is saved in the manifest, and is ignored for the server wide data source.
+func (o *OctopusSnapshot) Record(dataSource OctopusDataSource, spaceName string) *RecordingDataSource {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	records, ok := o.records[dataSource.GetSpaceID()]
+	if !ok {
+		records = &snapshotRecords{Now: dataSource.Now(), Entries: map[string]snapshotEntry{}}
+		o.records[dataSource.GetSpaceID()] = records
+
+		if dataSource.GetSpaceID() != "" {
+			o.Spaces = append(o.Spaces, SnapshotSpace{Id: dataSource.GetSpaceID(), Name: spaceName})
+		}
+	}
+
+	return &RecordingDataSource{dataSource: dataSource, records: records}
+}
+
+// Replay returns a data source that reads the resources saved for the space. Use an empty space ID to read the
+// server wide resources.
+func (o *OctopusSnapshot) Replay(spaceId string) (*SnapshotDataSource, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	records, ok := o.records[spaceId]
+	if !ok {
+		if spaceId == "" {
+			return nil, errors.New("the snapshot does not include the server wide resources")
+		}
+		return nil, errors.New("the snapshot does not include the space " + spaceId)
+	}
+
+	return &SnapshotDataSource{spaceId: spaceId, records: records}, nil
+}
+
+// ValidateGitRef returns an error if the snapshot was saved from a different Git reference. The snapshot only holds
+// the deployment processes, runbooks, and variables of version controlled projects from the reference it was saved
+// with, so replaying it with another reference would report the wrong resources.
+func (o *OctopusSnapshot) ValidateGitRef(gitRef string) error {
+	if o.GitRef == gitRef {
+		return nil
+	}
+
+	return fmt.Errorf("the snapshot read version controlled projects from %s, but the -gitRef argument requests %s", describeGitRef(o.GitRef), describeGitRef(gitRef))
+}
+
+// describeGitRef describes the Git reference in an error message.
+func describeGitRef(gitRef string) string {
+	if gitRef == "" {
+		return "the default branch"
+	}
+
+	return "\"" + gitRef + "\""
+}
+
+// ReadOctopusSnapshot loads a snapshot from a directory or zip file.
+func ReadOctopusSnapshot(path string) (*OctopusSnapshot, error) {
+	readFile, closer, err := openSnapshot(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closer()
+
+	snapshot := &OctopusSnapshot{records: map[string]*snapshotRecords{}}
+	if err := readSnapshotFile(readFile, snapshotManifestFile, snapshot); err != nil {
+		return nil, fmt.Errorf("the snapshot %s is not valid: %w", path, err)
+	}
+
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("the snapshot %s has version %d, but only version %d is supported", path, snapshot.Version, snapshotVersion)
+	}
+
+	if snapshot.Spaces == nil {
+		snapshot.Spaces = []SnapshotSpace{}
+	}
+
+	files := map[string]string{"": snapshotInstanceFile}
+	for _, space := range snapshot.Spaces {
+		files[space.Id] = snapshotSpacesDir + "/" + space.Id + ".json"
+	}
+
+	for spaceId, file := range files {
+		records := &snapshotRecords{}
+		if err := readSnapshotFile(readFile, file, records); err != nil {
+			return nil, fmt.Errorf("the snapshot %s is not valid: %w", path, err)
+		}
+
+		if records.Entries == nil {
+			records.Entries = map[string]snapshotEntry{}
+		}
+
+		snapshot.records[spaceId] = records
+	}
+
+	return snapshot, nil
+}
+
+// Write saves the snapshot to a directory, or to a zip file if the path ends in ".zip".
+func (o *OctopusSnapshot) Write(path string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// Spaces are recorded concurrently, so sort them to make the manifest consistent between runs
+	sort.Slice(o.Spaces, func(i, j int) bool {
+		return o.Spaces[i].Id < o.Spaces[j].Id
+	})
+
+	files := map[string]any{snapshotManifestFile: o}
+	for spaceId, records := range o.records {
+		records.mu.Lock()
+		defer records.mu.Unlock()
+
+		if spaceId == "" {
+			files[snapshotInstanceFile] = records
+		} else {
+			files[snapshotSpacesDir+"/"+spaceId+".json"] = records
+		}
+	}
+
+	if isZipPath(path) {
+		return writeSnapshotZip(path, files)
+	}
+
+	return writeSnapshotDirectory(path, files)
+}
+
+func writeSnapshotDirectory(path string, files map[string]any) error {
+	if err := os.MkdirAll(filepath.Join(path, snapshotSpacesDir), 0755); err != nil {
+		return err
+	}
+
+	for name, value := range files {
+		content, err := json.MarshalIndent(value, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filepath.Join(path, filepath.FromSlash(name)), content, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeSnapshotZip(path string, files map[string]any) error {
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	for name, value := range files {
+		content, err := json.Marshal(value)
+
+		if err != nil {
+			return err
+		}
+
+		writer, err := archive.Create(name)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := writer.Write(content); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// openSnapshot returns a function that reads the files in the snapshot directory or zip file, and a function that
+// closes the snapshot.
+func openSnapshot(path string) (func(name string) ([]byte, error), func(), error) {
+	if isZipPath(path) {
+		archive, err := zip.OpenReader(path)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		readFile := func(name string) ([]byte, error) {
+			file, err := archive.Open(name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			defer file.Close()
+
+			return io.ReadAll(file)
+		}
+
+		return readFile, func() { archive.Close() }, nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil, err
+	}
+
+	readFile := func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(path, filepath.FromSlash(name)))
+	}
+
+	return readFile, func() {}, nil
+}
+
+func readSnapshotFile(readFile func(name string) ([]byte, error), name string, value any) error {
+	content, err := readFile(name)
+
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(content, value); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+func isZipPath(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".zip")
+}
+
+// limitResources returns up to limit resources, or all the resources if limit is 0. Lists of resources are saved in
+// full, and the limit is applied when they are read.
+func limitResources[T any](items []T, limit int) []T {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+
+	return items
+}
+
+// snapshotKey identifies a request in the snapshot by the name of the data source method and its arguments.
+func snapshotKey(name string, args ...any) string {
+	key := name
+	for _, arg := range args {
+		switch value := arg.(type) {
+		case string:
+			key += ":" + value
+		case int:
+			key += ":" + fmt.Sprint(value)
+		default:
+			content, _ := json.Marshal(value)
+			key += ":" + string(content)
+		}
+	}
+	return key
+}
+
+func (o *snapshotRecords) set(key string, entry snapshotEntry) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.Entries[key] = entry
+}
+
+func (o *snapshotRecords) get(key string) (snapshotEntry, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entry, ok := o.Entries[key]
+	return entry, ok
+}
+
+// record saves the response to a request. Errors caused by the run, like a check timing out, the request budget
+// running out, or a transient network error, are not saved, as they say nothing about the server.
+func record[T any](records *snapshotRecords, key string, value T, err error) (T, error) {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+			IsRequestBudgetError(err) || IsTransientError(err) {
+			return value, err
+		}
+
+		if apiError, ok := err.(*core.APIError); ok {
+			records.set(key, snapshotEntry{Error: apiError})
+		} else {
+			records.set(key, snapshotEntry{Message: err.Error()})
+		}
+
+		return value, err
+	}
+
+	content, marshalErr := json.Marshal(value)
+
+	if marshalErr != nil {
+		// The request succeeded, so the check can continue. The snapshot just won't include the response.
+		return value, err
+	}
+
+	records.set(key, snapshotEntry{Value: content})
+
+	return value, err
+}
+
+// replay returns the response saved for a request.
+func replay[T any](ctx context.Context, records *snapshotRecords, key string) (T, error) {
+	var value T
+
+	if err := ctx.Err(); err != nil {
+		return value, err
+	}
+
+	entry, ok := records.get(key)
+
+	if !ok {
+		return value, fmt.Errorf("%w: %s", ErrNotInSnapshot, key)
+	}
+
+	if entry.Error != nil {
+		return value, entry.Error
+	}
+
+	if entry.Message != "" {
+		return value, errors.New(entry.Message)
+	}
+
+	if err := json.Unmarshal(entry.Value, &value); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
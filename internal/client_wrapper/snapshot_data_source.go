@@ -0,0 +1,182 @@
+package client_wrapper
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/accounts"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/authentication"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/certificates"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/channels"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/configuration"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/events"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/feeds"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/lifecycles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/machines"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/octopusservernodes"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projectgroups"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tasks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/teams"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/userroles"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/users"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/workerpools"
+	"time"
+)
+
+// SnapshotDataSource reads the resources saved in a snapshot, so the checks can be run without an Octopus server.
+// It is created by OctopusSnapshot.Replay.
+type SnapshotDataSource struct {
+	spaceId string
+	records *snapshotRecords
+}
+
+func (o *SnapshotDataSource) GetSpaceID() string {
+	return o.spaceId
+}
+
+// Now returns the time the snapshot was saved, so time based checks report the same results as the run that saved
+// the snapshot.
+func (o *SnapshotDataSource) Now() time.Time {
+	return o.records.Now
+}
+
+func (o *SnapshotDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	result, err := replay[[]*projects.Project](ctx, o.records, snapshotKey("Projects"))
+	return limitResources(result, limit), err
+}
+
+func (o *SnapshotDataSource) GetProjectByName(ctx context.Context, name string) ([]*projects.Project, error) {
+	return replay[[]*projects.Project](ctx, o.records, snapshotKey("ProjectByName", name))
+}
+
+func (o *SnapshotDataSource) GetProjectGroups(ctx context.Context) ([]*projectgroups.ProjectGroup, error) {
+	return replay[[]*projectgroups.ProjectGroup](ctx, o.records, snapshotKey("ProjectGroups"))
+}
+
+func (o *SnapshotDataSource) GetProjectGroupProjects(ctx context.Context, projectGroup *projectgroups.ProjectGroup) ([]*projects.Project, error) {
+	return replay[[]*projects.Project](ctx, o.records, snapshotKey("ProjectGroupProjects", projectGroup.ID))
+}
+
+func (o *SnapshotDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	result, err := replay[[]*environments.Environment](ctx, o.records, snapshotKey("Environments"))
+	return limitResources(result, limit), err
+}
+
+func (o *SnapshotDataSource) GetMachines(ctx context.Context, limit int) ([]*machines.DeploymentTarget, error) {
+	result, err := replay[[]*machines.DeploymentTarget](ctx, o.records, snapshotKey("Machines"))
+	return limitResources(result, limit), err
+}
+
+func (o *SnapshotDataSource) GetMachineDeploymentTasks(ctx context.Context, machine *machines.DeploymentTarget) ([]*tasks.Task, error) {
+	return replay[[]*tasks.Task](ctx, o.records, snapshotKey("MachineDeploymentTasks", machine.ID))
+}
+
+func (o *SnapshotDataSource) GetTenants(ctx context.Context, limit int) ([]*tenants.Tenant, error) {
+	result, err := replay[[]*tenants.Tenant](ctx, o.records, snapshotKey("Tenants"))
+	return limitResources(result, limit), err
+}
+
+func (o *SnapshotDataSource) GetLifecycles(ctx context.Context) ([]*lifecycles.Lifecycle, error) {
+	return replay[[]*lifecycles.Lifecycle](ctx, o.records, snapshotKey("Lifecycles"))
+}
+
+func (o *SnapshotDataSource) GetChannels(ctx context.Context) ([]*channels.Channel, error) {
+	return replay[[]*channels.Channel](ctx, o.records, snapshotKey("Channels"))
+}
+
+func (o *SnapshotDataSource) GetFeeds(ctx context.Context) ([]*feeds.FeedResource, error) {
+	return replay[[]*feeds.FeedResource](ctx, o.records, snapshotKey("Feeds"))
+}
+
+func (o *SnapshotDataSource) GetAccounts(ctx context.Context) ([]*accounts.AccountResource, error) {
+	return replay[[]*accounts.AccountResource](ctx, o.records, snapshotKey("Accounts"))
+}
+
+func (o *SnapshotDataSource) GetCertificates(ctx context.Context) ([]*certificates.CertificateResource, error) {
+	return replay[[]*certificates.CertificateResource](ctx, o.records, snapshotKey("Certificates"))
+}
+
+func (o *SnapshotDataSource) GetWorkerPools(ctx context.Context) ([]*workerpools.WorkerPoolListResult, error) {
+	return replay[[]*workerpools.WorkerPoolListResult](ctx, o.records, snapshotKey("WorkerPools"))
+}
+
+func (o *SnapshotDataSource) GetSubscriptions(ctx context.Context) ([]*OctopusSubscription, error) {
+	return replay[[]*OctopusSubscription](ctx, o.records, snapshotKey("Subscriptions"))
+}
+
+func (o *SnapshotDataSource) GetRunbooks(ctx context.Context) ([]*runbooks.Runbook, error) {
+	return replay[[]*runbooks.Runbook](ctx, o.records, snapshotKey("Runbooks"))
+}
+
+func (o *SnapshotDataSource) GetProjectRunbooks(ctx context.Context, project *projects.Project) ([]*runbooks.Runbook, error) {
+	return replay[[]*runbooks.Runbook](ctx, o.records, snapshotKey("ProjectRunbooks", project.ID))
+}
+
+func (o *SnapshotDataSource) GetVariables(ctx context.Context, project *projects.Project) (variables.VariableSet, error) {
+	return replay[variables.VariableSet](ctx, o.records, snapshotKey("Variables", project.ID))
+}
+
+func (o *SnapshotDataSource) GetDeploymentProcess(ctx context.Context, project *projects.Project) (*deployments.DeploymentProcess, error) {
+	return replay[*deployments.DeploymentProcess](ctx, o.records, snapshotKey("DeploymentProcess", project.ID))
+}
+
+func (o *SnapshotDataSource) GetRunbookProcess(ctx context.Context, project *projects.Project, runbook *runbooks.Runbook) (*runbooks.RunbookProcess, error) {
+	return replay[*runbooks.RunbookProcess](ctx, o.records, snapshotKey("RunbookProcess", project.ID, runbook.ID))
+}
+
+func (o *SnapshotDataSource) GetDeployment(ctx context.Context, deploymentId string) (*deployments.Deployment, error) {
+	return replay[*deployments.Deployment](ctx, o.records, snapshotKey("Deployment", deploymentId))
+}
+
+func (o *SnapshotDataSource) GetEvents(ctx context.Context, query events.EventsQuery) (*resources.Resources[*events.Event], error) {
+	return replay[*resources.Resources[*events.Event]](ctx, o.records, snapshotKey("Events", query))
+}
+
+func (o *SnapshotDataSource) GetTasks(ctx context.Context, query tasks.TasksQuery) (*resources.Resources[*tasks.Task], error) {
+	return replay[*resources.Resources[*tasks.Task]](ctx, o.records, snapshotKey("Tasks", query))
+}
+
+func (o *SnapshotDataSource) GetUsers(ctx context.Context) ([]*users.User, error) {
+	return replay[[]*users.User](ctx, o.records, snapshotKey("Users"))
+}
+
+func (o *SnapshotDataSource) GetUserApiKeys(ctx context.Context, user *users.User) ([]*APIKey, error) {
+	return replay[[]*APIKey](ctx, o.records, snapshotKey("UserApiKeys", user.ID))
+}
+
+func (o *SnapshotDataSource) GetTeams(ctx context.Context) ([]*teams.Team, error) {
+	return replay[[]*teams.Team](ctx, o.records, snapshotKey("Teams"))
+}
+
+func (o *SnapshotDataSource) GetTeamScopedUserRoles(ctx context.Context, team *teams.Team) ([]*userroles.ScopedUserRole, error) {
+	return replay[[]*userroles.ScopedUserRole](ctx, o.records, snapshotKey("TeamScopedUserRoles", team.ID))
+}
+
+func (o *SnapshotDataSource) GetUserRoles(ctx context.Context) ([]*userroles.UserRole, error) {
+	return replay[[]*userroles.UserRole](ctx, o.records, snapshotKey("UserRoles"))
+}
+
+func (o *SnapshotDataSource) GetAuthentication(ctx context.Context) (*authentication.Authentication, error) {
+	return replay[*authentication.Authentication](ctx, o.records, snapshotKey("Authentication"))
+}
+
+func (o *SnapshotDataSource) GetLicenseStatus(ctx context.Context) (*LicenseStatus, error) {
+	return replay[*LicenseStatus](ctx, o.records, snapshotKey("LicenseStatus"))
+}
+
+func (o *SnapshotDataSource) GetServerNodes(ctx context.Context) ([]*octopusservernodes.OctopusServerNodeResource, error) {
+	return replay[[]*octopusservernodes.OctopusServerNodeResource](ctx, o.records, snapshotKey("ServerNodes"))
+}
+
+func (o *SnapshotDataSource) GetConfigurationSections(ctx context.Context) ([]*configuration.ConfigurationSection, error) {
+	return replay[[]*configuration.ConfigurationSection](ctx, o.records, snapshotKey("ConfigurationSections"))
+}
+
+func (o *SnapshotDataSource) GetConfigurationValues(ctx context.Context, section *configuration.ConfigurationSection) (map[string]any, error) {
+	return replay[map[string]any](ctx, o.records, snapshotKey("ConfigurationValues", section.ID))
+}
@@ -0,0 +1,216 @@
+package client_wrapper
+
+import (
+	"context"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/environments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/projects"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeDataSource returns fixed resources. Methods that are not overridden panic if they are called.
+type fakeDataSource struct {
+	OctopusDataSource
+	spaceId string
+	now     time.Time
+}
+
+func (o *fakeDataSource) GetSpaceID() string {
+	return o.spaceId
+}
+
+func (o *fakeDataSource) Now() time.Time {
+	return o.now
+}
+
+func (o *fakeDataSource) GetProjects(ctx context.Context, limit int) ([]*projects.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	project := projects.NewProject("My Project", "Lifecycles-1", "ProjectGroups-1")
+	project.ID = "Projects-1"
+	otherProject := projects.NewProject("Other Project", "Lifecycles-1", "ProjectGroups-1")
+	otherProject.ID = "Projects-2"
+	return limitResources([]*projects.Project{project, otherProject}, limit), nil
+}
+
+func (o *fakeDataSource) GetEnvironments(ctx context.Context, limit int) ([]*environments.Environment, error) {
+	return nil, &core.APIError{StatusCode: http.StatusForbidden, ErrorMessage: "You do not have permission"}
+}
+
+func recordSnapshot(t *testing.T, path string) time.Time {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	snapshot := NewOctopusSnapshot("https://example.octopus.app", "")
+	dataSource := snapshot.Record(&fakeDataSource{spaceId: "Spaces-1", now: now}, "Default")
+
+	if recordedProjects, err := dataSource.GetProjects(context.Background(), 1); err != nil || len(recordedProjects) != 1 {
+		t.Fatalf("the recorded projects should be limited, got %v %v", recordedProjects, err)
+	}
+
+	if _, err := dataSource.GetEnvironments(context.Background(), 10); err == nil {
+		t.Fatal("the environments should have failed")
+	}
+
+	// Every run records the server wide resources
+	snapshot.Record(&fakeDataSource{now: now}, "")
+
+	if err := snapshot.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	return now
+}
+
+func verifySnapshot(t *testing.T, path string, now time.Time) {
+	snapshot, err := ReadOctopusSnapshot(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if snapshot.Url != "https://example.octopus.app" || len(snapshot.Spaces) != 1 || snapshot.Spaces[0].Name != "Default" {
+		t.Fatalf("the manifest was not read, got %+v", snapshot)
+	}
+
+	dataSource, err := snapshot.Replay("Spaces-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !dataSource.Now().Equal(now) || dataSource.GetSpaceID() != "Spaces-1" {
+		t.Fatal("the snapshot should replay the time and space of the recorded run")
+	}
+
+	replayedProjects, err := dataSource.GetProjects(context.Background(), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(replayedProjects) != 1 || replayedProjects[0].ID != "Projects-1" || replayedProjects[0].Name != "My Project" {
+		t.Fatalf("the projects were not replayed, got %+v", replayedProjects)
+	}
+
+	// Every project is saved, so the snapshot can be replayed with a different limit
+	if allProjects, err := dataSource.GetProjects(context.Background(), 0); err != nil || len(allProjects) != 2 {
+		t.Fatalf("all the projects should have been replayed, got %v %v", allProjects, err)
+	}
+
+	_, err = dataSource.GetEnvironments(context.Background(), 10)
+	apiError, ok := err.(*core.APIError)
+
+	if !ok || apiError.StatusCode != http.StatusForbidden {
+		t.Fatalf("the API error should have been replayed, got %v", err)
+	}
+
+	if _, err := dataSource.GetMachines(context.Background(), 10); !IsNotInSnapshotError(err) {
+		t.Fatalf("requests that were not recorded should return a not in snapshot error, got %v", err)
+	}
+
+	if _, err := snapshot.Replay("Spaces-2"); err == nil {
+		t.Fatal("spaces that were not recorded should not be replayed")
+	}
+
+	if _, err := snapshot.Replay(""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot")
+	now := recordSnapshot(t, path)
+	verifySnapshot(t, path, now)
+}
+
+func TestSnapshotZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.zip")
+	now := recordSnapshot(t, path)
+	verifySnapshot(t, path, now)
+}
+
+func TestSnapshotDoesNotRecordCancelledRequests(t *testing.T) {
+	snapshot := NewOctopusSnapshot("https://example.octopus.app", "")
+	dataSource := snapshot.Record(&fakeDataSource{spaceId: "Spaces-1"}, "Default")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := dataSource.GetProjects(ctx, 10); err == nil {
+		t.Fatal("the request should have been cancelled")
+	}
+
+	replay, err := snapshot.Replay("Spaces-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := replay.GetProjects(context.Background(), 10); !IsNotInSnapshotError(err) {
+		t.Fatalf("cancelled requests should not be saved, got %v", err)
+	}
+}
+
+func TestSnapshotRecordedWithFilters(t *testing.T) {
+	filters, err := NewResourceFilters(&config.OctolintConfig{Include: config.StringSliceArgs{"project=Other Project"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := NewOctopusSnapshot("https://example.octopus.app", "")
+	dataSource := NewFilteredDataSource(snapshot.Record(&fakeDataSource{spaceId: "Spaces-1"}, "Default"), filters)
+
+	if filteredProjects, err := dataSource.GetProjects(context.Background(), 100); err != nil || len(filteredProjects) != 1 {
+		t.Fatalf("the projects should have been filtered, got %v %v", filteredProjects, err)
+	}
+
+	replay, err := snapshot.Replay("Spaces-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayedProjects, err := replay.GetProjects(context.Background(), 100)
+
+	if err != nil {
+		t.Fatalf("a snapshot recorded with filters should be replayed without them, got %v", err)
+	}
+
+	if len(replayedProjects) != 2 {
+		t.Fatalf("the projects excluded by the filters should have been saved, got %+v", replayedProjects)
+	}
+}
+
+func TestSnapshotGitRef(t *testing.T) {
+	path := t.TempDir()
+	snapshot := NewOctopusSnapshot("https://example.octopus.app", "refs/heads/feature")
+	snapshot.Record(&fakeDataSource{}, "")
+
+	if err := snapshot.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := ReadOctopusSnapshot(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := replay.ValidateGitRef("refs/heads/feature"); err != nil {
+		t.Fatalf("the snapshot should be replayed with the Git reference it was saved with, got %v", err)
+	}
+
+	if err := replay.ValidateGitRef(""); err == nil {
+		t.Fatal("the snapshot should not be replayed with the default branch")
+	}
+
+	if err := replay.ValidateGitRef("refs/heads/main"); err == nil {
+		t.Fatal("the snapshot should not be replayed with another Git reference")
+	}
+}
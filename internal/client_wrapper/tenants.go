@@ -1,11 +1,16 @@
 package client_wrapper
 
 import (
+	"context"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/newclient"
 	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/tenants"
 )
 
-func GetTenants(limit int, client newclient.Client, spaceID string) ([]*tenants.Tenant, error) {
+func GetTenants(ctx context.Context, limit int, client newclient.Client, spaceID string) ([]*tenants.Tenant, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if limit == 0 {
 		return tenants.GetAll(client, spaceID)
 	}
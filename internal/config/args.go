@@ -2,26 +2,75 @@ package config
 
 import (
 	"strings"
+	"time"
 )
 
 type OctolintConfig struct {
-	Help          bool
-	Url           string
-	Space         string
-	ApiKey        string
-	SkipTests     string
-	OnlyTests     string
-	VerboseErrors bool
-	Version       bool
-	Spinner       bool
-	ConfigFile    string
-	ConfigPath    string
-	Verbose       bool
+	Help           bool
+	Url            string
+	Space          string
+	GitRef         string
+	ApiKey         string
+	SkipTests      string
+	OnlyTests      string
+	VerboseErrors  bool
+	Version        bool
+	Spinner        bool
+	ProgressJson   bool
+	ConfigFile     string
+	ConfigPath     string
+	Verbose        bool
+	Format         string
+	Baseline       string
+	WriteBaseline  string
+	Snapshot       string
+	WriteSnapshot  string
+	Ocl            string
+	MinSeverity    string
+	FailOnSeverity string
+
+	// SkipInstanceChecks disables the checks that inspect the server rather than a space
+	SkipInstanceChecks bool
+
+	// Timeouts
+	Timeout       time.Duration
+	CheckTimeout  time.Duration
+	CheckTimeouts StringSliceArgs
+
+	// Retries of transient API errors
+	MaxRetries      int
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+
+	// Limits on the requests sent to the Octopus API
+	MaxRequestsPerSecond  float64
+	MaxConcurrentRequests int
+	RequestBudget         int
+
+	// Spaces are run in parallel when checking multiple spaces
+	MaxParallelSpaces int
+
+	// Octopus integration
+	OctopusServiceMessages bool
+	ReportFile             string
 
 	// Global filters for resources
 	ExcludeProjects       StringSliceArgs
 	ExcludeProjectsExcept StringSliceArgs
 	ExcludeProjectsRegex  StringSliceArgs
+	// Include and Exclude are the filters of every resource type, in the format resource:kind=value
+	Include StringSliceArgs
+	Exclude StringSliceArgs
+
+	// Policies are the built-in names, paths, or URLs of the policy packs to apply
+	Policies StringSliceArgs
+
+	// Rules are the paths of the YAML files that define custom rules
+	Rules StringSliceArgs
+
+	// Checks holds the configuration of individual checks, keyed by the lower case check ID. The settings of the
+	// policy packs are merged with the checks section of the config file.
+	Checks map[string]CheckConfig
 
 	// These values are used to configure individual checks
 	MaxEnvironments                           int
@@ -48,6 +97,7 @@ type OctolintConfig struct {
 	ProjectNameRegex                          string
 	LifecycleNameRegex                        string
 	MaxDaysSinceLastTask                      int
+	MaxDaysSinceLastLogin                     int
 	MaxDuplicateVariables                     int
 	MaxDuplicateVariableProjects              int
 	MaxInvalidVariableProjects                int
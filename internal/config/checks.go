@@ -0,0 +1,70 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CheckConfig is the configuration of an individual check, read from the checks section of the config file:
+//
+//	checks:
+//	  OctoLintTooManySteps:
+//	    enabled: true
+//	    severity: error
+//	    maxSteps: 30
+//
+// Check IDs and parameter names are case-insensitive, as the config file keys are read by viper.
+type CheckConfig struct {
+	// Enabled is nil if the config file does not enable or disable the check
+	Enabled *bool
+	// Severity replaces the severity of the results of a failed check. It is empty if the severity is not overridden.
+	Severity string
+	// Parameters are the thresholds and resource limits of the check, keyed by the lower case parameter name
+	Parameters map[string]string
+}
+
+// CheckConfig returns the configuration of the check with the ID, or an empty configuration if there is none.
+func (c *OctolintConfig) CheckConfig(checkId string) CheckConfig {
+	if c == nil || c.Checks == nil {
+		return CheckConfig{}
+	}
+
+	return c.Checks[strings.ToLower(checkId)]
+}
+
+// CheckString returns a parameter of a check, or the fallback if the parameter is not set.
+func (c *OctolintConfig) CheckString(checkId string, parameter string, fallback string) string {
+	if value, ok := c.CheckConfig(checkId).Parameters[strings.ToLower(parameter)]; ok {
+		return value
+	}
+
+	return fallback
+}
+
+// CheckInt returns a numeric parameter of a check, or the fallback if the parameter is not set. Parameters are
+// validated when the config file is read, so a value that is not a number is treated as not set.
+func (c *OctolintConfig) CheckInt(checkId string, parameter string, fallback int) int {
+	value, err := strconv.Atoi(c.CheckString(checkId, parameter, strconv.Itoa(fallback)))
+
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// SetCheckParameter sets a parameter of a check, creating the check configuration if required.
+func (c *OctolintConfig) SetCheckParameter(checkId string, parameter string, value string) {
+	if c.Checks == nil {
+		c.Checks = map[string]CheckConfig{}
+	}
+
+	checkConfig := c.Checks[strings.ToLower(checkId)]
+
+	if checkConfig.Parameters == nil {
+		checkConfig.Parameters = map[string]string{}
+	}
+
+	checkConfig.Parameters[strings.ToLower(parameter)] = value
+	c.Checks[strings.ToLower(checkId)] = checkConfig
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"golang.org/x/exp/slices"
+	"regexp"
+	"strings"
+)
+
+// The resource types that can be filtered
+const (
+	ProjectFilterResource     = "project"
+	TargetFilterResource      = "target"
+	TenantFilterResource      = "tenant"
+	EnvironmentFilterResource = "environment"
+	LifecycleFilterResource   = "lifecycle"
+	FeedFilterResource        = "feed"
+	AccountFilterResource     = "account"
+	WorkerPoolFilterResource  = "workerpool"
+)
+
+// FilterResources lists the resource types that can be filtered.
+var FilterResources = []string{ProjectFilterResource, TargetFilterResource, TenantFilterResource, EnvironmentFilterResource,
+	LifecycleFilterResource, FeedFilterResource, AccountFilterResource, WorkerPoolFilterResource}
+
+// The ways a filter can match a resource
+const (
+	// NameFilter matches the name of the resource exactly
+	NameFilter = "name"
+	// RegexFilter matches the name of the resource with a regular expression
+	RegexFilter = "regex"
+	// TagFilter matches the canonical name of a tenant tag, like Region/US, assigned to a target, tenant, or account
+	TagFilter = "tag"
+	// ProjectGroupFilter matches the name or ID of the group of a project
+	ProjectGroupFilter = "projectgroup"
+)
+
+// filterKinds maps the kinds of filters to the resource types they can be applied to. Name and regex filters can be
+// applied to every resource type.
+var filterKinds = map[string][]string{
+	NameFilter:         FilterResources,
+	RegexFilter:        FilterResources,
+	TagFilter:          {TargetFilterResource, TenantFilterResource, AccountFilterResource},
+	ProjectGroupFilter: {ProjectFilterResource},
+}
+
+// filterResourceAliases maps alternative names to the resource types
+var filterResourceAliases = map[string]string{
+	"machine":     TargetFilterResource,
+	"worker_pool": WorkerPoolFilterResource,
+}
+
+// ResourceFilter selects resources of one type by name, regular expression, tag, or project group.
+type ResourceFilter struct {
+	Resource string
+	Kind     string
+	Value    string
+}
+
+// ParseResourceFilters converts filters in the format resource:kind=value, like target:regex=^Test or
+// project:projectGroup=Sandbox. The kind can be left out to match the name, like tenant=Acme.
+func ParseResourceFilters(filters StringSliceArgs) ([]ResourceFilter, error) {
+	resourceFilters := []ResourceFilter{}
+
+	for _, filter := range filters {
+		key, value, found := strings.Cut(filter, "=")
+
+		if !found || strings.TrimSpace(key) == "" || value == "" {
+			return nil, errors.New("the filter \"" + filter + "\" must be in the format resource:kind=value")
+		}
+
+		resource, kind, _ := strings.Cut(strings.ToLower(strings.TrimSpace(key)), ":")
+		resource = strings.TrimSuffix(strings.TrimSpace(resource), "s")
+		kind = strings.TrimSpace(kind)
+
+		if alias, ok := filterResourceAliases[resource]; ok {
+			resource = alias
+		}
+
+		if kind == "" {
+			kind = NameFilter
+		}
+
+		if slices.Index(FilterResources, resource) == -1 {
+			return nil, errors.New("the filter \"" + filter + "\" has an unsupported resource type. Supported values are " + strings.Join(FilterResources, ", "))
+		}
+
+		resources, ok := filterKinds[kind]
+
+		if !ok {
+			return nil, errors.New("the filter \"" + filter + "\" has an unsupported kind. Supported values are name, regex, tag, and projectGroup")
+		}
+
+		if slices.Index(resources, resource) == -1 {
+			return nil, errors.New("the filter \"" + filter + "\" can only be applied to " + strings.Join(resources, ", ") + " resources")
+		}
+
+		if kind == RegexFilter {
+			if _, err := regexp.Compile(value); err != nil {
+				return nil, errors.New("the filter \"" + filter + "\" does not have a valid regular expression: " + err.Error())
+			}
+		}
+
+		resourceFilters = append(resourceFilters, ResourceFilter{Resource: resource, Kind: kind, Value: value})
+	}
+
+	return resourceFilters, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ParseCheckTimeouts converts timeouts in the format CheckId=duration, like OctoLintDeploymentQueuedTime=5m, to a map
+// of check IDs to timeouts.
+func ParseCheckTimeouts(checkTimeouts StringSliceArgs) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+
+	for _, checkTimeout := range checkTimeouts {
+		checkId, timeout, found := strings.Cut(checkTimeout, "=")
+
+		if !found || strings.TrimSpace(checkId) == "" {
+			return nil, errors.New("the check timeout \"" + checkTimeout + "\" must be in the format CheckId=duration")
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(timeout))
+
+		if err != nil {
+			return nil, errors.New("the check timeout \"" + checkTimeout + "\" does not have a valid duration: " + err.Error())
+		}
+
+		timeouts[strings.TrimSpace(checkId)] = duration
+	}
+
+	return timeouts, nil
+}
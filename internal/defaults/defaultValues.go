@@ -1,7 +1,10 @@
 package defaults
 
+import "time"
+
 const MaxEnvironments = 10
 const MaxTimeSinceLastTask = 30
+const MaxTimeSinceLastLogin = 90
 const MaxDuplicateVariables = 100
 const MaxDuplicateVariableProjects = 100
 const MaxDeploymentsByAdminProjects = 100
@@ -26,3 +29,11 @@ const MaxInvalidNameTargets = 100
 const MaxInsecureK8sTargets = 100
 const MaxDeploymentTasks = 100
 const MaxDefaultStepNameProjects = 100
+const MaxRetries = 3
+const RetryBackoff = time.Second
+const RetryMaxBackoff = 30 * time.Second
+const MaxConcurrentRequests = 20
+const MaxParallelSpaces = 4
+const MaxRuleProjects = 100
+const MaxRuleTargets = 100
+const MaxRuleTenants = 100
@@ -0,0 +1,124 @@
+package entry
+
+import (
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"net/http"
+	"net/url"
+)
+
+// dataSourceFactory creates the data source the checks for a space read from. An empty space creates the data
+// source used by the instance checks.
+type dataSourceFactory func(space octopusSpace) (client_wrapper.OctopusDataSource, error)
+
+// liveDataSources connects to the Octopus server and resolves the spaces to check. The returned rate limiter is
+// shared by all the data sources, so every request counts towards the same limits and request budget.
+func liveDataSources(octolintConfig *config.OctolintConfig) (dataSourceFactory, *client_wrapper.RateLimitTransport, []octopusSpace, error) {
+	if octolintConfig.Url == "" {
+		return nil, nil, nil, errors.New("You must specify the URL with the -url argument")
+	}
+
+	if _, err := url.ParseRequestURI(octolintConfig.Url); err != nil {
+		return nil, nil, nil, errors.New("The URL \"" + octolintConfig.Url + "\" is not valid")
+	}
+
+	if octolintConfig.ApiKey == "" {
+		return nil, nil, nil, errors.New("You must specify the API key with the -apiKey argument")
+	}
+
+	if octolintConfig.Space == "" {
+		return nil, nil, nil, errors.New("You must specify the space key with the -space argument")
+	}
+
+	// All requests, including retries, share the same rate limits and request budget
+	rateLimiter := client_wrapper.NewRateLimitTransport(http.DefaultTransport, client_wrapper.RequestLimits{
+		MaxRequestsPerSecond:  octolintConfig.MaxRequestsPerSecond,
+		MaxConcurrentRequests: octolintConfig.MaxConcurrentRequests,
+		RequestBudget:         octolintConfig.RequestBudget,
+	})
+
+	httpClient := client_wrapper.NewHttpClient(rateLimiter, client_wrapper.RetryPolicy{
+		MaxRetries:     octolintConfig.MaxRetries,
+		InitialBackoff: octolintConfig.RetryBackoff,
+		MaxBackoff:     octolintConfig.RetryMaxBackoff,
+	})
+
+	checkedSpaces, err := resolveSpaces(httpClient, octolintConfig.Url, octolintConfig.Space, octolintConfig.ApiKey)
+
+	if err != nil {
+		return nil, nil, nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+	}
+
+	newDataSource := func(space octopusSpace) (client_wrapper.OctopusDataSource, error) {
+		// An empty space ID creates a client that is not scoped to a space
+		client, err := client_wrapper.CreateClient(httpClient, octolintConfig.Url, space.Id, octolintConfig.ApiKey)
+
+		if err != nil {
+			return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+		}
+
+		return client_wrapper.NewLiveDataSource(client, octolintConfig.GitRef), nil
+	}
+
+	return newDataSource, rateLimiter, checkedSpaces, nil
+}
+
+// snapshotDataSources reads a snapshot saved by an earlier run. The returned URL is the URL of the server the
+// snapshot was saved from, and is used to build the links in the report.
+func snapshotDataSources(octolintConfig *config.OctolintConfig) (dataSourceFactory, string, []octopusSpace, error) {
+	snapshot, err := client_wrapper.ReadOctopusSnapshot(octolintConfig.Snapshot)
+
+	if err != nil {
+		return nil, "", nil, errors.New("Failed to read the snapshot " + octolintConfig.Snapshot + ": " + err.Error())
+	}
+
+	if err := snapshot.ValidateGitRef(octolintConfig.GitRef); err != nil {
+		return nil, "", nil, errors.New("Failed to read the snapshot " + octolintConfig.Snapshot + ": " + err.Error())
+	}
+
+	checkedSpaces, err := resolveSnapshotSpaces(snapshot, octolintConfig.Space)
+
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	newDataSource := func(space octopusSpace) (client_wrapper.OctopusDataSource, error) {
+		dataSource, err := snapshot.Replay(space.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return dataSource, nil
+	}
+
+	return newDataSource, snapshot.Url, checkedSpaces, nil
+}
+
+// recordingDataSources saves every resource read from the data sources in the snapshot.
+func recordingDataSources(newDataSource dataSourceFactory, snapshot *client_wrapper.OctopusSnapshot) dataSourceFactory {
+	return func(space octopusSpace) (client_wrapper.OctopusDataSource, error) {
+		dataSource, err := newDataSource(space)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return snapshot.Record(dataSource, space.Name), nil
+	}
+}
+
+// filteredDataSources removes the resources excluded by the include and exclude arguments from the data sources. The
+// filters are applied after the resources are saved in a snapshot, so the snapshot can be checked with other filters.
+func filteredDataSources(newDataSource dataSourceFactory, filters *client_wrapper.ResourceFilters) dataSourceFactory {
+	return func(space octopusSpace) (client_wrapper.OctopusDataSource, error) {
+		dataSource, err := newDataSource(space)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return client_wrapper.NewFilteredDataSource(dataSource, filters), nil
+	}
+}
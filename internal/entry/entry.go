@@ -1,23 +1,21 @@
 package entry
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
-	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/baseline"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/factory"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/executor"
-	"github.com/OctopusSolutionsEngineering/OctopusTerraformTestFramework/octoclient"
-	"github.com/briandowns/spinner"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/progress"
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"net/http"
-	"net/url"
+	"golang.org/x/sync/errgroup"
 	"os"
-	"strings"
 	"time"
 )
 
@@ -26,136 +24,270 @@ var Version = "development"
 func Entry(octolintConfig *config.OctolintConfig) ([]checks.OctopusCheckResult, error) {
 	zap.ReplaceGlobals(createLogger(octolintConfig.Verbose))
 
-	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	if octolintConfig.Version {
+		fmt.Println("Version: " + Version)
+		os.Exit(0)
+	}
 
-	if octolintConfig.Spinner && !octolintConfig.Verbose {
-		s.Start()
+	// OCL files are checked locally, without connecting to a server
+	if octolintConfig.Ocl != "" {
+		return runOcl(octolintConfig)
 	}
 
-	defer func() {
-		if octolintConfig.Spinner && !octolintConfig.Verbose {
-			s.Stop()
-		}
-	}()
+	var newDataSource dataSourceFactory
+	var rateLimiter *client_wrapper.RateLimitTransport
+	var checkedSpaces []octopusSpace
+	var err error
+	octopusUrl := octolintConfig.Url
 
-	if octolintConfig.Version {
-		fmt.Println("Version: " + Version)
-		os.Exit(0)
+	if octolintConfig.Snapshot != "" {
+		newDataSource, octopusUrl, checkedSpaces, err = snapshotDataSources(octolintConfig)
+	} else {
+		newDataSource, rateLimiter, checkedSpaces, err = liveDataSources(octolintConfig)
 	}
 
-	if octolintConfig.Url == "" {
-		return nil, errors.New("You must specify the URL with the -url argument")
+	if err != nil {
+		return nil, err
+	}
+
+	// The requests are only limited when checking a live server. A nil rate limiter must not be passed to the
+	// executor as a non-nil interface.
+	var requestBudget executor.RequestBudget
+	if rateLimiter != nil {
+		requestBudget = rateLimiter
 	}
 
-	if _, err := url.ParseRequestURI(octolintConfig.Url); err != nil {
-		return nil, errors.New("The URL \"" + octolintConfig.Url + "\" is not valid")
+	var snapshot *client_wrapper.OctopusSnapshot
+	if octolintConfig.WriteSnapshot != "" {
+		snapshot = client_wrapper.NewOctopusSnapshot(octopusUrl, octolintConfig.GitRef)
+		newDataSource = recordingDataSources(newDataSource, snapshot)
+	}
+
+	filters, err := client_wrapper.NewResourceFilters(octolintConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	newDataSource = filteredDataSources(newDataSource, filters)
+
+	// Time the execution
+	startTime := time.Now().UnixMilli()
+	defer func() {
+		endTime := time.Now().UnixMilli()
+		// This is written to stderr so it does not pollute machine-readable reports written to stdout
+		fmt.Fprintln(os.Stderr, "Report took "+fmt.Sprint((endTime-startTime)/1000)+" seconds")
+	}()
+
+	checkTimeouts, err := config.ParseCheckTimeouts(octolintConfig.CheckTimeouts)
+
+	if err != nil {
+		return nil, err
 	}
 
-	if octolintConfig.ApiKey == "" {
-		return nil, errors.New("You must specify the API key with the -apiKey argument")
+	ctx := context.Background()
+	if octolintConfig.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, octolintConfig.Timeout)
+		defer cancel()
 	}
 
-	if octolintConfig.Space == "" {
-		return nil, errors.New("You must specify the space key with the -space argument")
+	// The instance checks are reported as the last member of the progress group
+	progressGroup := progress.NewGroup(progressListener(octolintConfig), len(checkedSpaces)+1)
+	spaceResults := make([][]checks.OctopusCheckResult, len(checkedSpaces))
+	var instanceResults []checks.OctopusCheckResult
+
+	// Each space is checked with its own client and cache, while the rate limits and request budget are shared
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(octolintConfig.MaxParallelSpaces)
+
+	for i, space := range checkedSpaces {
+		i := i
+		space := space
+
+		g.Go(func() error {
+			results, err := runSpace(ctx, newDataSource, requestBudget, progressGroup.Member(i), octolintConfig, checkTimeouts, octopusUrl, space)
+
+			if err != nil {
+				// Let the progress bar finish if the space could not be checked
+				progressGroup.Member(i).Update(progress.Update{Finished: true})
+				return err
+			}
+
+			spaceResults[i] = results
+			return nil
+		})
 	}
 
-	if !strings.HasPrefix(octolintConfig.Space, "Spaces-") {
-		spaceId, err := lookupSpaceAsName(octolintConfig.Url, octolintConfig.Space, octolintConfig.ApiKey)
+	// The instance checks inspect the server as a whole, so they are run once rather than for each space
+	g.Go(func() error {
+		results, err := runInstance(ctx, newDataSource, requestBudget, progressGroup.Member(len(checkedSpaces)), octolintConfig, checkTimeouts, octopusUrl)
 
 		if err != nil {
-			return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+			progressGroup.Member(len(checkedSpaces)).Update(progress.Update{Finished: true})
+			return err
+		}
+
+		instanceResults = results
+		return nil
+	})
+
+	err = g.Wait()
+
+	if rateLimiter != nil {
+		zap.L().Debug("API requests: " + fmt.Sprint(rateLimiter.Requests()))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot != nil {
+		if err := snapshot.Write(octolintConfig.WriteSnapshot); err != nil {
+			return nil, errors.New("Failed to write the snapshot " + octolintConfig.WriteSnapshot + ": " + err.Error())
 		}
+	}
+
+	results, err := applyBaseline(octolintConfig, append(lo.Flatten(spaceResults), instanceResults...))
 
-		octolintConfig.Space = spaceId
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := octoclient.CreateClient(octolintConfig.Url, octolintConfig.Space, octolintConfig.ApiKey)
+	if len(checkedSpaces) > 1 {
+		fmt.Fprintln(os.Stderr, instanceSummaryLines(checkedSpaces, results))
+		results = append(results, newInstanceSummaryResult(checkedSpaces, results))
+	}
+
+	return results, nil
+}
+
+// runSpace runs all the checks against a single space. The results are tagged with the name of the space.
+func runSpace(ctx context.Context, newDataSource dataSourceFactory, requestBudget executor.RequestBudget, listener progress.Listener, octolintConfig *config.OctolintConfig, checkTimeouts map[string]time.Duration, octopusUrl string, space octopusSpace) ([]checks.OctopusCheckResult, error) {
+	dataSource, err := newDataSource(space)
 
 	if err != nil {
-		return nil, errors.New("Failed to create the Octopus client_wrapper. Check that the url, api key, and space are correct.\nThe error was: " + err.Error())
+		return nil, err
 	}
 
-	factory := factory.NewOctopusCheckFactory(client, octolintConfig.Url, octolintConfig.Space)
+	// The cache is shared by all the checks so each resource is only read once
+	cache := client_wrapper.NewOctopusClientCache(dataSource)
+	factory := factory.NewOctopusCheckFactory(dataSource, cache, octopusUrl, space.Id)
 	checkCollection, err := factory.BuildAllChecks(octolintConfig)
 
 	if err != nil {
-		ErrorExit("Failed to create the checks")
+		return nil, errors.New("Failed to create the checks: " + err.Error())
 	}
 
-	// Time the execution
-	startTime := time.Now().UnixMilli()
-	defer func() {
-		endTime := time.Now().UnixMilli()
-		fmt.Println("Report took " + fmt.Sprint((endTime-startTime)/1000) + " seconds")
-	}()
-
-	executor := executor.NewOctopusCheckExecutor()
-	results, err := executor.ExecuteChecks(checkCollection, func(check checks.OctopusCheck, err error) error {
-		fmt.Fprintf(os.Stderr, "Failed to execute check "+check.Id())
+	executor := executor.NewOctopusCheckExecutor(octolintConfig.MaxConcurrentRequests, requestBudget, listener, octolintConfig.CheckTimeout, checkTimeouts)
+	results, err := executor.ExecuteChecks(ctx, checkCollection, func(check checks.OctopusCheck, err error) error {
+		fmt.Fprintln(os.Stderr, "Failed to execute check "+check.Id()+" in space "+space.Name)
 		if octolintConfig.VerboseErrors {
-			fmt.Println("##octopus[stdout-verbose]")
-			fmt.Println(err.Error())
-			fmt.Println("##octopus[stdout-default]")
+			fmt.Fprintln(os.Stderr, "##octopus[stdout-verbose]")
+			fmt.Fprintln(os.Stderr, err.Error())
+			fmt.Fprintln(os.Stderr, "##octopus[stdout-default]")
 		} else {
-			fmt.Fprintf(os.Stderr, err.Error()+"\n")
+			fmt.Fprintln(os.Stderr, err.Error())
 		}
 		return nil
 	})
 
+	cache.LogStatistics()
+
 	if err != nil {
 		return nil, errors.New("Failed to run the checks")
 	}
 
-	return results, nil
+	return lo.Map(results, func(item checks.OctopusCheckResult, index int) checks.OctopusCheckResult {
+		return checks.NewOctopusCheckSpaceResult(item, space.Id, space.Name)
+	}), nil
 }
 
-func ErrorExit(message string) {
-	fmt.Println(message)
-	os.Exit(1)
-}
+// runInstance runs the checks that inspect the server rather than a space. These results are not tagged with a space.
+func runInstance(ctx context.Context, newDataSource dataSourceFactory, requestBudget executor.RequestBudget, listener progress.Listener, octolintConfig *config.OctolintConfig, checkTimeouts map[string]time.Duration, octopusUrl string) ([]checks.OctopusCheckResult, error) {
+	// An empty space creates a data source that is not scoped to a space
+	dataSource, err := newDataSource(octopusSpace{})
 
-func lookupSpaceAsName(octopusUrl string, spaceName string, apiKey string) (string, error) {
-	if len(strings.TrimSpace(spaceName)) == 0 {
-		return "", errors.New("space can not be empty")
+	if err != nil {
+		return nil, err
 	}
 
-	requestURL := fmt.Sprintf("%s/api/Spaces?take=1000&partialName=%s", octopusUrl, url.QueryEscape(spaceName))
+	cache := client_wrapper.NewOctopusClientCache(dataSource)
+	factory := factory.NewOctopusInstanceCheckFactory(dataSource, cache, octopusUrl)
+	checkCollection, err := factory.BuildAllChecks(octolintConfig)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the checks")
+	}
 
-	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	executor := executor.NewOctopusCheckExecutor(octolintConfig.MaxConcurrentRequests, requestBudget, listener, octolintConfig.CheckTimeout, checkTimeouts)
+	results, err := executor.ExecuteChecks(ctx, checkCollection, func(check checks.OctopusCheck, err error) error {
+		fmt.Fprintln(os.Stderr, "Failed to execute check "+check.Id())
+		if octolintConfig.VerboseErrors {
+			fmt.Fprintln(os.Stderr, "##octopus[stdout-verbose]")
+			fmt.Fprintln(os.Stderr, err.Error())
+			fmt.Fprintln(os.Stderr, "##octopus[stdout-default]")
+		} else {
+			fmt.Fprintln(os.Stderr, err.Error())
+		}
+		return nil
+	})
 
 	if err != nil {
-		return "", err
+		return nil, errors.New("Failed to run the checks")
+	}
+
+	return results, nil
+}
+
+// progressListener returns the listener that displays the progress of the checks. Progress is written to stderr so
+// it does not pollute machine-readable reports written to stdout.
+func progressListener(octolintConfig *config.OctolintConfig) progress.Listener {
+	if octolintConfig.ProgressJson {
+		return progress.NewJsonLines(os.Stderr)
 	}
 
-	if apiKey != "" {
-		req.Header.Set("X-Octopus-ApiKey", apiKey)
+	// The progress bar would be interleaved with the verbose logs
+	if octolintConfig.Spinner && !octolintConfig.Verbose {
+		return progress.NewBar(os.Stderr)
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	return nil
+}
 
-	if err != nil {
-		return "", err
+// applyBaseline saves the results to a new baseline file, and removes any findings found in an existing
+// baseline file.
+func applyBaseline(octolintConfig *config.OctolintConfig, results []checks.OctopusCheckResult) ([]checks.OctopusCheckResult, error) {
+	if octolintConfig.WriteBaseline != "" {
+		if err := baseline.NewOctopusBaseline(results).Write(octolintConfig.WriteBaseline); err != nil {
+			return nil, errors.New("Failed to write the baseline file " + octolintConfig.WriteBaseline + ": " + err.Error())
+		}
 	}
 
-	if res.StatusCode != 200 {
-		return "", nil
+	if octolintConfig.Baseline == "" {
+		return results, nil
 	}
-	defer res.Body.Close()
 
-	collection := resources.Resources[spaces.Space]{}
-	err = json.NewDecoder(res.Body).Decode(&collection)
+	existingBaseline, err := baseline.ReadOctopusBaseline(octolintConfig.Baseline)
 
 	if err != nil {
-		return "", err
+		return nil, errors.New("Failed to read the baseline file " + octolintConfig.Baseline + ": " + err.Error())
 	}
 
-	for _, space := range collection.Items {
-		if space.Name == spaceName {
-			return space.ID, nil
-		}
+	filteredResults, resolved := existingBaseline.Apply(results)
+
+	if len(resolved) != 0 {
+		fmt.Fprintln(os.Stderr, fmt.Sprint(len(resolved))+" findings from the baseline have been resolved")
+		filteredResults = append(filteredResults, baseline.NewResolvedResult(resolved))
 	}
 
-	return "", errors.New("did not find space with name " + spaceName)
+	return filteredResults, nil
+}
+
+// ErrorExit prints the message to stderr, keeping it out of the report written to stdout, and exits.
+func ErrorExit(message string) {
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(ExitCodeError)
 }
 
 func createLogger(verbose bool) *zap.Logger {
@@ -0,0 +1,52 @@
+package entry
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/baseline"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+const (
+	// ExitCodeOk indicates that no results failed the run
+	ExitCodeOk = 0
+	// ExitCodeError indicates that octolint could not run, usually because of invalid arguments
+	ExitCodeError = 1
+	// ExitCodeFindings indicates that one or more results were at or above the failure threshold
+	ExitCodeFindings = 2
+	// ExitCodeCheckFailures indicates that one or more checks failed to run, timed out, or ran out of API requests
+	ExitCodeCheckFailures = 3
+	// ExitCodePermissions indicates that the only problems were checks that could not run due to missing permissions
+	ExitCodePermissions = 4
+)
+
+// ExitCode returns the process exit code for the results. Findings at or above the failOnSeverity threshold take
+// precedence over checks that failed to run, which in turn take precedence over permission errors.
+func ExitCode(results []checks.OctopusCheckResult, failOnSeverity int) int {
+	checkFailures := false
+	permissionErrors := false
+
+	for _, r := range results {
+		// Resolved baseline findings are good news, and the instance summary only repeats other results, so
+		// neither fails the run
+		if r.Code() == baseline.OctoLintResolvedFindings || r.Code() == OctoLintInstanceSummary {
+			continue
+		}
+
+		if checks.IsCheckFailure(r) {
+			checkFailures = true
+		} else if r.Severity() == checks.Permission {
+			permissionErrors = true
+		} else if r.Severity() != checks.Ok && r.Severity() >= failOnSeverity {
+			return ExitCodeFindings
+		}
+	}
+
+	if checkFailures {
+		return ExitCodeCheckFailures
+	}
+
+	if permissionErrors {
+		return ExitCodePermissions
+	}
+
+	return ExitCodeOk
+}
@@ -0,0 +1,63 @@
+package entry
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"testing"
+)
+
+func TestExitCodeNoResults(t *testing.T) {
+	if ExitCode(nil, checks.Warning) != ExitCodeOk {
+		t.Fatal("Should have returned the ok exit code")
+	}
+}
+
+func TestExitCodeBelowThreshold(t *testing.T) {
+	infoResult := checks.NewOctopusCheckResultImpl("Some info", "OctoRecInfo", "", checks.Info, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	if ExitCode([]checks.OctopusCheckResult{infoResult, passResult}, checks.Warning) != ExitCodeOk {
+		t.Fatal("Results below the threshold must not fail the run")
+	}
+
+	if ExitCode([]checks.OctopusCheckResult{infoResult, passResult}, checks.Info) != ExitCodeFindings {
+		t.Fatal("Results at the threshold must fail the run")
+	}
+}
+
+func TestExitCodePrecedence(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, checks.Organization)
+	generalError := checks.NewOctopusCheckResultImpl("The check failed to run", "OctoRecBroken", "", checks.Error, checks.GeneralError)
+	permissionResult := checks.NewOctopusCheckResultImpl("You do not have permission to run the check", "OctoRecPermission", "", checks.Permission, checks.Security)
+
+	if ExitCode([]checks.OctopusCheckResult{permissionResult, generalError, failedResult}, checks.Warning) != ExitCodeFindings {
+		t.Fatal("Findings must take precedence over other failures")
+	}
+
+	if ExitCode([]checks.OctopusCheckResult{permissionResult, generalError}, checks.Warning) != ExitCodeCheckFailures {
+		t.Fatal("Check failures must take precedence over permission errors")
+	}
+
+	if ExitCode([]checks.OctopusCheckResult{permissionResult}, checks.Warning) != ExitCodePermissions {
+		t.Fatal("Permission only results must return the permission exit code")
+	}
+}
+
+func TestExitCodeTimeout(t *testing.T) {
+	timedOut := checks.NewOctopusCheckResultImpl("The check did not complete within 1m0s", "OctoRecSlow", "", checks.Error, checks.TimedOut)
+
+	if ExitCode([]checks.OctopusCheckResult{timedOut}, checks.Warning) != ExitCodeCheckFailures {
+		t.Fatal("Timed out checks must return the check failure exit code")
+	}
+}
+
+func TestExitCodeBudgetExhausted(t *testing.T) {
+	budgetExhausted := checks.NewOctopusCheckResultImpl("The check could not run because the API request budget was exhausted", "OctoRecBudget", "", checks.Info, checks.BudgetExhausted)
+
+	if ExitCode([]checks.OctopusCheckResult{budgetExhausted}, checks.Warning) != ExitCodeCheckFailures {
+		t.Fatal("Checks that ran out of API requests must return the check failure exit code")
+	}
+
+	if ExitCode([]checks.OctopusCheckResult{budgetExhausted}, checks.Info) != ExitCodeCheckFailures {
+		t.Fatal("Checks that ran out of API requests must not be reported as findings")
+	}
+}
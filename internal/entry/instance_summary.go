@@ -0,0 +1,106 @@
+package entry
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"strings"
+)
+
+// OctoLintInstanceSummary is the code of the result summarising the findings in each space when multiple spaces
+// are checked.
+const OctoLintInstanceSummary = "OctoLintInstanceSummary"
+
+// spaceTotals counts the results reported for a single space.
+type spaceTotals struct {
+	errors        int
+	warnings      int
+	info          int
+	checkFailures int
+	permissions   int
+}
+
+func (s spaceTotals) String() string {
+	return fmt.Sprint(s.errors) + " errors, " +
+		fmt.Sprint(s.warnings) + " warnings, " +
+		fmt.Sprint(s.info) + " info, " +
+		fmt.Sprint(s.checkFailures) + " check failures, " +
+		fmt.Sprint(s.permissions) + " permission errors"
+}
+
+// countSpaceTotals returns the totals for each space. Results without any findings count as a single finding.
+func countSpaceTotals(checkedSpaces []octopusSpace, results []checks.OctopusCheckResult) map[string]*spaceTotals {
+	totals := map[string]*spaceTotals{}
+	for _, space := range checkedSpaces {
+		totals[space.Name] = &spaceTotals{}
+	}
+
+	for _, r := range results {
+		spaceTotal, ok := totals[r.Space()]
+
+		if !ok {
+			continue
+		}
+
+		if checks.IsCheckFailure(r) {
+			spaceTotal.checkFailures++
+			continue
+		}
+
+		if r.Severity() == checks.Permission {
+			spaceTotal.permissions++
+			continue
+		}
+
+		findings := len(r.Findings())
+		if findings == 0 {
+			findings = 1
+		}
+
+		switch {
+		case r.Severity() >= checks.Error:
+			spaceTotal.errors += findings
+		case r.Severity() >= checks.Warning:
+			spaceTotal.warnings += findings
+		case r.Severity() >= checks.Info:
+			spaceTotal.info += findings
+		}
+	}
+
+	return totals
+}
+
+// newInstanceSummaryResult creates a result listing the totals for each space that was checked.
+func newInstanceSummaryResult(checkedSpaces []octopusSpace, results []checks.OctopusCheckResult) checks.OctopusCheckResult {
+	totals := countSpaceTotals(checkedSpaces, results)
+
+	findings := []checks.OctopusCheckFinding{}
+	for _, space := range checkedSpaces {
+		findings = append(findings, checks.OctopusCheckFinding{
+			ResourceType: checks.SpaceResource,
+			ResourceId:   space.Id,
+			ResourceName: space.Name,
+			SpaceId:      space.Id,
+			Details:      totals[space.Name].String(),
+		})
+	}
+
+	return checks.NewOctopusCheckResultWithFindings(
+		"Checked "+fmt.Sprint(len(checkedSpaces))+" spaces with the following results:",
+		OctoLintInstanceSummary,
+		checks.WikiLink(OctoLintInstanceSummary),
+		checks.Info,
+		checks.Organization,
+		findings)
+}
+
+// instanceSummaryLines builds the per-space totals printed at the end of a run against multiple spaces.
+func instanceSummaryLines(checkedSpaces []octopusSpace, results []checks.OctopusCheckResult) string {
+	totals := countSpaceTotals(checkedSpaces, results)
+
+	lines := []string{}
+	for _, space := range checkedSpaces {
+		lines = append(lines, space.Name+": "+totals[space.Name].String())
+	}
+
+	return strings.Join(lines, "\n")
+}
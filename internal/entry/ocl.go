@@ -0,0 +1,85 @@
+package entry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks/factory"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/executor"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/ocl"
+	"github.com/samber/lo"
+	"os"
+)
+
+// runOcl runs the checks that inspect deployment processes, runbooks, and variables against the OCL files of a
+// version controlled project. No Octopus server is contacted.
+func runOcl(octolintConfig *config.OctolintConfig) ([]checks.OctopusCheckResult, error) {
+	project, err := ocl.ReadProject(octolintConfig.Ocl)
+
+	if err != nil {
+		return nil, errors.New("Failed to read the OCL files in " + octolintConfig.Ocl + ": " + err.Error())
+	}
+
+	checkTimeouts, err := config.ParseCheckTimeouts(octolintConfig.CheckTimeouts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if octolintConfig.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, octolintConfig.Timeout)
+		defer cancel()
+	}
+
+	dataSource := client_wrapper.NewOclDataSource(project)
+	cache := client_wrapper.NewOctopusClientCache(dataSource)
+	factory := factory.NewOctopusCheckFactory(dataSource, cache, "", "")
+	checkCollection, err := factory.BuildOclChecks(octolintConfig)
+
+	if err != nil {
+		return nil, errors.New("Failed to create the checks: " + err.Error())
+	}
+
+	executor := executor.NewOctopusCheckExecutor(octolintConfig.MaxConcurrentRequests, nil, progressListener(octolintConfig), octolintConfig.CheckTimeout, checkTimeouts)
+	results, err := executor.ExecuteChecks(ctx, checkCollection, func(check checks.OctopusCheck, err error) error {
+		fmt.Fprintln(os.Stderr, "Failed to execute check "+check.Id())
+		fmt.Fprintln(os.Stderr, err.Error())
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.New("Failed to run the checks")
+	}
+
+	results = lo.Map(results, func(item checks.OctopusCheckResult, index int) checks.OctopusCheckResult {
+		return oclResult(project, item)
+	})
+
+	return applyBaseline(octolintConfig, results)
+}
+
+// oclResult replaces the links to the Octopus web portal, which don't exist for local files, with the file and line
+// of each finding.
+func oclResult(project *ocl.Project, result checks.OctopusCheckResult) checks.OctopusCheckResult {
+	if len(result.Findings()) == 0 {
+		return result
+	}
+
+	findings := lo.Map(result.Findings(), func(finding checks.OctopusCheckFinding, index int) checks.OctopusCheckFinding {
+		finding.Link = ""
+
+		if position, ok := project.Position(finding.ResourceId); ok {
+			finding.File = position.File
+			finding.Line = position.Line
+		}
+
+		return finding
+	})
+
+	return checks.CopyOctopusCheckResult(result, findings)
+}
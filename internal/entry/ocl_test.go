@@ -0,0 +1,43 @@
+package entry
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/ocl"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOclResult(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "variables.ocl"), []byte("variable \"unused\" {\n    value \"test\" {}\n}\n"), 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := ocl.ReadProject(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := oclResult(project, checks.NewOctopusCheckResultWithFindings(
+		"The following variables may be unused:",
+		"OctoLintUnusedVariables",
+		"",
+		checks.Warning,
+		checks.Organization,
+		[]checks.OctopusCheckFinding{{
+			ResourceType: checks.VariableResource,
+			ResourceId:   project.Variables.Variables[0].ID,
+			ResourceName: "unused",
+			Link:         "https://example.octopus.app/app#/Spaces-1/projects/Projects-1/variables",
+		}}))
+
+	finding := result.Findings()[0]
+
+	if finding.File != filepath.Join(dir, "variables.ocl") || finding.Line != 2 || finding.Link != "" {
+		t.Fatalf("the finding should have the position of the variable instead of a link, got %+v", finding)
+	}
+}
@@ -0,0 +1,186 @@
+package entry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/resources"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/spaces"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/samber/lo"
+	"golang.org/x/exp/slices"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AllSpaces is the value passed to the -space argument to check every space
+const AllSpaces = "all"
+
+// octopusSpace identifies a space the checks are run against.
+type octopusSpace struct {
+	Id   string
+	Name string
+}
+
+// parseSpaceArg splits the comma separated list of space names and IDs passed to the -space argument.
+func parseSpaceArg(spaceArg string) []string {
+	spaceNames := []string{}
+	for _, spaceName := range strings.Split(spaceArg, ",") {
+		spaceName = strings.TrimSpace(spaceName)
+		if spaceName != "" && !slices.Contains(spaceNames, spaceName) {
+			spaceNames = append(spaceNames, spaceName)
+		}
+	}
+
+	return spaceNames
+}
+
+// resolveSpaces returns the spaces matching the -space argument. Spaces can be identified by their ID or name, or
+// every space can be selected with the value "all".
+func resolveSpaces(httpClient *http.Client, octopusUrl string, spaceArg string, apiKey string) ([]octopusSpace, error) {
+	spaceNames := parseSpaceArg(spaceArg)
+
+	if len(spaceNames) == 0 {
+		return nil, errors.New("space can not be empty")
+	}
+
+	if len(spaceNames) == 1 && strings.EqualFold(spaceNames[0], AllSpaces) {
+		return lookupAllSpaces(httpClient, octopusUrl, apiKey)
+	}
+
+	resolvedSpaces := []octopusSpace{}
+	for _, spaceName := range spaceNames {
+		if strings.HasPrefix(spaceName, "Spaces-") {
+			resolvedSpaces = append(resolvedSpaces, octopusSpace{Id: spaceName, Name: spaceName})
+			continue
+		}
+
+		spaceId, err := lookupSpaceAsName(httpClient, octopusUrl, spaceName, apiKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resolvedSpaces = append(resolvedSpaces, octopusSpace{Id: spaceId, Name: spaceName})
+	}
+
+	return resolvedSpaces, nil
+}
+
+func lookupAllSpaces(httpClient *http.Client, octopusUrl string, apiKey string) ([]octopusSpace, error) {
+	req, err := http.NewRequest(http.MethodGet, octopusUrl+"/api/Spaces/all", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey != "" {
+		req.Header.Set("X-Octopus-ApiKey", apiKey)
+	}
+
+	res, err := httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, errors.New("failed to list the spaces, the status code was " + fmt.Sprint(res.StatusCode))
+	}
+
+	allSpaces := []spaces.Space{}
+	if err := json.NewDecoder(res.Body).Decode(&allSpaces); err != nil {
+		return nil, err
+	}
+
+	if len(allSpaces) == 0 {
+		return nil, errors.New("did not find any spaces")
+	}
+
+	resolvedSpaces := []octopusSpace{}
+	for _, space := range allSpaces {
+		resolvedSpaces = append(resolvedSpaces, octopusSpace{Id: space.ID, Name: space.Name})
+	}
+
+	return resolvedSpaces, nil
+}
+
+func lookupSpaceAsName(httpClient *http.Client, octopusUrl string, spaceName string, apiKey string) (string, error) {
+	if len(strings.TrimSpace(spaceName)) == 0 {
+		return "", errors.New("space can not be empty")
+	}
+
+	requestURL := fmt.Sprintf("%s/api/Spaces?take=1000&partialName=%s", octopusUrl, url.QueryEscape(spaceName))
+
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	if apiKey != "" {
+		req.Header.Set("X-Octopus-ApiKey", apiKey)
+	}
+
+	res, err := httpClient.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != 200 {
+		return "", nil
+	}
+	defer res.Body.Close()
+
+	collection := resources.Resources[spaces.Space]{}
+	err = json.NewDecoder(res.Body).Decode(&collection)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, space := range collection.Items {
+		if space.Name == spaceName {
+			return space.ID, nil
+		}
+	}
+
+	return "", errors.New("did not find space with name " + spaceName)
+}
+
+// resolveSnapshotSpaces returns the spaces in the snapshot matching the -space argument. Every space in the snapshot
+// is checked if the argument is empty or "all".
+func resolveSnapshotSpaces(snapshot *client_wrapper.OctopusSnapshot, spaceArg string) ([]octopusSpace, error) {
+	spaceNames := parseSpaceArg(spaceArg)
+
+	if len(spaceNames) == 0 || (len(spaceNames) == 1 && strings.EqualFold(spaceNames[0], AllSpaces)) {
+		resolvedSpaces := []octopusSpace{}
+		for _, space := range snapshot.Spaces {
+			resolvedSpaces = append(resolvedSpaces, octopusSpace{Id: space.Id, Name: space.Name})
+		}
+
+		if len(resolvedSpaces) == 0 {
+			return nil, errors.New("the snapshot does not include any spaces")
+		}
+
+		return resolvedSpaces, nil
+	}
+
+	resolvedSpaces := []octopusSpace{}
+	for _, spaceName := range spaceNames {
+		space, found := lo.Find(snapshot.Spaces, func(item client_wrapper.SnapshotSpace) bool {
+			return item.Id == spaceName || item.Name == spaceName
+		})
+
+		if !found {
+			return nil, errors.New("the snapshot does not include the space " + spaceName)
+		}
+
+		resolvedSpaces = append(resolvedSpaces, octopusSpace{Id: space.Id, Name: space.Name})
+	}
+
+	return resolvedSpaces, nil
+}
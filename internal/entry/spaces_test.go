@@ -0,0 +1,127 @@
+package entry
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newSpacesServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/Spaces/all":
+			w.Write([]byte(`[{"Id":"Spaces-1","Name":"Default"},{"Id":"Spaces-2","Name":"Second"}]`))
+		case "/api/Spaces":
+			w.Write([]byte(`{"Items":[{"Id":"Spaces-2","Name":"Second"},{"Id":"Spaces-3","Name":"Second Copy"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestParseSpaceArg(t *testing.T) {
+	spaceNames := parseSpaceArg(" Default, Spaces-2,,Default ")
+
+	if len(spaceNames) != 2 || spaceNames[0] != "Default" || spaceNames[1] != "Spaces-2" {
+		t.Fatal("Should have returned the unique space names")
+	}
+}
+
+func TestResolveAllSpaces(t *testing.T) {
+	server := newSpacesServer()
+	defer server.Close()
+
+	resolvedSpaces, err := resolveSpaces(http.DefaultClient, server.URL, "All", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resolvedSpaces) != 2 || resolvedSpaces[0] != (octopusSpace{Id: "Spaces-1", Name: "Default"}) {
+		t.Fatal("Should have returned every space")
+	}
+}
+
+func TestResolveSpaceNamesAndIds(t *testing.T) {
+	server := newSpacesServer()
+	defer server.Close()
+
+	resolvedSpaces, err := resolveSpaces(http.DefaultClient, server.URL, "Spaces-1,Second", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resolvedSpaces) != 2 ||
+		resolvedSpaces[0] != (octopusSpace{Id: "Spaces-1", Name: "Spaces-1"}) ||
+		resolvedSpaces[1] != (octopusSpace{Id: "Spaces-2", Name: "Second"}) {
+		t.Fatal("Should have resolved the space IDs and names")
+	}
+
+	if _, err := resolveSpaces(http.DefaultClient, server.URL, "Missing", ""); err == nil {
+		t.Fatal("Should have failed to find the space")
+	}
+}
+
+func TestInstanceSummary(t *testing.T) {
+	checkedSpaces := []octopusSpace{{Id: "Spaces-1", Name: "Default"}, {Id: "Spaces-2", Name: "Second"}}
+	results := []checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(checks.NewOctopusCheckResultWithFindings("Bad projects", "OctoRecError", "", checks.Error, checks.Organization, []checks.OctopusCheckFinding{{ResourceId: "Projects-1"}, {ResourceId: "Projects-2"}}), "Spaces-1", "Default"),
+		checks.NewOctopusCheckSpaceResult(checks.NewOctopusCheckResultImpl("Some warning", "OctoRecWarning", "", checks.Warning, checks.Organization), "Spaces-1", "Default"),
+		checks.NewOctopusCheckSpaceResult(checks.NewOctopusCheckResultImpl("Timed out", "OctoRecTimeout", "", checks.Error, checks.TimedOut), "Spaces-2", "Second"),
+		checks.NewOctopusCheckSpaceResult(checks.NewOctopusCheckResultImpl("All good", "OctoRecOk", "", checks.Ok, checks.Organization), "Spaces-2", "Second"),
+	}
+
+	summary := newInstanceSummaryResult(checkedSpaces, results)
+
+	if len(summary.Findings()) != 2 {
+		t.Fatal("Should have summarised each space")
+	}
+
+	if summary.Findings()[0].Details != "2 errors, 1 warnings, 0 info, 0 check failures, 0 permission errors" {
+		t.Fatal("Should have counted the findings in the first space, got " + summary.Findings()[0].Details)
+	}
+
+	if summary.Findings()[1].Details != "0 errors, 0 warnings, 0 info, 1 check failures, 0 permission errors" {
+		t.Fatal("Should have counted the check failures in the second space, got " + summary.Findings()[1].Details)
+	}
+
+	if ExitCode([]checks.OctopusCheckResult{summary}, checks.Info) != ExitCodeOk {
+		t.Fatal("The instance summary must not fail the run")
+	}
+}
+
+func TestResolveSnapshotSpaces(t *testing.T) {
+	snapshot := &client_wrapper.OctopusSnapshot{Spaces: []client_wrapper.SnapshotSpace{
+		{Id: "Spaces-1", Name: "Default"},
+		{Id: "Spaces-2", Name: "Second"},
+	}}
+
+	allSpaces, err := resolveSnapshotSpaces(snapshot, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(allSpaces) != 2 {
+		t.Fatal("Should have returned every space in the snapshot")
+	}
+
+	namedSpaces, err := resolveSnapshotSpaces(snapshot, "Second,Spaces-1")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(namedSpaces) != 2 ||
+		namedSpaces[0] != (octopusSpace{Id: "Spaces-2", Name: "Second"}) ||
+		namedSpaces[1] != (octopusSpace{Id: "Spaces-1", Name: "Default"}) {
+		t.Fatal("Should have resolved the space IDs and names")
+	}
+
+	if _, err := resolveSnapshotSpaces(snapshot, "Missing"); err == nil {
+		t.Fatal("Should have failed to find a space that is not in the snapshot")
+	}
+}
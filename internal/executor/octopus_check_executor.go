@@ -2,65 +2,120 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/mathext"
-	"github.com/avast/retry-go/v4"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/progress"
+	"github.com/hayageek/threadsafe"
+	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
+	"time"
 )
 
 const ParallelTasks = 15
-const CheckParallelTasks = 2
+
+// RequestBudget reports whether the checks have used up all the API requests they are allowed to make.
+type RequestBudget interface {
+	Exhausted() bool
+}
 
 // OctopusCheckExecutor is responsible for running each lint check and returning the results. It deals with things
-// like retries and error handling.
+// like timeouts and error handling.
 type OctopusCheckExecutor struct {
+	// checkConcurrency is the number of concurrent operations each check can run. The number of requests sent to
+	// Octopus is limited by the HTTP client, so this is typically the maximum number of concurrent requests.
+	checkConcurrency int
+	// requestBudget is used to mark results as partial if the checks ran out of API requests. It may be nil.
+	requestBudget RequestBudget
+	// progressListener is notified as the checks report their progress. It may be nil.
+	progressListener progress.Listener
+	// checkTimeout is the maximum time each check can run for. Zero means checks are only limited by the context
+	// passed to ExecuteChecks.
+	checkTimeout time.Duration
+	// checkTimeouts overrides the checkTimeout for individual checks, keyed by the check ID.
+	checkTimeouts map[string]time.Duration
+}
+
+func NewOctopusCheckExecutor(checkConcurrency int, requestBudget RequestBudget, progressListener progress.Listener, checkTimeout time.Duration, checkTimeouts map[string]time.Duration) OctopusCheckExecutor {
+	return OctopusCheckExecutor{checkConcurrency: checkConcurrency, requestBudget: requestBudget, progressListener: progressListener, checkTimeout: checkTimeout, checkTimeouts: checkTimeouts}
 }
 
-func NewOctopusCheckExecutor() OctopusCheckExecutor {
-	return OctopusCheckExecutor{}
+const budgetExhaustedReason = "the API request budget was exhausted"
+
+// checkOutput captures the values returned by a check running in the background.
+type checkOutput struct {
+	result checks.OctopusCheckResult
+	err    error
 }
 
-// ExecuteChecks executes each check and collects the results.
-func (o OctopusCheckExecutor) ExecuteChecks(checkCollection []checks.OctopusCheck, handleError func(checks.OctopusCheck, error) error) ([]checks.OctopusCheckResult, error) {
+// ExecuteChecks executes each check and collects the results. Checks that do not complete before their timeout, or
+// before the context is done, are reported with the TimedOut category. Checks that return an error are passed to
+// handleError, and are reported with the GeneralError category unless handleError returns an error to stop the run.
+// Stopping the run cancels the checks that are still running.
+// The progress reported by each check is combined into the overall progress sent to the progress listener.
+func (o OctopusCheckExecutor) ExecuteChecks(ctx context.Context, checkCollection []checks.OctopusCheck, handleError func(checks.OctopusCheck, error) error) ([]checks.OctopusCheckResult, error) {
 	if checkCollection == nil || len(checkCollection) == 0 {
 		return []checks.OctopusCheckResult{}, nil
 	}
 
-	checkResults := []checks.OctopusCheckResult{}
+	checkResults := threadsafe.NewSlice[checks.OctopusCheckResult]()
+
+	tracker := progress.NewTracker(lo.Map(checkCollection, func(item checks.OctopusCheck, index int) string {
+		return item.Id()
+	}), o.progressListener)
+	defer tracker.Finish()
 
-	g, _ := errgroup.WithContext(context.Background())
+	// A check that fails the run cancels the checks that are still running
+	g, gctx := errgroup.WithContext(ctx)
 	g.SetLimit(mathext.TopLevelConcurrency(ParallelTasks, len(checkCollection)))
 
 	for _, c := range checkCollection {
 		c := c
 		g.Go(func() error {
-			err := retry.Do(
-				func() error {
-					result, err := c.Execute(mathext.InternalLevelConcurrency(ParallelTasks, CheckParallelTasks, len(checkCollection)))
-
-					if err != nil {
-						checkResults = append(
-							checkResults,
-							checks.NewOctopusCheckResultImpl(
-								"The check failed to run: "+err.Error(),
-								c.Id(),
-								"",
-								checks.Error,
-								checks.GeneralError))
-					}
-
-					if result != nil {
-						checkResults = append(checkResults, result)
-					}
-
-					return nil
-				}, retry.Attempts(3))
+			checkProgress := tracker.Check(c.Id())
+			defer checkProgress.Done()
+
+			checkCtx, cancel := o.checkContext(checks.ContextWithProgress(gctx, checkProgress), c.Id())
+			defer cancel()
+
+			// Transient errors are retried for each API request, so a check that returns an error is not retried
+			result, err := o.executeCheck(checkCtx, c, mathext.MaxInt(1, o.checkConcurrency))
+
+			if err != nil && checkCtx.Err() != nil {
+				checkResults.Append(o.timeoutResult(ctx, c))
+				return nil
+			}
+
+			if client_wrapper.IsRequestBudgetError(err) {
+				checkResults.Append(o.budgetExhaustedResult(c))
+				return nil
+			}
 
 			if err != nil {
-				err := handleError(c, err)
-				if err != nil {
+				if err := handleError(c, err); err != nil {
 					return err
 				}
+
+				checkResults.Append(
+					checks.NewOctopusCheckResultImpl(
+						"The check failed to run: "+err.Error(),
+						c.Id(),
+						checks.WikiLink(c.Id()),
+						checks.Error,
+						checks.GeneralError))
+
+				// Any result returned with the error is discarded, so the check is only reported once
+				return nil
+			}
+
+			if result != nil {
+				if o.requestBudget != nil && o.requestBudget.Exhausted() {
+					// Requests that failed once the budget was used up were skipped, so the findings may be incomplete
+					result = checks.NewOctopusCheckPartialResult(result, budgetExhaustedReason)
+				}
+
+				checkResults.Append(result)
 			}
 
 			return nil
@@ -71,5 +126,79 @@ func (o OctopusCheckExecutor) ExecuteChecks(checkCollection []checks.OctopusChec
 		return nil, err
 	}
 
-	return checkResults, nil
+	return checkResults.Values(), nil
+}
+
+// checkContext returns the context used to run a single check, applying any timeout configured for the check.
+func (o OctopusCheckExecutor) checkContext(ctx context.Context, checkId string) (context.Context, context.CancelFunc) {
+	timeout := o.timeout(checkId)
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (o OctopusCheckExecutor) timeout(checkId string) time.Duration {
+	if timeout, ok := o.checkTimeouts[checkId]; ok {
+		return timeout
+	}
+
+	return o.checkTimeout
+}
+
+// executeCheck runs the check, returning as soon as the context is done. The Octopus client does not support
+// cancelling requests that are in flight, so a check that times out finishes in the background and its result is
+// discarded.
+func (o OctopusCheckExecutor) executeCheck(ctx context.Context, check checks.OctopusCheck, concurrency int) (checks.OctopusCheckResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan checkOutput, 1)
+
+	go func() {
+		result, err := check.Execute(ctx, concurrency)
+		done <- checkOutput{result: result, err: err}
+	}()
+
+	select {
+	case output := <-done:
+		return output.result, output.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// timeoutResult builds the result reported for a check that did not complete in time. The parent context is used
+// to distinguish between the global timeout and the timeout of the individual check.
+func (o OctopusCheckExecutor) timeoutResult(ctx context.Context, check checks.OctopusCheck) checks.OctopusCheckResult {
+	description := "The check did not complete within " + o.timeout(check.Id()).String()
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		description = "The check did not complete before the global timeout"
+	} else if errors.Is(ctx.Err(), context.Canceled) {
+		description = "The check was cancelled"
+	}
+
+	return checks.NewOctopusCheckResultImpl(
+		description,
+		check.Id(),
+		checks.WikiLink(check.Id()),
+		checks.Error,
+		checks.TimedOut)
+}
+
+// budgetExhaustedResult builds the result reported for a check that could not read the resources it needed because
+// the API request budget was used up.
+func (o OctopusCheckExecutor) budgetExhaustedResult(check checks.OctopusCheck) checks.OctopusCheckResult {
+	return checks.NewOctopusCheckPartialResult(
+		checks.NewOctopusCheckResultImpl(
+			"The check could not read the resources it needed",
+			check.Id(),
+			checks.WikiLink(check.Id()),
+			checks.Info,
+			checks.BudgetExhausted),
+		budgetExhaustedReason)
 }
@@ -1,14 +1,21 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/progress"
+	"github.com/samber/lo"
+	"sync"
 	"testing"
+	"time"
 )
 
 type alwaysFailCheck struct {
 }
 
-func (o alwaysFailCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o alwaysFailCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	return checks.NewOctopusCheckResultImpl("This check always fails", o.Id(), "", checks.Error, ""), nil
 }
 
@@ -16,10 +23,35 @@ func (o alwaysFailCheck) Id() string {
 	return "OctoRecAlwaysFail"
 }
 
+// progressCheck reports that it completed half of its work.
+type progressCheck struct {
+}
+
+func (o progressCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	checks.Progress(ctx).AddTotal(2)
+	checks.Progress(ctx).Complete(1)
+	return checks.NewOctopusCheckResultImpl("This check passed ok", o.Id(), "", checks.Ok, ""), nil
+}
+
+func (o progressCheck) Id() string {
+	return "OctoRecProgress"
+}
+
+type recordingListener struct {
+	mutex   sync.Mutex
+	updates []progress.Update
+}
+
+func (r *recordingListener) Update(update progress.Update) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.updates = append(r.updates, update)
+}
+
 type alwaysPassCheck struct {
 }
 
-func (o alwaysPassCheck) Execute(concurrency int) (checks.OctopusCheckResult, error) {
+func (o alwaysPassCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
 	return checks.NewOctopusCheckResultImpl("This check passed ok", o.Id(), "", checks.Ok, ""), nil
 }
 
@@ -27,8 +59,21 @@ func (o alwaysPassCheck) Id() string {
 	return "OctoRecAlwaysPass"
 }
 
+// slowCheck blocks until the context is done, like a check waiting on a slow API request.
+type slowCheck struct {
+}
+
+func (o slowCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (o slowCheck) Id() string {
+	return "OctoRecSlow"
+}
+
 func TestNoChecks(t *testing.T) {
-	results, err := OctopusCheckExecutor{}.ExecuteChecks(nil, func(check checks.OctopusCheck, err error) error {
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), nil, func(check checks.OctopusCheck, err error) error {
 		return nil
 	})
 
@@ -42,7 +87,7 @@ func TestNoChecks(t *testing.T) {
 }
 
 func TestFailChecks(t *testing.T) {
-	results, err := OctopusCheckExecutor{}.ExecuteChecks([]checks.OctopusCheck{alwaysFailCheck{}}, func(check checks.OctopusCheck, err error) error {
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{alwaysFailCheck{}}, func(check checks.OctopusCheck, err error) error {
 		return nil
 	})
 
@@ -56,7 +101,187 @@ func TestFailChecks(t *testing.T) {
 }
 
 func TestFailAndPassChecks(t *testing.T) {
-	results, err := OctopusCheckExecutor{}.ExecuteChecks([]checks.OctopusCheck{alwaysFailCheck{}, alwaysPassCheck{}}, func(check checks.OctopusCheck, err error) error {
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{alwaysFailCheck{}, alwaysPassCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) != 2 {
+		t.Fatal("Should have returned 2 results")
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	results, err := NewOctopusCheckExecutor(1, nil, nil, 10*time.Millisecond, nil).ExecuteChecks(context.Background(), []checks.OctopusCheck{slowCheck{}, alwaysPassCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) != 2 {
+		t.Fatal("Should have returned 2 results")
+	}
+
+	for _, result := range results {
+		if result.Code() == "OctoRecSlow" && result.Category() != checks.TimedOut {
+			t.Fatal("The slow check should have timed out")
+		}
+
+		if result.Code() == "OctoRecAlwaysPass" && result.Category() == checks.TimedOut {
+			t.Fatal("The fast check should not have timed out")
+		}
+	}
+}
+
+func TestCheckTimeoutOverride(t *testing.T) {
+	executor := NewOctopusCheckExecutor(1, nil, nil, 0, map[string]time.Duration{"OctoRecSlow": 10 * time.Millisecond})
+	results, err := executor.ExecuteChecks(context.Background(), []checks.OctopusCheck{slowCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) != 1 || results[0].Category() != checks.TimedOut || results[0].Severity() != checks.Error {
+		t.Fatal("The slow check should have timed out")
+	}
+}
+
+func TestGlobalTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(ctx, []checks.OctopusCheck{slowCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) != 1 || results[0].Category() != checks.TimedOut {
+		t.Fatal("The slow check should have timed out")
+	}
+
+	if results[0].Description() != "The check did not complete before the global timeout" {
+		t.Fatal("The result should have reported the global timeout")
+	}
+}
+
+// budgetCheck fails like a check that made a request after the request budget was used up.
+type budgetCheck struct {
+}
+
+func (o budgetCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	return nil, client_wrapper.ErrRequestBudgetExhausted
+}
+
+func (o budgetCheck) Id() string {
+	return "OctoRecBudget"
+}
+
+type exhaustedBudget struct {
+}
+
+func (o exhaustedBudget) Exhausted() bool {
+	return true
+}
+
+type errorCheck struct {
+}
+
+func (o errorCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	return nil, errors.New("the check is broken")
+}
+
+func (o errorCheck) Id() string {
+	return "OctoRecError"
+}
+
+func TestErrorChecks(t *testing.T) {
+	handled := false
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{errorCheck{}}, func(check checks.OctopusCheck, err error) error {
+		handled = true
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !handled {
+		t.Fatal("Should have passed the error to the error handler")
+	}
+
+	if len(results) != 1 || results[0].Category() != checks.GeneralError {
+		t.Fatal("Should have returned a general error result")
+	}
+}
+
+func TestErrorChecksStopRun(t *testing.T) {
+	_, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{errorCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return err
+	})
+
+	if err == nil {
+		t.Fatal("Should have returned the error from the error handler")
+	}
+}
+
+func TestErrorChecksCancelRunningChecks(t *testing.T) {
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{slowCheck{}, errorCheck{}}, func(check checks.OctopusCheck, err error) error {
+			return err
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Should have returned the error from the error handler")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Should have cancelled the checks that were still running")
+	}
+}
+
+// partialErrorCheck returns a result along with an error.
+type partialErrorCheck struct {
+}
+
+func (o partialErrorCheck) Execute(ctx context.Context, concurrency int) (checks.OctopusCheckResult, error) {
+	return checks.NewOctopusCheckResultImpl("This check found an issue", o.Id(), "", checks.Warning, ""), errors.New("the check is broken")
+}
+
+func (o partialErrorCheck) Id() string {
+	return "OctoRecPartialError"
+}
+
+func TestErrorChecksWithResult(t *testing.T) {
+	results, err := OctopusCheckExecutor{}.ExecuteChecks(context.Background(), []checks.OctopusCheck{partialErrorCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if len(results) != 1 || results[0].Category() != checks.GeneralError {
+		t.Fatal("Should have only returned the general error result")
+	}
+}
+
+func TestRequestBudgetExhausted(t *testing.T) {
+	results, err := NewOctopusCheckExecutor(1, exhaustedBudget{}, nil, 0, nil).ExecuteChecks(context.Background(), []checks.OctopusCheck{budgetCheck{}, alwaysFailCheck{}}, func(check checks.OctopusCheck, err error) error {
 		return nil
 	})
 
@@ -67,4 +292,41 @@ func TestFailAndPassChecks(t *testing.T) {
 	if len(results) != 2 {
 		t.Fatal("Should have returned 2 results")
 	}
+
+	for _, result := range results {
+		if !result.Partial() {
+			t.Fatal("Results should be partial once the budget is exhausted")
+		}
+
+		if result.Code() == "OctoRecBudget" && result.Category() != checks.BudgetExhausted {
+			t.Fatal("The check that ran out of requests should have been reported")
+		}
+
+		if result.Code() == "OctoRecAlwaysFail" && result.Severity() != checks.Error {
+			t.Fatal("The partial result should have kept the severity")
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	listener := recordingListener{}
+
+	_, err := NewOctopusCheckExecutor(1, nil, &listener, 0, nil).ExecuteChecks(context.Background(), []checks.OctopusCheck{progressCheck{}, alwaysPassCheck{}}, func(check checks.OctopusCheck, err error) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !lo.ContainsBy(listener.updates, func(item progress.Update) bool {
+		return item.Check == "OctoRecProgress" && item.ChecksTotal == 2 && item.Percent > 0 && item.Percent < 100
+	}) {
+		t.Fatal("The progress reported by the check should have been sent to the listener")
+	}
+
+	last := listener.updates[len(listener.updates)-1]
+	if !last.Finished || last.ChecksCompleted != 2 || last.Percent != 100 {
+		t.Fatal("The last update should show all checks are complete")
+	}
 }
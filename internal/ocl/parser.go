@@ -0,0 +1,644 @@
+package ocl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Block is a block in an OCL file, like a step, action or variable. The file itself is parsed as a block with an
+// empty type.
+type Block struct {
+	Type       string
+	Labels     []string
+	Attributes []*Attribute
+	Blocks     []*Block
+	// Line is the line the block starts on
+	Line int
+}
+
+// Attribute is a name and value pair in a block. Values are a string, bool, []any, map[string]any, or nil.
+// Numbers are returned as strings, as that is how Octopus stores properties.
+type Attribute struct {
+	Name  string
+	Value any
+	Line  int
+}
+
+// SyntaxError is returned when an OCL file can not be parsed.
+type SyntaxError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.File + ":" + fmt.Sprint(e.Line) + ": " + e.Message
+}
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	tokenType tokenType
+	value     string
+	line      int
+}
+
+// Parse reads the blocks and attributes in an OCL file. The file name is only used in error messages.
+//
+// OCL is a subset of HCL. Blocks, attributes, strings, heredocs, numbers, bools, lists and maps are supported.
+// Expressions and function calls are not used by Octopus, and are reported as syntax errors.
+func Parse(file string, content []byte) (*Block, error) {
+	parser := &parser{lexer: &lexer{file: file, content: string(content), line: 1}}
+
+	if err := parser.next(); err != nil {
+		return nil, err
+	}
+
+	body := &Block{Line: 1}
+	if err := parser.parseBody(body, false); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+type parser struct {
+	lexer   *lexer
+	current token
+}
+
+func (p *parser) next() error {
+	token, err := p.lexer.next()
+
+	if err != nil {
+		return err
+	}
+
+	p.current = token
+	return nil
+}
+
+func (p *parser) errorf(line int, format string, args ...any) error {
+	return &SyntaxError{File: p.lexer.file, Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isPunct(value string) bool {
+	return p.current.tokenType == tokenPunct && p.current.value == value
+}
+
+// parseBody reads the attributes and blocks until the closing brace, or the end of the file for the top level body.
+func (p *parser) parseBody(block *Block, nested bool) error {
+	for {
+		if p.current.tokenType == tokenEOF {
+			if nested {
+				return p.errorf(p.current.line, "the block that starts on line %d is not closed", block.Line)
+			}
+			return nil
+		}
+
+		if p.isPunct("}") {
+			if !nested {
+				return p.errorf(p.current.line, "unexpected \"}\"")
+			}
+			return p.next()
+		}
+
+		if p.current.tokenType != tokenIdent {
+			return p.errorf(p.current.line, "expected an attribute or block name, found %q", p.current.value)
+		}
+
+		name := p.current.value
+		line := p.current.line
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if p.isPunct("=") {
+			if err := p.next(); err != nil {
+				return err
+			}
+
+			value, err := p.parseValue()
+
+			if err != nil {
+				return err
+			}
+
+			block.Attributes = append(block.Attributes, &Attribute{Name: name, Value: value, Line: line})
+			continue
+		}
+
+		child := &Block{Type: name, Line: line}
+
+		for p.current.tokenType == tokenString || p.current.tokenType == tokenIdent {
+			child.Labels = append(child.Labels, p.current.value)
+
+			if err := p.next(); err != nil {
+				return err
+			}
+		}
+
+		if !p.isPunct("{") {
+			return p.errorf(p.current.line, "expected \"=\" or \"{\" after %q", name)
+		}
+
+		if err := p.next(); err != nil {
+			return err
+		}
+
+		if err := p.parseBody(child, true); err != nil {
+			return err
+		}
+
+		block.Blocks = append(block.Blocks, child)
+	}
+}
+
+func (p *parser) parseValue() (any, error) {
+	current := p.current
+
+	switch {
+	case current.tokenType == tokenString:
+		return current.value, p.next()
+	case current.tokenType == tokenIdent:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		if p.isPunct("(") {
+			return nil, p.errorf(current.line, "function calls are not supported")
+		}
+
+		switch current.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+
+		return current.value, nil
+	case p.isPunct("["):
+		return p.parseList()
+	case p.isPunct("{"):
+		return p.parseMap()
+	case current.tokenType == tokenEOF:
+		return nil, p.errorf(current.line, "expected a value, found the end of the file")
+	}
+
+	return nil, p.errorf(current.line, "expected a value, found %q", current.value)
+}
+
+func (p *parser) parseList() (any, error) {
+	start := p.current.line
+	list := []any{}
+
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	for !p.isPunct("]") {
+		if p.current.tokenType == tokenEOF {
+			return nil, p.errorf(p.current.line, "the list that starts on line %d is not closed", start)
+		}
+
+		value, err := p.parseValue()
+
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, value)
+
+		if p.isPunct(",") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		} else if !p.isPunct("]") {
+			return nil, p.errorf(p.current.line, "expected \",\" or \"]\", found %q", p.current.value)
+		}
+	}
+
+	return list, p.next()
+}
+
+func (p *parser) parseMap() (any, error) {
+	start := p.current.line
+	result := map[string]any{}
+
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	for !p.isPunct("}") {
+		if p.current.tokenType == tokenEOF {
+			return nil, p.errorf(p.current.line, "the map that starts on line %d is not closed", start)
+		}
+
+		if p.current.tokenType != tokenIdent && p.current.tokenType != tokenString {
+			return nil, p.errorf(p.current.line, "expected a map key, found %q", p.current.value)
+		}
+
+		key := p.current.value
+
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		if !p.isPunct("=") && !p.isPunct(":") {
+			return nil, p.errorf(p.current.line, "expected \"=\" after the map key %q", key)
+		}
+
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue()
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[key] = value
+
+		if p.isPunct(",") {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, p.next()
+}
+
+type lexer struct {
+	file    string
+	content string
+	offset  int
+	line    int
+}
+
+func (l *lexer) errorf(format string, args ...any) error {
+	return &SyntaxError{File: l.file, Line: l.line, Message: fmt.Sprintf(format, args...)}
+}
+
+func (l *lexer) peek(offset int) byte {
+	if l.offset+offset >= len(l.content) {
+		return 0
+	}
+	return l.content[l.offset+offset]
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipWhitespace(); err != nil {
+		return token{}, err
+	}
+
+	if l.offset >= len(l.content) {
+		return token{tokenType: tokenEOF, line: l.line}, nil
+	}
+
+	line := l.line
+	c := l.content[l.offset]
+
+	switch {
+	case c == '"':
+		value, err := l.readString()
+		return token{tokenType: tokenString, value: value, line: line}, err
+	case c == '<' && l.peek(1) == '<':
+		value, err := l.readHeredoc()
+		return token{tokenType: tokenString, value: value, line: line}, err
+	case strings.ContainsRune("{}[]=,:()", rune(c)):
+		l.offset++
+		return token{tokenType: tokenPunct, value: string(c), line: line}, nil
+	}
+
+	start := l.offset
+	for l.offset < len(l.content) {
+		r, size := utf8.DecodeRuneInString(l.content[l.offset:])
+		if !isIdentRune(r) {
+			break
+		}
+		l.offset += size
+	}
+
+	if l.offset == start {
+		r, _ := utf8.DecodeRuneInString(l.content[l.offset:])
+		return token{}, l.errorf("unexpected character %q", r)
+	}
+
+	return token{tokenType: tokenIdent, value: l.content[start:l.offset], line: line}, nil
+}
+
+// isIdentRune returns true for the characters in names and numbers. Property names like Octopus.Action.Script.Syntax
+// are not quoted, so dots are part of the name.
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+func (l *lexer) skipWhitespace() error {
+	for l.offset < len(l.content) {
+		c := l.content[l.offset]
+
+		switch {
+		case c == '\n':
+			l.line++
+			l.offset++
+		case c == ' ' || c == '\t' || c == '\r':
+			l.offset++
+		case c == '#' || (c == '/' && l.peek(1) == '/'):
+			for l.offset < len(l.content) && l.content[l.offset] != '\n' {
+				l.offset++
+			}
+		case c == '/' && l.peek(1) == '*':
+			end := strings.Index(l.content[l.offset+2:], "*/")
+			if end == -1 {
+				return l.errorf("the comment is not closed")
+			}
+			comment := l.content[l.offset : l.offset+2+end+2]
+			l.line += strings.Count(comment, "\n")
+			l.offset += len(comment)
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (l *lexer) readString() (string, error) {
+	var value strings.Builder
+	l.offset++
+
+	for {
+		if l.offset >= len(l.content) || l.content[l.offset] == '\n' {
+			return "", l.errorf("the string is not closed")
+		}
+
+		c := l.content[l.offset]
+
+		switch {
+		case c == '"':
+			l.offset++
+			return value.String(), nil
+		case c == '\\':
+			escaped, err := l.readEscape()
+			if err != nil {
+				return "", err
+			}
+			value.WriteString(escaped)
+		case (c == '$' || c == '%') && l.peek(1) == c && l.peek(2) == '{':
+			// Template sequences are escaped by doubling the leading character
+			value.WriteString(string(c) + "{")
+			l.offset += 3
+		default:
+			value.WriteByte(c)
+			l.offset++
+		}
+	}
+}
+
+func (l *lexer) readEscape() (string, error) {
+	escape := l.peek(1)
+	l.offset += 2
+
+	switch escape {
+	case 'n':
+		return "\n", nil
+	case 'r':
+		return "\r", nil
+	case 't':
+		return "\t", nil
+	case '"':
+		return "\"", nil
+	case '\\':
+		return "\\", nil
+	case 'u', 'U':
+		length := 4
+		if escape == 'U' {
+			length = 8
+		}
+
+		if l.offset+length > len(l.content) {
+			return "", l.errorf("the unicode escape sequence is not complete")
+		}
+
+		code, err := strconv.ParseUint(l.content[l.offset:l.offset+length], 16, 32)
+
+		if err != nil {
+			return "", l.errorf("the unicode escape sequence is not valid")
+		}
+
+		l.offset += length
+		return string(rune(code)), nil
+	}
+
+	return "", l.errorf("the escape sequence \\%c is not supported", escape)
+}
+
+// readHeredoc reads a multi-line string like <<EOT or <<-EOT. The indented form removes the leading whitespace shared
+// by all the lines.
+func (l *lexer) readHeredoc() (string, error) {
+	l.offset += 2
+	indented := false
+
+	if l.peek(0) == '-' {
+		indented = true
+		l.offset++
+	}
+
+	lineEnd := strings.IndexByte(l.content[l.offset:], '\n')
+	if lineEnd == -1 {
+		return "", l.errorf("the heredoc is not closed")
+	}
+
+	marker := strings.TrimSpace(strings.TrimSuffix(l.content[l.offset:l.offset+lineEnd], "\r"))
+	if marker == "" {
+		return "", l.errorf("the heredoc does not have a marker")
+	}
+
+	l.offset += lineEnd + 1
+	l.line++
+
+	lines := []string{}
+	for {
+		if l.offset >= len(l.content) {
+			return "", l.errorf("the heredoc is not closed by %s", marker)
+		}
+
+		lineEnd := strings.IndexByte(l.content[l.offset:], '\n')
+		var line string
+		if lineEnd == -1 {
+			line = l.content[l.offset:]
+			l.offset = len(l.content)
+		} else {
+			line = l.content[l.offset : l.offset+lineEnd]
+			l.offset += lineEnd + 1
+			l.line++
+		}
+
+		line = strings.TrimSuffix(line, "\r")
+
+		if strings.TrimSpace(line) == marker {
+			// The closing marker is not followed by a newline, so don't count it twice
+			if lineEnd != -1 {
+				l.offset--
+				l.line--
+			}
+			break
+		}
+
+		lines = append(lines, line)
+	}
+
+	if indented {
+		lines = removeIndent(lines)
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// removeIndent removes the leading whitespace shared by all the lines that are not blank.
+func removeIndent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent == -1 || lineIndent < indent {
+			indent = lineIndent
+		}
+	}
+
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			trimmed[i] = line[indent:]
+		} else {
+			trimmed[i] = strings.TrimLeft(line, " \t")
+		}
+	}
+
+	return trimmed
+}
+
+// Attribute returns the attribute with the name, or nil if the block does not have the attribute.
+func (b *Block) Attribute(name string) *Attribute {
+	for _, attribute := range b.Attributes {
+		if attribute.Name == name {
+			return attribute
+		}
+	}
+	return nil
+}
+
+// Children returns the nested blocks of the type.
+func (b *Block) Children(blockType string) []*Block {
+	children := []*Block{}
+	for _, child := range b.Blocks {
+		if child.Type == blockType {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Label returns the first label of the block, or an empty string if the block has no labels.
+func (b *Block) Label() string {
+	if len(b.Labels) == 0 {
+		return ""
+	}
+	return b.Labels[0]
+}
+
+// String returns the value of an attribute as a string. Missing attributes and values that are not scalars are
+// returned as an empty string.
+func (b *Block) String(name string) string {
+	attribute := b.Attribute(name)
+	if attribute == nil {
+		return ""
+	}
+	return scalarString(attribute.Value)
+}
+
+// Bool returns the value of a boolean attribute.
+func (b *Block) Bool(name string) bool {
+	attribute := b.Attribute(name)
+	if attribute == nil {
+		return false
+	}
+
+	switch value := attribute.Value.(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(value, "true")
+	}
+
+	return false
+}
+
+// StringList returns the value of a list attribute. A single value is returned as a list with one item.
+func (b *Block) StringList(name string) []string {
+	attribute := b.Attribute(name)
+	if attribute == nil || attribute.Value == nil {
+		return nil
+	}
+
+	list, ok := attribute.Value.([]any)
+	if !ok {
+		return []string{scalarString(attribute.Value)}
+	}
+
+	values := []string{}
+	for _, item := range list {
+		values = append(values, scalarString(item))
+	}
+	return values
+}
+
+// StringMap returns the value of a map attribute, like the properties of a step.
+func (b *Block) StringMap(name string) map[string]string {
+	values := map[string]string{}
+
+	attribute := b.Attribute(name)
+	if attribute == nil {
+		return values
+	}
+
+	if mapValue, ok := attribute.Value.(map[string]any); ok {
+		for key, value := range mapValue {
+			values[key] = scalarString(value)
+		}
+	}
+
+	return values
+}
+
+func scalarString(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package ocl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseBlocks(t *testing.T) {
+	body, err := Parse("deployment_process.ocl", []byte(`
+# A comment
+step "run-a-script" {
+    name = "Run a Script"
+    properties = {
+        Octopus.Action.TargetRoles = "web"
+    }
+
+    action {
+        action_type = "Octopus.Script"
+        is_required = true
+        environments = ["production", "staging"]
+        properties = {
+            Octopus.Action.Script.ScriptBody = <<-EOT
+                Write-Host "Hello"
+                Write-Host "#{Name}"
+                EOT
+        }
+    }
+}
+`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := body.Children("step")
+
+	if len(steps) != 1 || steps[0].Label() != "run-a-script" || steps[0].Line != 3 {
+		t.Fatalf("the step block was not parsed, got %+v", steps)
+	}
+
+	if steps[0].String("name") != "Run a Script" {
+		t.Fatal("the step name should have been read")
+	}
+
+	if steps[0].StringMap("properties")["Octopus.Action.TargetRoles"] != "web" {
+		t.Fatal("map keys with dots should have been read")
+	}
+
+	actions := steps[0].Children("action")
+
+	if len(actions) != 1 || actions[0].Label() != "" || actions[0].Line != 9 {
+		t.Fatalf("the action block was not parsed, got %+v", actions)
+	}
+
+	if !actions[0].Bool("is_required") {
+		t.Fatal("the boolean attribute should have been read")
+	}
+
+	environments := actions[0].StringList("environments")
+
+	if len(environments) != 2 || environments[0] != "production" || environments[1] != "staging" {
+		t.Fatalf("the list should have been read, got %v", environments)
+	}
+
+	script := actions[0].StringMap("properties")["Octopus.Action.Script.ScriptBody"]
+
+	if script != "Write-Host \"Hello\"\nWrite-Host \"#{Name}\"\n" {
+		t.Fatalf("the heredoc should have had its indent removed, got %q", script)
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	_, err := Parse("variables.ocl", []byte(`
+variable "Name" {
+    value "test" {
+        environment = ["production"
+    }
+}
+`))
+
+	var syntaxError *SyntaxError
+
+	if !errors.As(err, &syntaxError) {
+		t.Fatalf("a syntax error should have been returned, got %v", err)
+	}
+
+	if syntaxError.File != "variables.ocl" || syntaxError.Line != 5 {
+		t.Fatalf("the syntax error should have reported the position, got %v", syntaxError)
+	}
+}
@@ -0,0 +1,317 @@
+package ocl
+
+import (
+	"errors"
+	"fmt"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/core"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/deployments"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/packages"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/runbooks"
+	"github.com/OctopusDeploy/go-octopusdeploy/v2/pkg/variables"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const octopusDir = ".octopus"
+const deploymentProcessFile = "deployment_process.ocl"
+const variablesFile = "variables.ocl"
+const runbooksDir = "runbooks"
+
+// Position is the location of a resource in an OCL file.
+type Position struct {
+	File string
+	Line int
+}
+
+func (p Position) String() string {
+	return p.File + ":" + fmt.Sprint(p.Line)
+}
+
+// Project holds the resources read from the OCL files of a version controlled project. The steps, actions, and
+// variables are given IDs built from the file they were read from and their slug or name, and the position of each
+// resource is recorded against its ID.
+type Project struct {
+	// Name is the name of the directory holding the project
+	Name              string
+	DeploymentProcess *deployments.DeploymentProcess
+	Variables         variables.VariableSet
+	Runbooks          []*runbooks.Runbook
+	// RunbookProcesses are the runbook processes keyed by the runbook ID
+	RunbookProcesses map[string]*runbooks.RunbookProcess
+
+	positions map[string]Position
+}
+
+// Position returns the location of the resource with the ID.
+func (p *Project) Position(id string) (Position, bool) {
+	position, ok := p.positions[id]
+	return position, ok
+}
+
+// ReadProject reads the OCL files of a version controlled project. The path is the directory holding the
+// deployment_process.ocl and variables.ocl files, or the root of a Git repository with a .octopus directory.
+// Runbooks are read from the runbooks directory, if it exists.
+func ReadProject(path string) (*Project, error) {
+	if _, err := os.Stat(filepath.Join(path, octopusDir)); err == nil {
+		path = filepath.Join(path, octopusDir)
+	}
+
+	absolutePath, err := filepath.Abs(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(absolutePath)
+	if name == octopusDir {
+		name = filepath.Base(filepath.Dir(absolutePath))
+	}
+
+	project := &Project{
+		Name:              name,
+		DeploymentProcess: &deployments.DeploymentProcess{Steps: []*deployments.DeploymentStep{}},
+		Variables:         variables.VariableSet{Variables: []*variables.Variable{}},
+		Runbooks:          []*runbooks.Runbook{},
+		RunbookProcesses:  map[string]*runbooks.RunbookProcess{},
+		positions:         map[string]Position{},
+	}
+
+	found := false
+
+	if body, file, err := readFile(path, deploymentProcessFile); err != nil {
+		return nil, err
+	} else if body != nil {
+		found = true
+		project.DeploymentProcess.ID = deploymentProcessFile
+		project.DeploymentProcess.Steps = project.readSteps(body, file, deploymentProcessFile)
+	}
+
+	if body, file, err := readFile(path, variablesFile); err != nil {
+		return nil, err
+	} else if body != nil {
+		found = true
+		project.Variables.ID = variablesFile
+		project.Variables.Variables = project.readVariables(body, file)
+	}
+
+	runbookFiles, err := filepath.Glob(filepath.Join(path, runbooksDir, "*.ocl"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(runbookFiles)
+
+	for _, runbookFile := range runbookFiles {
+		body, file, err := readFile(path, filepath.Join(runbooksDir, filepath.Base(runbookFile)))
+
+		if err != nil {
+			return nil, err
+		}
+
+		found = true
+		project.readRunbook(body, file, runbooksDir+"/"+filepath.Base(runbookFile))
+	}
+
+	if !found {
+		return nil, errors.New("the directory " + path + " does not contain a " + deploymentProcessFile + " or " + variablesFile + " file")
+	}
+
+	return project, nil
+}
+
+// readFile parses an OCL file, returning a nil block if the file does not exist.
+func readFile(dir string, name string) (*Block, string, error) {
+	file := filepath.Join(dir, name)
+	content, err := os.ReadFile(file)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, file, nil
+		}
+		return nil, file, err
+	}
+
+	body, err := Parse(file, content)
+
+	if err != nil {
+		return nil, file, err
+	}
+
+	return body, file, nil
+}
+
+func (p *Project) record(id string, file string, line int) {
+	p.positions[id] = Position{File: file, Line: line}
+}
+
+// readSteps converts the step blocks in a deployment or runbook process. The idPrefix makes the IDs of steps in
+// different processes unique.
+func (p *Project) readSteps(body *Block, file string, idPrefix string) []*deployments.DeploymentStep {
+	steps := []*deployments.DeploymentStep{}
+
+	for _, stepBlock := range body.Children("step") {
+		slug := stepBlock.Label()
+		name := stepBlock.String("name")
+		if name == "" {
+			name = slug
+		}
+
+		step := deployments.NewDeploymentStep(name)
+		step.ID = idPrefix + "/" + slug
+		step.Properties = propertyValues(stepBlock.StringMap("properties"))
+		step.TargetRoles = stepBlock.StringList("target_roles")
+
+		if condition := stepBlock.String("condition"); condition != "" {
+			step.Condition = deployments.DeploymentStepConditionType(condition)
+		}
+
+		if startTrigger := stepBlock.String("start_trigger"); startTrigger != "" {
+			step.StartTrigger = deployments.DeploymentStepStartTrigger(startTrigger)
+		}
+
+		if packageRequirement := stepBlock.String("package_requirement"); packageRequirement != "" {
+			step.PackageRequirement = deployments.DeploymentStepPackageRequirement(packageRequirement)
+		}
+
+		p.record(step.ID, file, stepBlock.Line)
+
+		for _, actionBlock := range stepBlock.Children("action") {
+			step.Actions = append(step.Actions, p.readAction(actionBlock, file, step))
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
+// readAction converts an action block. A step with a single action has an unlabeled action block that takes the
+// name and position of the step, while the child steps of a parent step are labeled with their slug and have their
+// own name.
+func (p *Project) readAction(actionBlock *Block, file string, step *deployments.DeploymentStep) *deployments.DeploymentAction {
+	slug := actionBlock.Label()
+	name := actionBlock.String("name")
+	id := step.ID
+
+	if slug != "" {
+		id += "/" + slug
+		p.record(id, file, actionBlock.Line)
+		if name == "" {
+			name = slug
+		}
+	} else if name == "" {
+		name = step.Name
+	}
+
+	action := deployments.NewDeploymentAction(name, actionBlock.String("action_type"))
+	action.ID = id
+	action.Slug = slug
+	action.Channels = actionBlock.StringList("channels")
+	action.Condition = actionBlock.String("condition")
+	action.Environments = actionBlock.StringList("environments")
+	action.ExcludedEnvironments = actionBlock.StringList("excluded_environments")
+	action.IsDisabled = actionBlock.Bool("is_disabled")
+	action.IsRequired = actionBlock.Bool("is_required")
+	action.Notes = actionBlock.String("notes")
+	action.Properties = propertyValues(actionBlock.StringMap("properties"))
+	action.TenantTags = actionBlock.StringList("tenant_tags")
+	action.WorkerPool = actionBlock.String("worker_pool")
+	action.WorkerPoolVariable = actionBlock.String("worker_pool_variable")
+
+	for _, containerBlock := range actionBlock.Children("container") {
+		action.Container = &deployments.DeploymentActionContainer{
+			FeedID: containerBlock.String("feed"),
+			Image:  containerBlock.String("image"),
+		}
+	}
+
+	for _, packageBlock := range actionBlock.Children("packages") {
+		action.Packages = append(action.Packages, &packages.PackageReference{
+			Name:                packageBlock.Label(),
+			AcquisitionLocation: packageBlock.String("acquisition_location"),
+			FeedID:              packageBlock.String("feed"),
+			PackageID:           packageBlock.String("package_id"),
+			Properties:          packageBlock.StringMap("properties"),
+		})
+	}
+
+	return action
+}
+
+// readVariables converts the variable blocks. Each value of a variable is a separate Octopus variable with its
+// own scope. The value is the block label, or the value attribute for values that span multiple lines.
+func (p *Project) readVariables(body *Block, file string) []*variables.Variable {
+	result := []*variables.Variable{}
+
+	for _, variableBlock := range body.Children("variable") {
+		name := variableBlock.Label()
+
+		for i, valueBlock := range variableBlock.Children("value") {
+			value := valueBlock.Label()
+			if valueAttribute := valueBlock.Attribute("value"); valueAttribute != nil {
+				value = scalarString(valueAttribute.Value)
+			}
+
+			variableType := valueBlock.String("type")
+			if variableType == "" {
+				variableType = "String"
+			}
+
+			variable := variables.NewVariable(name)
+			variable.ID = variablesFile + "/" + name + "/" + fmt.Sprint(i)
+			variable.Value = value
+			variable.Type = variableType
+			variable.Description = valueBlock.String("description")
+			variable.Scope = variables.VariableScope{
+				Environments:  valueBlock.StringList("environment"),
+				Machines:      valueBlock.StringList("machine"),
+				Actions:       valueBlock.StringList("action"),
+				Roles:         valueBlock.StringList("role"),
+				Channels:      valueBlock.StringList("channel"),
+				TenantTags:    valueBlock.StringList("tenant_tag"),
+				ProcessOwners: valueBlock.StringList("process"),
+			}
+			p.record(variable.ID, file, valueBlock.Line)
+
+			result = append(result, variable)
+		}
+	}
+
+	return result
+}
+
+// readRunbook converts a runbook file, which holds the name of the runbook and a process block with its steps.
+func (p *Project) readRunbook(body *Block, file string, idPrefix string) {
+	slug := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	name := body.String("name")
+	if name == "" {
+		name = slug
+	}
+
+	runbook := runbooks.NewRunbook(name, "")
+	runbook.ID = idPrefix
+	runbook.Description = body.String("description")
+	runbook.RunbookProcessID = idPrefix + "/process"
+	p.record(runbook.ID, file, body.Line)
+
+	process := &runbooks.RunbookProcess{RunbookID: runbook.ID, Steps: []*deployments.DeploymentStep{}}
+	process.ID = runbook.RunbookProcessID
+
+	for _, processBlock := range body.Children("process") {
+		process.Steps = append(process.Steps, p.readSteps(processBlock, file, idPrefix)...)
+	}
+
+	p.Runbooks = append(p.Runbooks, runbook)
+	p.RunbookProcesses[runbook.ID] = process
+}
+
+func propertyValues(properties map[string]string) map[string]core.PropertyValue {
+	values := map[string]core.PropertyValue{}
+	for key, value := range properties {
+		values[key] = core.NewPropertyValue(value, false)
+	}
+	return values
+}
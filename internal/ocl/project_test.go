@@ -0,0 +1,125 @@
+package ocl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadProject(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-project")
+
+	writeFile(t, filepath.Join(dir, ".octopus", "deployment_process.ocl"), `step "run-a-script" {
+    name = "Run a Script"
+
+    action {
+        action_type = "Octopus.Script"
+
+        container {
+            feed = "docker-hub"
+            image = "octopusdeploy/worker-tools:latest"
+        }
+    }
+}
+
+step "deploy" {
+    name = "Deploy"
+
+    action "deploy-web" {
+        action_type = "Octopus.TentaclePackage"
+    }
+}
+`)
+
+	writeFile(t, filepath.Join(dir, ".octopus", "variables.ocl"), `variable "Database.Name" {
+    value "db" {
+        environment = ["production"]
+    }
+
+    value {
+        value = "db-dev"
+    }
+}
+`)
+
+	writeFile(t, filepath.Join(dir, ".octopus", "runbooks", "backup.ocl"), `name = "Backup"
+
+process {
+    step "backup" {
+        name = "Backup the database"
+
+        action {
+            action_type = "Octopus.Script"
+        }
+    }
+}
+`)
+
+	project, err := ReadProject(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.Name != "my-project" {
+		t.Fatalf("the project should have been named after the directory, got %s", project.Name)
+	}
+
+	steps := project.DeploymentProcess.Steps
+
+	if len(steps) != 2 || steps[0].ID != "deployment_process.ocl/run-a-script" || steps[0].Name != "Run a Script" {
+		t.Fatalf("the steps were not read, got %+v", steps)
+	}
+
+	if len(steps[0].Actions) != 1 || steps[0].Actions[0].Name != "Run a Script" || steps[0].Actions[0].Container.Image != "octopusdeploy/worker-tools:latest" {
+		t.Fatal("the unlabeled action should have taken the name of the step")
+	}
+
+	if position, ok := project.Position(steps[0].Actions[0].ID); !ok || position.Line != 1 {
+		t.Fatalf("the unlabeled action should have the position of the step, got %v", position)
+	}
+
+	if position, ok := project.Position(steps[1].Actions[0].ID); !ok || position.Line != 17 || filepath.Base(position.File) != "deployment_process.ocl" {
+		t.Fatalf("the labeled action should have its own position, got %v", position)
+	}
+
+	values := project.Variables.Variables
+
+	if len(values) != 2 || values[0].Value != "db" || values[1].Value != "db-dev" || values[0].Scope.Environments[0] != "production" {
+		t.Fatalf("the variables were not read, got %+v", values)
+	}
+
+	if position, ok := project.Position(values[1].ID); !ok || position.Line != 6 {
+		t.Fatalf("the variable value should have its own position, got %v", position)
+	}
+
+	if len(project.Runbooks) != 1 || project.Runbooks[0].Name != "Backup" {
+		t.Fatalf("the runbook was not read, got %+v", project.Runbooks)
+	}
+
+	process := project.RunbookProcesses[project.Runbooks[0].ID]
+
+	if process == nil || len(process.Steps) != 1 || process.Steps[0].Name != "Backup the database" {
+		t.Fatal("the runbook process was not read")
+	}
+
+	if position, ok := project.Position(process.Steps[0].ID); !ok || position.Line != 4 || filepath.Base(position.File) != "backup.ocl" {
+		t.Fatalf("the runbook step should have its position, got %v", position)
+	}
+}
+
+func TestReadProjectWithoutOcl(t *testing.T) {
+	if _, err := ReadProject(t.TempDir()); err == nil {
+		t.Fatal("directories without OCL files should return an error")
+	}
+}
@@ -0,0 +1,233 @@
+package policy
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed packs/*.yaml
+var builtInPacks embed.FS
+
+const builtInPackDir = "packs"
+
+// maxExtendsDepth limits the number of packs that can be chained with extends
+const maxExtendsDepth = 10
+
+// downloadTimeout is the maximum time to download a pack from a URL
+const downloadTimeout = 30 * time.Second
+
+// OctopusPolicyPack is a named and versioned bundle of check settings. Packs are loaded from the built-in packs, files,
+// or URLs, and can extend another pack to build on its settings:
+//
+//	name: my-team
+//	version: 1.0.0
+//	description: The checks run against the projects owned by my team
+//	extends: recommended
+//	checks:
+//	  OctoLintTooManySteps:
+//	    severity: error
+//	    maxSteps: 30
+//
+// The checks section has the same format as the checks section of the octolint.yaml file.
+type OctopusPolicyPack struct {
+	Name        string
+	Version     string
+	Description string
+	// Extends is the built-in name, path, or URL of the pack this pack builds on
+	Extends string
+	// Source is the built-in name, path, or URL the pack was loaded from
+	Source string
+	// Checks maps the lower case check IDs to the settings of each check
+	Checks map[string]any
+}
+
+// BuiltInPackNames returns the names of the packs that are distributed with octolint.
+func BuiltInPackNames() []string {
+	entries, err := builtInPacks.ReadDir(builtInPackDir)
+
+	if err != nil {
+		return []string{}
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// LoadOctopusPolicyPacks loads the packs from each source, along with the packs they extend. The packs are returned
+// in the order their settings are merged, so a pack is listed after the pack it extends, and the packs of later
+// sources are listed after the packs of earlier sources.
+func LoadOctopusPolicyPacks(sources []string) ([]OctopusPolicyPack, error) {
+	packs := []OctopusPolicyPack{}
+
+	for _, source := range sources {
+		chain, err := loadPackChain(source, "", []string{})
+
+		if err != nil {
+			return nil, err
+		}
+
+		packs = append(packs, chain...)
+	}
+
+	return packs, nil
+}
+
+// loadPackChain loads a pack and the packs it extends, with the base pack first. The parent is the source of the pack
+// that extends this one, and is used to resolve relative paths and URLs.
+func loadPackChain(source string, parent string, visited []string) ([]OctopusPolicyPack, error) {
+	resolved := resolveSource(source, parent)
+
+	for _, visitedSource := range visited {
+		if visitedSource == resolved {
+			return nil, errors.New("the policy pack " + resolved + " extends itself")
+		}
+	}
+
+	if len(visited) >= maxExtendsDepth {
+		return nil, errors.New("the policy pack " + resolved + " extends more than " + fmt.Sprint(maxExtendsDepth) + " packs")
+	}
+
+	content, err := readSource(resolved)
+
+	if err != nil {
+		return nil, errors.New("failed to read the policy pack " + resolved + ": " + err.Error())
+	}
+
+	pack, err := ParseOctopusPolicyPack(resolved, content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	zap.L().Debug("Loaded policy pack " + pack.Name + " " + pack.Version + " from " + resolved)
+
+	if strings.TrimSpace(pack.Extends) == "" {
+		return []OctopusPolicyPack{pack}, nil
+	}
+
+	chain, err := loadPackChain(pack.Extends, resolved, append(visited, resolved))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(chain, pack), nil
+}
+
+// ParseOctopusPolicyPack reads a pack from YAML. The source is only used in error messages and to resolve the pack
+// it extends.
+func ParseOctopusPolicyPack(source string, content []byte) (OctopusPolicyPack, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	if err := v.ReadConfig(bytes.NewReader(content)); err != nil {
+		return OctopusPolicyPack{}, errors.New("the policy pack " + source + " is not valid YAML: " + err.Error())
+	}
+
+	pack := OctopusPolicyPack{
+		Name:        v.GetString("name"),
+		Version:     v.GetString("version"),
+		Description: v.GetString("description"),
+		Extends:     v.GetString("extends"),
+		Source:      source,
+		Checks:      v.GetStringMap("checks"),
+	}
+
+	if strings.TrimSpace(pack.Name) == "" {
+		return OctopusPolicyPack{}, errors.New("the policy pack " + source + " does not have a name")
+	}
+
+	return pack, nil
+}
+
+// resolveSource returns the source of a pack relative to the pack that extends it. Built-in names and absolute paths
+// and URLs are returned as is.
+func resolveSource(source string, parent string) string {
+	if isUrl(source) || isBuiltIn(source) || parent == "" || isBuiltIn(parent) {
+		return source
+	}
+
+	if isUrl(parent) {
+		parentUrl, err := url.Parse(parent)
+
+		if err != nil {
+			return source
+		}
+
+		sourceUrl, err := url.Parse(source)
+
+		if err != nil {
+			return source
+		}
+
+		return parentUrl.ResolveReference(sourceUrl).String()
+	}
+
+	if filepath.IsAbs(source) {
+		return source
+	}
+
+	return filepath.Join(filepath.Dir(parent), source)
+}
+
+func readSource(source string) ([]byte, error) {
+	if isUrl(source) {
+		return download(source)
+	}
+
+	if isBuiltIn(source) {
+		return builtInPacks.ReadFile(path.Join(builtInPackDir, source+".yaml"))
+	}
+
+	return os.ReadFile(source)
+}
+
+func download(source string) ([]byte, error) {
+	client := http.Client{Timeout: downloadTimeout}
+	response, err := client.Get(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New("the server returned " + response.Status)
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+func isUrl(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+func isBuiltIn(source string) bool {
+	for _, name := range BuiltInPackNames() {
+		if name == source {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuiltInPacks(t *testing.T) {
+	names := BuiltInPackNames()
+
+	if len(names) != 3 || names[0] != "octopus-samples" || names[1] != "recommended" || names[2] != "strict-security" {
+		t.Fatalf("the built-in packs were not found, got %v", names)
+	}
+
+	packs, err := LoadOctopusPolicyPacks([]string{"strict-security", "octopus-samples"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := []string{}
+	for _, pack := range packs {
+		loaded = append(loaded, pack.Name)
+	}
+
+	if len(loaded) != 4 || loaded[0] != "recommended" || loaded[1] != "strict-security" || loaded[2] != "recommended" || loaded[3] != "octopus-samples" {
+		t.Fatalf("the packs must be listed after the packs they extend, got %v", loaded)
+	}
+
+	if packs[1].Version == "" || packs[1].Description == "" {
+		t.Fatal("the built-in packs must have a version and description")
+	}
+}
+
+func TestPackExtendsRelativePath(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "base.yaml"), []byte("name: base\nchecks:\n  OctoLintTooManySteps:\n    maxSteps: 30\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "team.yaml"), []byte("name: team\nversion: 2.0.0\nextends: base.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	packs, err := LoadOctopusPolicyPacks([]string{filepath.Join(dir, "team.yaml")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(packs) != 2 || packs[0].Name != "base" || packs[1].Name != "team" || packs[1].Version != "2.0.0" {
+		t.Fatalf("the extended pack should have been loaded relative to the pack that extends it, got %+v", packs)
+	}
+
+	if _, ok := packs[0].Checks["octolinttoomanysteps"]; !ok {
+		t.Fatal("the checks section should have been read")
+	}
+}
+
+func TestPackExtendsItself(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "a.yaml"), []byte("name: a\nextends: b.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "b.yaml"), []byte("name: b\nextends: a.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadOctopusPolicyPacks([]string{filepath.Join(dir, "a.yaml")}); err == nil {
+		t.Fatal("packs that extend each other should return an error")
+	}
+}
+
+func TestPackFromUrl(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/policies/team.yaml":
+			w.Write([]byte("name: team\nextends: base.yaml\n"))
+		case "/policies/base.yaml":
+			w.Write([]byte("name: base\nextends: recommended\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	packs, err := LoadOctopusPolicyPacks([]string{server.URL + "/policies/team.yaml"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(packs) != 3 || packs[0].Name != "recommended" || packs[1].Source != server.URL+"/policies/base.yaml" || packs[2].Name != "team" {
+		t.Fatalf("the extended packs should have been resolved relative to the URL, got %+v", packs)
+	}
+
+	if _, err := LoadOctopusPolicyPacks([]string{server.URL + "/missing.yaml"}); err == nil {
+		t.Fatal("packs that can not be downloaded should return an error")
+	}
+}
+
+func TestPackWithoutName(t *testing.T) {
+	if _, err := ParseOctopusPolicyPack("test.yaml", []byte("version: 1.0.0\n")); err == nil {
+		t.Fatal("packs without a name should return an error")
+	}
+}
@@ -0,0 +1,37 @@
+package progress
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/mathext"
+	"io"
+	"strings"
+)
+
+const barWidth = 30
+
+// Bar draws the overall progress as a bar on a single line of a terminal. The line is cleared once the checks have
+// finished, so the bar does not remain in the output.
+type Bar struct {
+	writer io.Writer
+}
+
+func NewBar(writer io.Writer) Bar {
+	return Bar{writer: writer}
+}
+
+func (b Bar) Update(update Update) {
+	if update.Finished {
+		fmt.Fprint(b.writer, "\r"+strings.Repeat(" ", len(b.render(update)))+"\r")
+		return
+	}
+
+	fmt.Fprint(b.writer, "\r"+b.render(update))
+}
+
+func (b Bar) render(update Update) string {
+	filled := mathext.MaxInt(0, mathext.MinInt(barWidth, int(update.Percent/100*barWidth)))
+
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled) + "] " +
+		fmt.Sprintf("%3d%%", int(update.Percent)) + " " +
+		fmt.Sprint(update.ChecksCompleted) + "/" + fmt.Sprint(update.ChecksTotal) + " checks"
+}
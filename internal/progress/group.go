@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"math"
+	"sync"
+)
+
+// Group combines the progress of several runs, like the checks run against each space, into a single stream of
+// updates sent to one listener. Each run contributes equally to the overall progress.
+type Group struct {
+	mutex    sync.Mutex
+	listener Listener
+	updates  []*Update
+}
+
+// NewGroup creates a group of count members, all sending their updates to the listener.
+func NewGroup(listener Listener, count int) *Group {
+	return &Group{listener: listener, updates: make([]*Update, count)}
+}
+
+// Member returns the listener for the run at the index.
+func (g *Group) Member(index int) Listener {
+	return groupMember{group: g, index: index}
+}
+
+func (g *Group) update(index int, update Update) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.updates[index] = &update
+
+	combined := Update{Check: update.Check, Finished: true}
+	percent := 0.0
+
+	for _, memberUpdate := range g.updates {
+		if memberUpdate == nil {
+			combined.Finished = false
+			continue
+		}
+
+		combined.ChecksCompleted += memberUpdate.ChecksCompleted
+		combined.ChecksTotal += memberUpdate.ChecksTotal
+		combined.Finished = combined.Finished && memberUpdate.Finished
+		percent += memberUpdate.Percent
+	}
+
+	if len(g.updates) != 0 {
+		combined.Percent = math.Round(percent/float64(len(g.updates))*10) / 10
+	}
+
+	if g.listener != nil {
+		g.listener.Update(combined)
+	}
+}
+
+type groupMember struct {
+	group *Group
+	index int
+}
+
+func (g groupMember) Update(update Update) {
+	g.group.update(g.index, update)
+}
@@ -0,0 +1,26 @@
+package progress
+
+import "testing"
+
+func TestGroup(t *testing.T) {
+	listener := recordingListener{}
+	group := NewGroup(&listener, 2)
+
+	group.Member(0).Update(Update{Check: "CheckA", ChecksCompleted: 1, ChecksTotal: 2, Percent: 50})
+
+	if listener.last().Percent != 25 || listener.last().ChecksTotal != 2 || listener.last().Finished {
+		t.Fatal("the members that have not reported progress should count as 0% complete")
+	}
+
+	group.Member(1).Update(Update{ChecksCompleted: 3, ChecksTotal: 3, Percent: 100, Finished: true})
+
+	if listener.last().Percent != 75 || listener.last().ChecksCompleted != 4 || listener.last().ChecksTotal != 5 || listener.last().Finished {
+		t.Fatal("the progress should be combined")
+	}
+
+	group.Member(0).Update(Update{ChecksCompleted: 2, ChecksTotal: 2, Percent: 100, Finished: true})
+
+	if !listener.last().Finished || listener.last().Percent != 100 {
+		t.Fatal("the group should finish once every member has finished")
+	}
+}
@@ -0,0 +1,30 @@
+package progress
+
+import (
+	"encoding/json"
+	"go.uber.org/zap"
+	"io"
+)
+
+// JsonLines writes each update as a JSON object on its own line, for tools that wrap octolint and display their own
+// progress.
+type JsonLines struct {
+	writer io.Writer
+}
+
+func NewJsonLines(writer io.Writer) JsonLines {
+	return JsonLines{writer: writer}
+}
+
+func (j JsonLines) Update(update Update) {
+	line, err := json.Marshal(update)
+
+	if err != nil {
+		zap.L().Error("Failed to serialize the progress: " + err.Error())
+		return
+	}
+
+	if _, err := j.writer.Write(append(line, '\n')); err != nil {
+		zap.L().Error("Failed to write the progress: " + err.Error())
+	}
+}
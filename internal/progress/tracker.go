@@ -0,0 +1,190 @@
+package progress
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"math"
+	"sync"
+)
+
+// Update describes the overall progress of the checks.
+type Update struct {
+	// Check is the ID of the check whose progress triggered the update
+	Check string `json:"check,omitempty"`
+	// ChecksCompleted is the number of checks that have finished
+	ChecksCompleted int `json:"checksCompleted"`
+	// ChecksTotal is the number of checks being run
+	ChecksTotal int `json:"checksTotal"`
+	// Percent is the overall percentage of work that is complete
+	Percent float64 `json:"percent"`
+	// Finished is true for the last update, sent once all the checks have finished
+	Finished bool `json:"finished"`
+}
+
+// Listener is notified as the overall progress changes. Updates are delivered one at a time.
+type Listener interface {
+	Update(update Update)
+}
+
+// Tracker aggregates the progress reported by each check into the overall progress. Each check contributes equally
+// to the overall progress, regardless of how many units of work it reports. Listeners are notified when the overall
+// percentage changes by at least one percent, or when a check finishes.
+type Tracker struct {
+	mutex       sync.Mutex
+	listener    Listener
+	checks      []*CheckProgress
+	completed   int
+	lastPercent int
+	finished    bool
+}
+
+// NewTracker creates a tracker for the checks. The listener may be nil, in which case the progress is only logged.
+func NewTracker(checkIds []string, listener Listener) *Tracker {
+	tracker := Tracker{listener: listener, lastPercent: -1}
+
+	for _, id := range checkIds {
+		tracker.checks = append(tracker.checks, &CheckProgress{tracker: &tracker, id: id, lastPercent: -1})
+	}
+
+	return &tracker
+}
+
+// Check returns the progress of the check with the ID, or nil if the tracker was not created with the check.
+func (t *Tracker) Check(id string) *CheckProgress {
+	for _, check := range t.checks {
+		if check.id == id {
+			return check
+		}
+	}
+
+	return nil
+}
+
+// Finish marks all the checks as done and sends the final update.
+func (t *Tracker) Finish() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.finished {
+		return
+	}
+
+	t.finished = true
+	t.completed = len(t.checks)
+
+	for _, check := range t.checks {
+		check.done = true
+	}
+
+	t.notify("", true)
+}
+
+// percent returns the overall percentage of work that is complete. The mutex must be held.
+func (t *Tracker) percent() float64 {
+	if len(t.checks) == 0 {
+		return 100
+	}
+
+	sum := 0.0
+	for _, check := range t.checks {
+		sum += check.fraction()
+	}
+
+	return sum / float64(len(t.checks)) * 100
+}
+
+// notify sends an update to the listener. The mutex must be held.
+func (t *Tracker) notify(checkId string, force bool) {
+	percent := t.percent()
+
+	if !force && int(percent) == t.lastPercent {
+		return
+	}
+
+	t.lastPercent = int(percent)
+
+	if t.listener != nil {
+		t.listener.Update(Update{
+			Check:           checkId,
+			ChecksCompleted: t.completed,
+			ChecksTotal:     len(t.checks),
+			Percent:         math.Round(percent*10) / 10,
+			Finished:        t.finished,
+		})
+	}
+}
+
+// CheckProgress records the progress of a single check. It implements checks.ProgressReporter.
+type CheckProgress struct {
+	tracker     *Tracker
+	id          string
+	total       int
+	completed   int
+	done        bool
+	lastPercent int
+}
+
+func (c *CheckProgress) AddTotal(units int) {
+	c.tracker.mutex.Lock()
+	defer c.tracker.mutex.Unlock()
+
+	if c.done {
+		return
+	}
+
+	c.total += units
+	c.tracker.notify(c.id, false)
+}
+
+func (c *CheckProgress) Complete(units int) {
+	c.tracker.mutex.Lock()
+	defer c.tracker.mutex.Unlock()
+
+	// A check that timed out can keep running in the background, but it no longer contributes to the progress
+	if c.done {
+		return
+	}
+
+	c.completed += units
+	c.log()
+	c.tracker.notify(c.id, false)
+}
+
+// Done marks the check as finished, regardless of how many units of work it completed.
+func (c *CheckProgress) Done() {
+	c.tracker.mutex.Lock()
+	defer c.tracker.mutex.Unlock()
+
+	if c.done {
+		return
+	}
+
+	c.done = true
+	c.tracker.completed++
+	c.tracker.notify(c.id, true)
+}
+
+// fraction returns the fraction of the check that is complete. The mutex must be held.
+func (c *CheckProgress) fraction() float64 {
+	if c.done {
+		return 1
+	}
+
+	if c.total <= 0 {
+		return 0
+	}
+
+	return math.Min(1, float64(c.completed)/float64(c.total))
+}
+
+// log writes the progress of the check to the debug log each time the percentage changes. The mutex must be held.
+func (c *CheckProgress) log() {
+	percent := int(c.fraction() * 100)
+
+	if percent == c.lastPercent {
+		return
+	}
+
+	c.lastPercent = percent
+	zap.L().Debug(c.id + " " + fmt.Sprint(percent) + "% complete")
+}
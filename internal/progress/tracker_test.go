@@ -0,0 +1,117 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type recordingListener struct {
+	updates []Update
+}
+
+func (r *recordingListener) Update(update Update) {
+	r.updates = append(r.updates, update)
+}
+
+func (r *recordingListener) last() Update {
+	return r.updates[len(r.updates)-1]
+}
+
+func TestTracker(t *testing.T) {
+	listener := recordingListener{}
+	tracker := NewTracker([]string{"CheckA", "CheckB"}, &listener)
+
+	checkA := tracker.Check("CheckA")
+	checkA.AddTotal(4)
+	checkA.Complete(2)
+
+	if listener.last().Percent != 25 || listener.last().Check != "CheckA" {
+		t.Fatal("half of one of two checks should be 25% complete")
+	}
+
+	tracker.Check("CheckB").Done()
+
+	if listener.last().Percent != 75 || listener.last().ChecksCompleted != 1 {
+		t.Fatal("a finished check should count as fully complete")
+	}
+
+	tracker.Finish()
+
+	if !listener.last().Finished || listener.last().Percent != 100 || listener.last().ChecksCompleted != 2 {
+		t.Fatal("the last update should be finished")
+	}
+
+	updates := len(listener.updates)
+	checkA.Complete(2)
+	tracker.Finish()
+
+	if len(listener.updates) != updates {
+		t.Fatal("no updates should be sent once the tracker has finished")
+	}
+}
+
+func TestTrackerOnlyNotifiesWhenPercentChanges(t *testing.T) {
+	listener := recordingListener{}
+	tracker := NewTracker([]string{"CheckA"}, &listener)
+
+	check := tracker.Check("CheckA")
+	check.AddTotal(1000)
+
+	for i := 0; i < 1000; i++ {
+		check.Complete(1)
+	}
+
+	// One update for each percent, plus the initial update when the total was added
+	if len(listener.updates) != 101 {
+		t.Fatalf("expected 101 updates, got %d", len(listener.updates))
+	}
+}
+
+func TestTrackerCompletionIsCapped(t *testing.T) {
+	listener := recordingListener{}
+	tracker := NewTracker([]string{"CheckA", "CheckB"}, &listener)
+
+	check := tracker.Check("CheckA")
+	check.AddTotal(1)
+	check.Complete(5)
+
+	if listener.last().Percent != 50 {
+		t.Fatal("a check can not be more than 100% complete")
+	}
+}
+
+func TestJsonLines(t *testing.T) {
+	buffer := bytes.Buffer{}
+	tracker := NewTracker([]string{"CheckA"}, NewJsonLines(&buffer))
+	tracker.Check("CheckA").Done()
+	tracker.Finish()
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+
+	update := Update{}
+	if err := json.Unmarshal([]byte(lines[1]), &update); err != nil {
+		t.Fatal(err)
+	}
+
+	if !update.Finished || update.ChecksTotal != 1 || update.ChecksCompleted != 1 {
+		t.Fatal("the last line should describe the finished checks")
+	}
+}
+
+func TestBar(t *testing.T) {
+	bar := NewBar(nil)
+
+	if bar.render(Update{ChecksCompleted: 1, ChecksTotal: 4, Percent: 50}) != "[===============               ]  50% 1/4 checks" {
+		t.Fatal("the bar should be half full")
+	}
+
+	if bar.render(Update{Percent: 150}) != "["+strings.Repeat("=", barWidth)+"] 150% 0/0 checks" {
+		t.Fatal("the bar should not overflow")
+	}
+}
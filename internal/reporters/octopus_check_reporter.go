@@ -1,8 +1,81 @@
 package reporters
 
-import "github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+import (
+	"errors"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/baseline"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+)
+
+const (
+	PlainFormat    = "plain"
+	JsonFormat     = "json"
+	SarifFormat    = "sarif"
+	JUnitFormat    = "junit"
+	MarkdownFormat = "markdown"
+	HtmlFormat     = "html"
+)
+
+// Formats lists the report formats that can be passed to the -format argument.
+var Formats = []string{PlainFormat, JsonFormat, SarifFormat, JUnitFormat, MarkdownFormat, HtmlFormat}
+
+// fileExtensions maps the report formats to the extension of the file the report is saved to.
+var fileExtensions = map[string]string{
+	PlainFormat:    ".txt",
+	JsonFormat:     ".json",
+	SarifFormat:    ".sarif",
+	JUnitFormat:    ".xml",
+	MarkdownFormat: ".md",
+	HtmlFormat:     ".html",
+}
+
+// DefaultReportFile returns the name of the file a report is saved to when no file name is supplied.
+func DefaultReportFile(format string) string {
+	extension, ok := fileExtensions[format]
+
+	if !ok {
+		extension = fileExtensions[PlainFormat]
+	}
+
+	return "octolint-report" + extension
+}
 
 // OctopusCheckReporter defines the contract used by reporters to print the result of lint checks.
 type OctopusCheckReporter interface {
 	Generate(results []checks.OctopusCheckResult) (string, error)
 }
+
+// resultName returns the name used to identify a result in a report. Results include the name of the space the check
+// was run against, as the same check is reported once for each space.
+func resultName(result checks.OctopusCheckResult) string {
+	if result.Space() == "" {
+		return result.Code()
+	}
+
+	return result.Code() + " (" + result.Space() + ")"
+}
+
+// isReported returns true if the result is included in a report. The findings resolved since the baseline are always
+// reported, as they are informational and would otherwise be hidden by the default minimum severity.
+func isReported(result checks.OctopusCheckResult, minSeverity int) bool {
+	return result.Severity() >= minSeverity || result.Code() == baseline.OctoLintResolvedFindings
+}
+
+// NewOctopusCheckReporter returns the reporter that matches the supplied format.
+func NewOctopusCheckReporter(format string, minSeverity int) (OctopusCheckReporter, error) {
+	switch format {
+	case PlainFormat, "":
+		return NewOctopusPlainCheckReporter(minSeverity), nil
+	case JsonFormat:
+		return NewOctopusJsonCheckReporter(minSeverity), nil
+	case SarifFormat:
+		return NewOctopusSarifCheckReporter(minSeverity), nil
+	case JUnitFormat:
+		return NewOctopusJUnitCheckReporter(minSeverity), nil
+	case MarkdownFormat:
+		return NewOctopusMarkdownCheckReporter(minSeverity), nil
+	case HtmlFormat:
+		return NewOctopusHtmlCheckReporter(minSeverity), nil
+	}
+
+	return nil, errors.New("the report format \"" + format + "\" is not supported")
+}
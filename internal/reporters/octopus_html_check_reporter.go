@@ -0,0 +1,224 @@
+package reporters
+
+import (
+	"bytes"
+	_ "embed"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+//go:embed octopus_html_check_reporter.html
+var htmlReportTemplate string
+
+const (
+	htmlProjectGroup = "Projects"
+	htmlTargetGroup  = "Targets"
+	htmlOtherGroup   = "Other resources"
+	htmlSpaceGroup   = "Space"
+)
+
+// HtmlReport is the data passed to the HTML template.
+type HtmlReport struct {
+	WikiUrl    string
+	Checks     []HtmlCheck
+	Groups     []HtmlGroup
+	Categories []string
+	Severities []string
+}
+
+// HtmlCheck summarises the result of a single check.
+type HtmlCheck struct {
+	Code     string
+	Category string
+	Severity string
+	Summary  string
+	Link     string
+	Count    int
+}
+
+// HtmlGroup is a collection of owners, like all the projects or all the targets.
+type HtmlGroup struct {
+	Name   string
+	Owners []HtmlOwner
+}
+
+// HtmlOwner is the resource that owns a set of findings, like a project or a target.
+type HtmlOwner struct {
+	Name  string
+	Link  string
+	Items []HtmlItem
+}
+
+// HtmlItem is a single finding reported against an owner.
+type HtmlItem struct {
+	Code     string
+	Category string
+	Severity string
+	Link     string
+	Resource string
+	Details  string
+	Url      string
+	// Location is the file and line of a resource read from a local OCL file
+	Location string
+}
+
+// OctopusHtmlCheckReporter prints the lint reports as a self-contained HTML dashboard. The CSS and JavaScript are
+// embedded in the page so it can be viewed offline. Findings are grouped by the project or target that owns them.
+type OctopusHtmlCheckReporter struct {
+	minSeverity int
+}
+
+func NewOctopusHtmlCheckReporter(minSeverity int) OctopusHtmlCheckReporter {
+	return OctopusHtmlCheckReporter{minSeverity: minSeverity}
+}
+
+func (o OctopusHtmlCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	tmpl, err := template.New("report").Parse(htmlReportTemplate)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, o.buildReport(results)); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+func (o OctopusHtmlCheckReporter) buildReport(results []checks.OctopusCheckResult) HtmlReport {
+	filteredResults := []checks.OctopusCheckResult{}
+	for _, r := range results {
+		if isReported(r, o.minSeverity) {
+			filteredResults = append(filteredResults, r)
+		}
+	}
+
+	sort.SliceStable(filteredResults, func(i, j int) bool {
+		if filteredResults[i].Severity() == filteredResults[j].Severity() {
+			return filteredResults[i].Code() < filteredResults[j].Code()
+		}
+		return filteredResults[i].Severity() > filteredResults[j].Severity()
+	})
+
+	report := HtmlReport{
+		WikiUrl:    checks.WikiUrl,
+		Checks:     []HtmlCheck{},
+		Groups:     []HtmlGroup{},
+		Categories: []string{},
+		Severities: []string{},
+	}
+
+	// groups maps the group name to the owners in the group, keyed by the owner name
+	groups := map[string]map[string]*HtmlOwner{}
+	categories := map[string]bool{}
+	severities := map[string]bool{}
+
+	for _, r := range filteredResults {
+		severity := checks.SeverityToString(r.Severity())
+		categories[r.Category()] = true
+		severities[severity] = true
+
+		report.Checks = append(report.Checks, HtmlCheck{
+			Code:     resultName(r),
+			Category: r.Category(),
+			Severity: severity,
+			Summary:  strings.TrimSuffix(r.Summary(), ":"),
+			Link:     r.Link(),
+			Count:    len(r.Findings()),
+		})
+
+		if len(r.Findings()) == 0 {
+			// Results without findings, like permission errors or space wide checks, are reported against the space
+			o.addItem(groups, htmlSpaceGroup, htmlSpaceGroup, "", HtmlItem{
+				Code:     resultName(r),
+				Category: r.Category(),
+				Severity: severity,
+				Link:     r.Link(),
+				Details:  r.Description(),
+			})
+			continue
+		}
+
+		for _, f := range r.Findings() {
+			group, owner, ownerLink := o.owner(f)
+			o.addItem(groups, group, owner, ownerLink, HtmlItem{
+				Code:     resultName(r),
+				Category: r.Category(),
+				Severity: severity,
+				Link:     r.Link(),
+				Resource: f.ResourceName,
+				Details:  f.Details,
+				Url:      f.Link,
+				Location: f.Location(),
+			})
+		}
+	}
+
+	for _, groupName := range []string{htmlProjectGroup, htmlTargetGroup, htmlOtherGroup, htmlSpaceGroup} {
+		owners, ok := groups[groupName]
+		if !ok {
+			continue
+		}
+
+		group := HtmlGroup{Name: groupName, Owners: []HtmlOwner{}}
+		for _, owner := range owners {
+			group.Owners = append(group.Owners, *owner)
+		}
+
+		sort.Slice(group.Owners, func(i, j int) bool {
+			return strings.ToLower(group.Owners[i].Name) < strings.ToLower(group.Owners[j].Name)
+		})
+
+		report.Groups = append(report.Groups, group)
+	}
+
+	for category := range categories {
+		report.Categories = append(report.Categories, category)
+	}
+	sort.Strings(report.Categories)
+
+	for _, severity := range checks.SeverityNames {
+		if severities[severity] {
+			report.Severities = append(report.Severities, severity)
+		}
+	}
+
+	return report
+}
+
+// owner returns the group, name and link of the resource that owns the finding. Findings reported against a
+// project, or against a resource like a variable or step inside a project, are owned by the project.
+func (o OctopusHtmlCheckReporter) owner(finding checks.OctopusCheckFinding) (string, string, string) {
+	if finding.ParentType == checks.ProjectResource && finding.ParentName != "" {
+		return htmlProjectGroup, finding.ParentName, ""
+	}
+
+	switch finding.ResourceType {
+	case checks.ProjectResource:
+		return htmlProjectGroup, finding.ResourceName, finding.Link
+	case checks.TargetResource:
+		return htmlTargetGroup, finding.ResourceName, finding.Link
+	}
+
+	return htmlOtherGroup, finding.ResourceType, ""
+}
+
+func (o OctopusHtmlCheckReporter) addItem(groups map[string]map[string]*HtmlOwner, group string, owner string, ownerLink string, item HtmlItem) {
+	if _, ok := groups[group]; !ok {
+		groups[group] = map[string]*HtmlOwner{}
+	}
+
+	if _, ok := groups[group][owner]; !ok {
+		groups[group][owner] = &HtmlOwner{Name: owner, Link: ownerLink, Items: []HtmlItem{}}
+	}
+
+	if groups[group][owner].Link == "" {
+		groups[group][owner].Link = ownerLink
+	}
+
+	groups[group][owner].Items = append(groups[group][owner].Items, item)
+}
@@ -0,0 +1,75 @@
+package reporters
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"strings"
+	"testing"
+)
+
+func TestHtmlNoChecks(t *testing.T) {
+	results, err := OctopusHtmlCheckReporter{}.Generate(nil)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "No issues detected") {
+		t.Fatal("Should have reported that no issues were detected")
+	}
+}
+
+func TestHtmlGroupsByProjectAndTarget(t *testing.T) {
+	variablesResult := checks.NewOctopusCheckResultWithFindings("The following variables are unused:", "OctoLintUnusedVariables", "https://example.org/wiki/OctoLintUnusedVariables", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.VariableResource, ResourceId: "Variables-1", ResourceName: "Unused", ParentType: checks.ProjectResource, ParentId: "Projects-1", ParentName: "My Project"},
+		})
+	targetsResult := checks.NewOctopusCheckResultWithFindings("The following targets are unhealthy:", "OctoLintUnhealthyTargets", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.TargetResource, ResourceId: "Machines-1", ResourceName: "<script>", Link: "https://example.octopus.app/app#/Spaces-1/infrastructure/machines/Machines-1/settings"},
+		})
+
+	report := OctopusHtmlCheckReporter{minSeverity: checks.Warning}.buildReport([]checks.OctopusCheckResult{variablesResult, targetsResult})
+
+	if len(report.Checks) != 2 || report.Checks[0].Count != 1 {
+		t.Fatal("Should have counted the findings for each check")
+	}
+
+	if len(report.Groups) != 2 || report.Groups[0].Name != htmlProjectGroup || report.Groups[1].Name != htmlTargetGroup {
+		t.Fatal("Should have grouped the findings by project and target")
+	}
+
+	if report.Groups[0].Owners[0].Name != "My Project" || report.Groups[0].Owners[0].Items[0].Resource != "Unused" {
+		t.Fatal("Findings inside a project must be owned by the project")
+	}
+
+	results, err := OctopusHtmlCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{variablesResult, targetsResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if strings.Contains(results, "<script>\"") || !strings.Contains(results, "&lt;script&gt;") {
+		t.Fatal("Resource names must be escaped")
+	}
+
+	if !strings.Contains(results, "href=\"https://example.org/wiki/OctoLintUnusedVariables\"") {
+		t.Fatal("Should have linked to the wiki")
+	}
+}
+
+func TestHtmlLocation(t *testing.T) {
+	projectsResult := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.ProjectResource, ResourceName: "My Project", File: ".octopus/deployment_process.ocl", Line: 3},
+		})
+
+	results, err := OctopusHtmlCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{projectsResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "<span class=\"location\">.octopus/deployment_process.ocl:3</span>") {
+		t.Fatal("Should have included the location of the resource")
+	}
+}
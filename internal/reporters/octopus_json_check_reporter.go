@@ -0,0 +1,89 @@
+package reporters
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"sort"
+)
+
+// JsonReport is the document written by the OctopusJsonCheckReporter.
+type JsonReport struct {
+	Results []JsonCheckResult `json:"results"`
+}
+
+// JsonCheckResult is the JSON representation of a single OctopusCheckResult.
+type JsonCheckResult struct {
+	Code        string                       `json:"code"`
+	Description string                       `json:"description"`
+	Severity    string                       `json:"severity"`
+	Category    string                       `json:"category"`
+	Link        string                       `json:"link"`
+	Partial     bool                         `json:"partial,omitempty"`
+	Space       string                       `json:"space,omitempty"`
+	Findings    []checks.OctopusCheckFinding `json:"findings"`
+}
+
+// OctopusJsonCheckReporter prints the lint reports as a JSON document. The results are sorted
+// so the output is stable between runs.
+type OctopusJsonCheckReporter struct {
+	minSeverity int
+}
+
+func NewOctopusJsonCheckReporter(minSeverity int) OctopusJsonCheckReporter {
+	return OctopusJsonCheckReporter{minSeverity: minSeverity}
+}
+
+func (o OctopusJsonCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	report := JsonReport{
+		Results: []JsonCheckResult{},
+	}
+
+	for _, r := range results {
+		if isReported(r, o.minSeverity) {
+			report.Results = append(report.Results, JsonCheckResult{
+				Code:        r.Code(),
+				Description: r.Description(),
+				Severity:    checks.SeverityToString(r.Severity()),
+				Category:    r.Category(),
+				Link:        r.Link(),
+				Partial:     r.Partial(),
+				Space:       r.Space(),
+				Findings:    sortFindings(r.Findings()),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		if report.Results[i].Code == report.Results[j].Code {
+			if report.Results[i].Space != report.Results[j].Space {
+				return report.Results[i].Space < report.Results[j].Space
+			}
+			return report.Results[i].Description < report.Results[j].Description
+		}
+		return report.Results[i].Code < report.Results[j].Code
+	})
+
+	reportJson, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(reportJson), nil
+}
+
+// sortFindings returns a copy of the findings ordered by parent, resource name and resource ID. Checks often build
+// findings from maps, so the order they are returned in is not stable between runs.
+func sortFindings(findings []checks.OctopusCheckFinding) []checks.OctopusCheckFinding {
+	sorted := append([]checks.OctopusCheckFinding{}, findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].ParentName != sorted[j].ParentName {
+			return sorted[i].ParentName < sorted[j].ParentName
+		}
+		if sorted[i].ResourceName != sorted[j].ResourceName {
+			return sorted[i].ResourceName < sorted[j].ResourceName
+		}
+		return sorted[i].ResourceId < sorted[j].ResourceId
+	})
+	return sorted
+}
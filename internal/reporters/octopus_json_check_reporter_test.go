@@ -0,0 +1,121 @@
+package reporters
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"testing"
+)
+
+func TestJsonNoChecks(t *testing.T) {
+	results, err := OctopusJsonCheckReporter{}.Generate(nil)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.Results) != 0 {
+		t.Fatal("Should not have returned any results")
+	}
+}
+
+func TestJsonFailAndPassChecks(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "https://example.org", checks.Error, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	results, err := OctopusJsonCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{passResult, failedResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.Results) != 1 {
+		t.Fatal("Should have returned 1 result")
+	}
+
+	if report.Results[0].Code != "OctoRecAlwaysFail" ||
+		report.Results[0].Description != "This check always fails" ||
+		report.Results[0].Severity != "error" ||
+		report.Results[0].Category != checks.Organization ||
+		report.Results[0].Link != "https://example.org" {
+		t.Fatal("Should have returned the failed result")
+	}
+}
+
+func TestJsonResultsAreSorted(t *testing.T) {
+	resultB := checks.NewOctopusCheckResultImpl("Result B", "OctoRecB", "", checks.Warning, checks.Organization)
+	resultA := checks.NewOctopusCheckResultImpl("Result A", "OctoRecA", "", checks.Warning, checks.Organization)
+	results, err := OctopusJsonCheckReporter{minSeverity: checks.Ok}.Generate([]checks.OctopusCheckResult{resultB, resultA})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.Results) != 2 || report.Results[0].Code != "OctoRecA" || report.Results[1].Code != "OctoRecB" {
+		t.Fatal("Should have sorted the results by code")
+	}
+}
+
+func TestJsonFindings(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultWithFindings("The following projects have no steps:", "OctoLintEmptyProject", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.ProjectResource, ResourceId: "Projects-2", ResourceName: "Project B"},
+			{ResourceType: checks.ProjectResource, ResourceId: "Projects-1", ResourceName: "Project A"},
+		})
+	results, err := OctopusJsonCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{failedResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.Results) != 1 || len(report.Results[0].Findings) != 2 {
+		t.Fatal("Should have returned 1 result with 2 findings")
+	}
+
+	if report.Results[0].Findings[0].ResourceId != "Projects-1" || report.Results[0].Findings[1].ResourceId != "Projects-2" {
+		t.Fatal("Should have sorted the findings by name")
+	}
+
+	if report.Results[0].Description != "The following projects have no steps:\nProject A\nProject B" {
+		t.Fatal("Should have included the findings in the description")
+	}
+}
+
+func TestJsonSpaces(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, checks.Organization)
+	results, err := OctopusJsonCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(failedResult, "Spaces-2", "Second"),
+		checks.NewOctopusCheckSpaceResult(failedResult, "Spaces-1", "Default"),
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JsonReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if len(report.Results) != 2 || report.Results[0].Space != "Default" || report.Results[1].Space != "Second" {
+		t.Fatal("Should have returned the results for each space, sorted by space")
+	}
+}
@@ -0,0 +1,110 @@
+package reporters
+
+import (
+	"encoding/xml"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"sort"
+)
+
+const junitSuitesName = "octolint"
+
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// OctopusJUnitCheckReporter prints the lint reports as JUnit XML. Each check is a test case, and the test cases
+// are grouped into test suites by their category. Results at or above the minimum severity are failures,
+// permission results are skipped, and everything else passes.
+type OctopusJUnitCheckReporter struct {
+	minSeverity int
+}
+
+func NewOctopusJUnitCheckReporter(minSeverity int) OctopusJUnitCheckReporter {
+	return OctopusJUnitCheckReporter{minSeverity: minSeverity}
+}
+
+func (o OctopusJUnitCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	sortedResults := append([]checks.OctopusCheckResult{}, results...)
+	sort.SliceStable(sortedResults, func(i, j int) bool {
+		if sortedResults[i].Category() == sortedResults[j].Category() {
+			return sortedResults[i].Code() < sortedResults[j].Code()
+		}
+		return sortedResults[i].Category() < sortedResults[j].Category()
+	})
+
+	report := JUnitTestSuites{
+		Name:   junitSuitesName,
+		Suites: []JUnitTestSuite{},
+	}
+
+	for _, r := range sortedResults {
+		if len(report.Suites) == 0 || report.Suites[len(report.Suites)-1].Name != r.Category() {
+			report.Suites = append(report.Suites, JUnitTestSuite{Name: r.Category()})
+		}
+
+		suite := &report.Suites[len(report.Suites)-1]
+		testCase := JUnitTestCase{
+			Name:      resultName(r),
+			ClassName: junitSuitesName + "." + r.Category(),
+		}
+
+		if r.Severity() == checks.Permission {
+			testCase.Skipped = &JUnitSkipped{Message: r.Description()}
+			suite.Skipped++
+			report.Skipped++
+		} else if r.Severity() != checks.Ok && r.Severity() >= o.minSeverity {
+			testCase.Failure = &JUnitFailure{
+				Message: r.Description(),
+				Type:    checks.SeverityToString(r.Severity()),
+				Text:    r.Description(),
+			}
+			suite.Failures++
+			report.Failures++
+		} else {
+			testCase.SystemOut = r.Description()
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	reportXml, err := xml.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(reportXml), nil
+}
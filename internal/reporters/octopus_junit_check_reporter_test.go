@@ -0,0 +1,67 @@
+package reporters
+
+import (
+	"encoding/xml"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"testing"
+)
+
+func TestJUnitNoChecks(t *testing.T) {
+	results, err := OctopusJUnitCheckReporter{}.Generate(nil)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JUnitTestSuites{}
+	if err := xml.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid XML")
+	}
+
+	if report.Tests != 0 || len(report.Suites) != 0 {
+		t.Fatal("Should not have returned any test cases")
+	}
+}
+
+func TestJUnitSuitesByCategory(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Warning, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	infoResult := checks.NewOctopusCheckResultImpl("This check is informational", "OctoRecInfo", "", checks.Info, checks.Naming)
+	permissionResult := checks.NewOctopusCheckResultImpl("You do not have permission to run the check", "OctoRecPermission", "", checks.Permission, checks.Security)
+
+	results, err := OctopusJUnitCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{failedResult, passResult, infoResult, permissionResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := JUnitTestSuites{}
+	if err := xml.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid XML")
+	}
+
+	if report.Tests != 4 || report.Failures != 1 || report.Skipped != 1 {
+		t.Fatal("Should have returned 4 tests with 1 failure and 1 skipped test")
+	}
+
+	if len(report.Suites) != 3 {
+		t.Fatal("Should have returned 3 test suites")
+	}
+
+	// Suites are sorted by category
+	if report.Suites[0].Name != checks.Naming || report.Suites[0].TestCases[0].Failure != nil {
+		t.Fatal("Results below the minimum severity must pass")
+	}
+
+	if report.Suites[1].Name != checks.Organization || report.Suites[1].Tests != 2 || report.Suites[1].Failures != 1 {
+		t.Fatal("The organization suite should have 2 tests and 1 failure")
+	}
+
+	if report.Suites[1].TestCases[0].Failure == nil || report.Suites[1].TestCases[0].Failure.Message != "This check always fails" {
+		t.Fatal("The failure must include the description as the message")
+	}
+
+	if report.Suites[2].Name != checks.Security || report.Suites[2].TestCases[0].Skipped == nil {
+		t.Fatal("Permission results must be skipped")
+	}
+}
@@ -0,0 +1,144 @@
+package reporters
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"html"
+	"sort"
+	"strings"
+)
+
+var markdownEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"|", "\\|",
+	"[", "\\[",
+	"]", "\\]",
+	"*", "\\*",
+	"_", "\\_",
+	"`", "\\`",
+	"<", "&lt;",
+	">", "&gt;")
+
+// OctopusMarkdownCheckReporter prints the lint reports as Markdown, suitable for pull request comments, wiki pages,
+// and Octopus artifacts. The report starts with a summary table, followed by a collapsible section per check
+// listing the individual findings.
+type OctopusMarkdownCheckReporter struct {
+	minSeverity int
+}
+
+func NewOctopusMarkdownCheckReporter(minSeverity int) OctopusMarkdownCheckReporter {
+	return OctopusMarkdownCheckReporter{minSeverity: minSeverity}
+}
+
+func (o OctopusMarkdownCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	filteredResults := []checks.OctopusCheckResult{}
+	for _, r := range results {
+		if isReported(r, o.minSeverity) {
+			filteredResults = append(filteredResults, r)
+		}
+	}
+
+	// The most severe results are listed first
+	sort.SliceStable(filteredResults, func(i, j int) bool {
+		if filteredResults[i].Severity() == filteredResults[j].Severity() {
+			return filteredResults[i].Code() < filteredResults[j].Code()
+		}
+		return filteredResults[i].Severity() > filteredResults[j].Severity()
+	})
+
+	report := []string{"## Octolint Report"}
+
+	if len(filteredResults) == 0 {
+		report = append(report, "No issues detected")
+		return strings.Join(report, "\n\n") + "\n", nil
+	}
+
+	table := []string{
+		"| Check | Category | Severity | Count |",
+		"|-------|----------|----------|-------|",
+	}
+	for _, r := range filteredResults {
+		table = append(table, "| "+o.link(resultName(r), r.Link())+
+			" | "+o.escape(r.Category())+
+			" | "+checks.SeverityToString(r.Severity())+
+			" | "+fmt.Sprint(o.count(r))+" |")
+	}
+	report = append(report, strings.Join(table, "\n"))
+
+	for _, r := range filteredResults {
+		report = append(report, o.details(r))
+	}
+
+	report = append(report, "The checks are documented at "+checks.WikiUrl)
+
+	return strings.Join(report, "\n\n") + "\n", nil
+}
+
+// details builds the collapsible section for a single result. A blank line is required after the summary element
+// for the Markdown inside the details element to be rendered.
+func (o OctopusMarkdownCheckReporter) details(result checks.OctopusCheckResult) string {
+	section := []string{
+		"<details>",
+		"<summary>" + html.EscapeString(resultName(result)) + " (" + fmt.Sprint(o.count(result)) + ")</summary>",
+		"",
+		o.escape(result.Summary()),
+	}
+
+	if len(result.Findings()) != 0 {
+		section = append(section, "")
+		for _, f := range sortFindings(result.Findings()) {
+			section = append(section, "* "+o.finding(f))
+		}
+	}
+
+	if result.Link() != "" {
+		section = append(section, "", "More information: "+o.link(result.Link(), result.Link()))
+	}
+
+	section = append(section, "", "</details>")
+
+	return strings.Join(section, "\n")
+}
+
+func (o OctopusMarkdownCheckReporter) finding(finding checks.OctopusCheckFinding) string {
+	name := finding.ResourceName
+	if finding.ParentName != "" {
+		name = finding.ParentName + "/" + name
+	}
+
+	bullet := o.link(name, finding.Link)
+
+	if finding.Details != "" {
+		bullet += " - " + o.escape(finding.Details)
+	}
+
+	if finding.File != "" {
+		bullet = "`" + finding.Location() + "` " + bullet
+	}
+
+	return bullet
+}
+
+// count returns the number of findings, treating a failed result without any findings as a single issue.
+func (o OctopusMarkdownCheckReporter) count(result checks.OctopusCheckResult) int {
+	if len(result.Findings()) == 0 {
+		if result.Severity() >= checks.Info {
+			return 1
+		}
+		return 0
+	}
+
+	return len(result.Findings())
+}
+
+func (o OctopusMarkdownCheckReporter) link(text string, url string) string {
+	if url == "" {
+		return o.escape(text)
+	}
+
+	return "[" + o.escape(text) + "](" + url + ")"
+}
+
+func (o OctopusMarkdownCheckReporter) escape(text string) string {
+	return markdownEscaper.Replace(strings.ReplaceAll(text, "\n", " "))
+}
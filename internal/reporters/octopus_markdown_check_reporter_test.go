@@ -0,0 +1,56 @@
+package reporters
+
+import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownNoChecks(t *testing.T) {
+	results, err := OctopusMarkdownCheckReporter{}.Generate(nil)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "No issues detected") {
+		t.Fatal("Should have reported that no issues were detected")
+	}
+}
+
+func TestMarkdownSummaryAndFindings(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultWithFindings("The following feeds use an insecure HTTP endpoint:", "OctoLintInsecureFeeds", "https://example.org/wiki", checks.Warning, checks.Security,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.FeedResource, ResourceId: "Feeds-2", ResourceName: "Feed_2"},
+			{ResourceType: checks.FeedResource, ResourceId: "Feeds-1", ResourceName: "Feed1", Link: "https://example.octopus.app/app#/Spaces-1/library/feeds/Feeds-1/edit"},
+		})
+	errorResult := checks.NewOctopusCheckResultImpl("Too many environments", "OctoLintTooManyEnvironments", "", checks.Error, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+
+	results, err := OctopusMarkdownCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{failedResult, errorResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if strings.Contains(results, "OctoRecAlwaysPass") {
+		t.Fatal("Results below the minimum severity must not be reported")
+	}
+
+	if !strings.Contains(results, "| OctoLintTooManyEnvironments | Organization | error | 1 |") ||
+		!strings.Contains(results, "| [OctoLintInsecureFeeds](https://example.org/wiki) | Security | warning | 2 |") {
+		t.Fatal("Should have included a row in the summary table for each check")
+	}
+
+	if strings.Index(results, "| OctoLintTooManyEnvironments") > strings.Index(results, "| [OctoLintInsecureFeeds]") {
+		t.Fatal("The most severe results must be listed first")
+	}
+
+	if strings.Count(results, "<details>") != 2 {
+		t.Fatal("Should have included a details section per check")
+	}
+
+	if !strings.Contains(results, "* [Feed1](https://example.octopus.app/app#/Spaces-1/library/feeds/Feeds-1/edit)\n* Feed\\_2\n") {
+		t.Fatal("Each finding must be a bullet, linking to the portal when a link is available")
+	}
+}
@@ -22,9 +22,9 @@ func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult)
 	report := []string{}
 
 	for _, r := range results {
-		if r.Severity() >= o.minSeverity {
+		if isReported(r, o.minSeverity) {
 			report = append(report, "====================================================================================================")
-			report = append(report, r.Code())
+			report = append(report, resultName(r))
 			report = append(report, r.Description())
 		}
 	}
@@ -32,7 +32,7 @@ func (o OctopusPlainCheckReporter) Generate(results []checks.OctopusCheckResult)
 	if len(report) == 0 {
 		return "No issues detected", nil
 	} else {
-		report = append(report, "The checks are documented at https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/wiki")
+		report = append(report, "The checks are documented at "+checks.WikiUrl)
 	}
 
 	return strings.Join(report[:], "\n\n"), nil
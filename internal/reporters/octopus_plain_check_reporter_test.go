@@ -1,6 +1,7 @@
 package reporters
 
 import (
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/baseline"
 	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
 	"strings"
 	"testing"
@@ -62,3 +63,31 @@ func TestFailAndPassWithOkChecks(t *testing.T) {
 		t.Fatal("Should have returned 1 pass result")
 	}
 }
+
+func TestPlainSpaces(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultImpl("This check always fails", "OctoRecAlwaysFail", "", checks.Error, checks.Organization)
+	results, err := OctopusPlainCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{
+		checks.NewOctopusCheckSpaceResult(failedResult, "Spaces-1", "Default"),
+	})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, "OctoRecAlwaysFail (Default)") {
+		t.Fatal("Should have included the space in the report")
+	}
+}
+
+func TestPlainResolvedFindings(t *testing.T) {
+	resolved := baseline.NewResolvedResult([]baseline.OctopusBaselineEntry{{CheckId: "OctoLintEmptyProject", ResourceId: "Projects-1", ResourceName: "Project A"}})
+	results, err := OctopusPlainCheckReporter{minSeverity: checks.Warning}.Generate([]checks.OctopusCheckResult{resolved})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	if !strings.Contains(results, baseline.OctoLintResolvedFindings) || !strings.Contains(results, "Project A") {
+		t.Fatal("Should have listed the resolved findings regardless of the minimum severity")
+	}
+}
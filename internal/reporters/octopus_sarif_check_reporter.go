@@ -0,0 +1,230 @@
+package reporters
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+const sarifVersion = "2.1.0"
+const sarifToolName = "octolint"
+const sarifToolUri = "https://github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine"
+
+type SarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	Id                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     SarifMessage           `json:"shortDescription"`
+	HelpUri              string                 `json:"helpUri"`
+	DefaultConfiguration SarifRuleConfiguration `json:"defaultConfiguration"`
+	Properties           SarifRuleProperties    `json:"properties"`
+}
+
+type SarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifRuleProperties struct {
+	Category string `json:"category"`
+}
+
+type SarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation *SarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []SarifLogicalLocation `json:"logicalLocations"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type SarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+// OctopusSarifCheckReporter prints the lint reports as a SARIF 2.1.0 document. Each check is exposed as a rule,
+// and each offending resource is reported as a result.
+type OctopusSarifCheckReporter struct {
+	minSeverity int
+}
+
+func NewOctopusSarifCheckReporter(minSeverity int) OctopusSarifCheckReporter {
+	return OctopusSarifCheckReporter{minSeverity: minSeverity}
+}
+
+func (o OctopusSarifCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	filteredResults := []checks.OctopusCheckResult{}
+	for _, r := range results {
+		if isReported(r, o.minSeverity) {
+			filteredResults = append(filteredResults, r)
+		}
+	}
+
+	sort.SliceStable(filteredResults, func(i, j int) bool {
+		return filteredResults[i].Code() < filteredResults[j].Code()
+	})
+
+	run := SarifRun{
+		Tool: SarifTool{
+			Driver: SarifDriver{
+				Name:           sarifToolName,
+				InformationUri: sarifToolUri,
+				Rules:          []SarifRule{},
+			},
+		},
+		Results: []SarifResult{},
+	}
+
+	ruleIndexes := map[string]int{}
+	for _, r := range filteredResults {
+		ruleIndex, ok := ruleIndexes[r.Code()]
+		if !ok {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			ruleIndexes[r.Code()] = ruleIndex
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, SarifRule{
+				Id:                   r.Code(),
+				Name:                 r.Code(),
+				ShortDescription:     SarifMessage{Text: r.Code()},
+				HelpUri:              checks.WikiLink(r.Code()),
+				DefaultConfiguration: SarifRuleConfiguration{Level: o.sarifLevel(r.Severity())},
+				Properties:           SarifRuleProperties{Category: r.Category()},
+			})
+		}
+
+		run.Results = append(run.Results, o.buildResults(r, ruleIndex)...)
+	}
+
+	report := SarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []SarifRun{run},
+	}
+
+	reportJson, err := json.MarshalIndent(report, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(reportJson), nil
+}
+
+// buildResults creates one SARIF result per finding in the check result. The first line of the description is the
+// message. Results without any findings are reported as a single result with the full description.
+func (o OctopusSarifCheckReporter) buildResults(result checks.OctopusCheckResult, ruleIndex int) []SarifResult {
+	findings := sortFindings(result.Findings())
+
+	if len(findings) == 0 {
+		return []SarifResult{{
+			RuleId:    result.Code(),
+			RuleIndex: ruleIndex,
+			Level:     o.sarifLevel(result.Severity()),
+			Message:   SarifMessage{Text: result.Description()},
+		}}
+	}
+
+	lines := strings.Split(result.Description(), "\n")
+	message := strings.TrimSuffix(strings.TrimSpace(lines[0]), ":")
+
+	sarifResults := []SarifResult{}
+	for _, finding := range findings {
+		fullyQualifiedName := finding.ResourceName
+		if finding.ParentName != "" {
+			fullyQualifiedName = finding.ParentName + "/" + finding.ResourceName
+		}
+		if result.Space() != "" {
+			fullyQualifiedName = result.Space() + "/" + fullyQualifiedName
+		}
+
+		sarifResults = append(sarifResults, SarifResult{
+			RuleId:    result.Code(),
+			RuleIndex: ruleIndex,
+			Level:     o.sarifLevel(result.Severity()),
+			Message:   SarifMessage{Text: message + ": " + finding.String()},
+			Locations: []SarifLocation{{
+				PhysicalLocation: o.physicalLocation(finding),
+				LogicalLocations: []SarifLogicalLocation{{
+					Name:               finding.ResourceName,
+					FullyQualifiedName: fullyQualifiedName,
+					Kind:               finding.ResourceType,
+				}},
+			}},
+		})
+	}
+
+	return sarifResults
+}
+
+// physicalLocation returns the file and line of findings read from local OCL files, or nil for resources read from
+// the Octopus API.
+func (o OctopusSarifCheckReporter) physicalLocation(finding checks.OctopusCheckFinding) *SarifPhysicalLocation {
+	if finding.File == "" {
+		return nil
+	}
+
+	location := &SarifPhysicalLocation{ArtifactLocation: SarifArtifactLocation{Uri: filepath.ToSlash(finding.File)}}
+
+	if finding.Line != 0 {
+		location.Region = &SarifRegion{StartLine: finding.Line}
+	}
+
+	return location
+}
+
+func (o OctopusSarifCheckReporter) sarifLevel(severity int) string {
+	switch {
+	case severity >= checks.Error:
+		return "error"
+	case severity >= checks.Warning:
+		return "warning"
+	case severity >= checks.Info:
+		return "note"
+	default:
+		return "none"
+	}
+}
@@ -0,0 +1,99 @@
+package reporters
+
+import (
+	"encoding/json"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"testing"
+)
+
+func TestSarifNoChecks(t *testing.T) {
+	results, err := OctopusSarifCheckReporter{}.Generate(nil)
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := SarifReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	if report.Version != "2.1.0" || len(report.Runs) != 1 || len(report.Runs[0].Results) != 0 {
+		t.Fatal("Should have returned a single run with no results")
+	}
+}
+
+func TestSarifResultPerResource(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultWithFindings("The following feeds use an insecure HTTP endpoint:", "OctoLintInsecureFeeds", "", checks.Warning, checks.Security,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.FeedResource, ResourceId: "Feeds-2", ResourceName: "Feed2"},
+			{ResourceType: checks.FeedResource, ResourceId: "Feeds-1", ResourceName: "Feed1"},
+		})
+	infoResult := checks.NewOctopusCheckResultImpl("Some info", "OctoLintInfo", "", checks.Info, checks.Organization)
+	passResult := checks.NewOctopusCheckResultImpl("This check always passes", "OctoRecAlwaysPass", "", checks.Ok, checks.Organization)
+	results, err := OctopusSarifCheckReporter{minSeverity: checks.Info}.Generate([]checks.OctopusCheckResult{failedResult, infoResult, passResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := SarifReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	run := report.Runs[0]
+
+	if len(run.Tool.Driver.Rules) != 2 {
+		t.Fatal("Should have returned 2 rules")
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatal("Should have returned 3 results")
+	}
+
+	for _, r := range run.Results {
+		rule := run.Tool.Driver.Rules[r.RuleIndex]
+		if rule.Id != r.RuleId {
+			t.Fatal("The rule index must match the rule ID")
+		}
+
+		if rule.HelpUri != checks.WikiLink(r.RuleId) {
+			t.Fatal("The rule must link to the wiki")
+		}
+	}
+
+	if run.Results[0].Level != "note" || run.Results[0].Message.Text != "Some info" {
+		t.Fatal("Info results must be reported as notes")
+	}
+
+	if run.Results[1].Level != "warning" ||
+		run.Results[1].Message.Text != "The following feeds use an insecure HTTP endpoint: Feed1" ||
+		run.Results[1].Locations[0].LogicalLocations[0].Name != "Feed1" ||
+		run.Results[1].Locations[0].LogicalLocations[0].Kind != checks.FeedResource {
+		t.Fatal("Warning results must be reported per resource")
+	}
+}
+
+func TestSarifPhysicalLocation(t *testing.T) {
+	failedResult := checks.NewOctopusCheckResultWithFindings("The following variables may be unused:", "OctoLintUnusedVariables", "", checks.Warning, checks.Organization,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.VariableResource, ResourceId: "variables.ocl/Unused/0", ResourceName: "Unused", File: ".octopus/variables.ocl", Line: 12},
+		})
+	results, err := OctopusSarifCheckReporter{}.Generate([]checks.OctopusCheckResult{failedResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report := SarifReport{}
+	if err := json.Unmarshal([]byte(results), &report); err != nil {
+		t.Fatal("Should have returned valid JSON")
+	}
+
+	location := report.Runs[0].Results[0].Locations[0].PhysicalLocation
+
+	if location == nil || location.ArtifactLocation.Uri != ".octopus/variables.ocl" || location.Region.StartLine != 12 {
+		t.Fatal("Findings read from OCL files must include the file and line")
+	}
+}
@@ -0,0 +1,124 @@
+package reporters
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const serviceMessageVariablePrefix = "Octolint"
+
+// OctopusServiceMessageCheckReporter wraps another reporter when octolint runs inside an Octopus step. The report is
+// written to a file that is attached to the deployment as an artifact, and the number of findings are captured in
+// output variables.
+type OctopusServiceMessageCheckReporter struct {
+	reporter   OctopusCheckReporter
+	reportFile string
+}
+
+func NewOctopusServiceMessageCheckReporter(reporter OctopusCheckReporter, reportFile string) OctopusServiceMessageCheckReporter {
+	return OctopusServiceMessageCheckReporter{reporter: reporter, reportFile: reportFile}
+}
+
+func (o OctopusServiceMessageCheckReporter) Generate(results []checks.OctopusCheckResult) (string, error) {
+	report, err := o.reporter.Generate(results)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(o.reportFile, []byte(report), 0644); err != nil {
+		return "", err
+	}
+
+	absReportFile, err := filepath.Abs(o.reportFile)
+
+	if err != nil {
+		return "", err
+	}
+
+	messages := []string{report}
+
+	counts := o.countFindings(results)
+	names := []string{}
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		messages = append(messages, serviceMessage("setVariable",
+			"name", serviceMessageVariablePrefix+"."+name,
+			"value", fmt.Sprint(counts[name])))
+	}
+
+	messages = append(messages, serviceMessage("createArtifact",
+		"path", absReportFile,
+		"name", filepath.Base(o.reportFile),
+		"length", fmt.Sprint(len(report))))
+
+	return strings.Join(messages, "\n"), nil
+}
+
+// countFindings returns the number of findings for each severity and category. Results without any findings count
+// as a single finding. Checks that failed to run, timed out, or lacked permissions are counted separately.
+func (o OctopusServiceMessageCheckReporter) countFindings(results []checks.OctopusCheckResult) map[string]int {
+	counts := map[string]int{
+		"Findings.Total":   0,
+		"Findings.Error":   0,
+		"Findings.Warning": 0,
+		"Findings.Info":    0,
+		"CheckFailures":    0,
+		"CheckTimeouts":    0,
+		"PermissionErrors": 0,
+	}
+
+	for _, r := range results {
+		if r.Category() == checks.GeneralError {
+			counts["CheckFailures"]++
+			continue
+		}
+
+		if r.Category() == checks.TimedOut {
+			counts["CheckTimeouts"]++
+			continue
+		}
+
+		if r.Severity() == checks.Permission {
+			counts["PermissionErrors"]++
+			continue
+		}
+
+		if r.Severity() < checks.Info {
+			continue
+		}
+
+		findings := len(r.Findings())
+		if findings == 0 {
+			findings = 1
+		}
+
+		severity := checks.SeverityToString(r.Severity())
+		counts["Findings.Total"] += findings
+		counts["Findings."+strings.ToUpper(severity[:1])+severity[1:]] += findings
+		counts["Findings.Category."+r.Category()] += findings
+	}
+
+	return counts
+}
+
+// serviceMessage builds an Octopus service message. The attribute values are base64 encoded, which allows them to
+// contain any characters.
+// See https://octopus.com/docs/deployments/custom-scripts/logging-messages-in-scripts#service-message
+func serviceMessage(name string, attributes ...string) string {
+	encodedAttributes := []string{}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		encodedAttributes = append(encodedAttributes, attributes[i]+"='"+base64.StdEncoding.EncodeToString([]byte(attributes[i+1]))+"'")
+	}
+
+	return "##octopus[" + name + " " + strings.Join(encodedAttributes, " ") + "]"
+}
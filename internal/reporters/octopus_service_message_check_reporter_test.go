@@ -0,0 +1,57 @@
+package reporters
+
+import (
+	"encoding/base64"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServiceMessage(t *testing.T) {
+	message := serviceMessage("setVariable", "name", "Octolint.Findings.Total", "value", "2")
+
+	if message != "##octopus[setVariable name='"+base64.StdEncoding.EncodeToString([]byte("Octolint.Findings.Total"))+
+		"' value='"+base64.StdEncoding.EncodeToString([]byte("2"))+"']" {
+		t.Fatal("Service message values must be base64 encoded")
+	}
+}
+
+func TestServiceMessagesWritesReportAndVariables(t *testing.T) {
+	reportFile := filepath.Join(t.TempDir(), "octolint-report.json")
+	failedResult := checks.NewOctopusCheckResultWithFindings("The following feeds use an insecure HTTP endpoint:", "OctoLintInsecureFeeds", "", checks.Warning, checks.Security,
+		[]checks.OctopusCheckFinding{
+			{ResourceType: checks.FeedResource, ResourceId: "Feeds-1", ResourceName: "Feed1"},
+			{ResourceType: checks.FeedResource, ResourceId: "Feeds-2", ResourceName: "Feed2"},
+		})
+	errorResult := checks.NewOctopusCheckResultImpl("The check failed to run", "OctoRecBroken", "", checks.Error, checks.GeneralError)
+
+	results, err := NewOctopusServiceMessageCheckReporter(NewOctopusJsonCheckReporter(checks.Warning), reportFile).
+		Generate([]checks.OctopusCheckResult{failedResult, errorResult})
+
+	if err != nil {
+		t.Fatal("Should not have returned an error")
+	}
+
+	report, err := os.ReadFile(reportFile)
+
+	if err != nil || !strings.HasPrefix(results, string(report)) {
+		t.Fatal("Should have written the report to the file and printed it")
+	}
+
+	expectedMessages := []string{
+		serviceMessage("setVariable", "name", "Octolint.Findings.Total", "value", "2"),
+		serviceMessage("setVariable", "name", "Octolint.Findings.Warning", "value", "2"),
+		serviceMessage("setVariable", "name", "Octolint.Findings.Category.Security", "value", "2"),
+		serviceMessage("setVariable", "name", "Octolint.Findings.Error", "value", "0"),
+		serviceMessage("setVariable", "name", "Octolint.CheckFailures", "value", "1"),
+		"##octopus[createArtifact path='" + base64.StdEncoding.EncodeToString([]byte(reportFile)) + "'",
+	}
+
+	for _, message := range expectedMessages {
+		if !strings.Contains(results, message) {
+			t.Fatal("Should have included the service message " + message)
+		}
+	}
+}
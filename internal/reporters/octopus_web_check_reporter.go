@@ -22,7 +22,7 @@ func (o OctopusWebCheckReporter) Generate(results []checks.OctopusCheckResult) (
 	report := []string{}
 
 	for _, r := range results {
-		if r.Severity() >= o.minSeverity {
+		if isReported(r, o.minSeverity) {
 			report = append(report, r.Description())
 		}
 	}
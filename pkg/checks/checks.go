@@ -0,0 +1,130 @@
+// Package checks is the public API for adding checks to octolint. Checks are registered from the init function of
+// their package, and are run against each space along with the built-in checks:
+//
+//	func init() {
+//		checks.MustRegisterCheck(MyCheckId, func(dependencies checks.OctopusCheckDependencies) checks.OctopusCheck {
+//			return NewMyCheck(dependencies)
+//		})
+//	}
+//
+// The types are aliases of the types used by the built-in checks, so registered checks receive the same client,
+// cache, config, and error handler.
+package checks
+
+import (
+	"context"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/client_wrapper"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/config"
+)
+
+// OctopusCheck defines the contract for each lint check
+type OctopusCheck = checks.OctopusCheck
+
+// OctopusCheckResult is the result of a check
+type OctopusCheckResult = checks.OctopusCheckResult
+
+// OctopusCheckResultImpl is the implementation of OctopusCheckResult returned by the result constructors
+type OctopusCheckResultImpl = checks.OctopusCheckResultImpl
+
+// OctopusCheckFinding identifies an individual resource that was flagged by a check
+type OctopusCheckFinding = checks.OctopusCheckFinding
+
+// OctopusClientErrorHandler converts the errors returned by the Octopus API into results
+type OctopusClientErrorHandler = checks.OctopusClientErrorHandler
+
+// OctopusClientPermissiveErrorHandler reports checks that could not be run because of missing permissions, rather
+// than failing them
+type OctopusClientPermissiveErrorHandler = checks.OctopusClientPermissiveErrorHandler
+
+// OctopusUrlBuilder creates links to resources in the Octopus web portal
+type OctopusUrlBuilder = checks.OctopusUrlBuilder
+
+// ProgressReporter receives the progress of a check
+type ProgressReporter = checks.ProgressReporter
+
+// OctopusDataSource provides the resources read by the checks
+type OctopusDataSource = client_wrapper.OctopusDataSource
+
+// OctopusClientCache caches the resources shared by the checks, so each resource is only read once
+type OctopusClientCache = client_wrapper.OctopusClientCache
+
+// OctolintConfig holds the arguments passed to octolint
+type OctolintConfig = config.OctolintConfig
+
+// OctopusCheckDependencies are the shared resources passed to the constructor of a registered check
+type OctopusCheckDependencies = checks.OctopusCheckDependencies
+
+// OctopusCheckConstructor creates a new instance of a registered check for a space
+type OctopusCheckConstructor = checks.OctopusCheckConstructor
+
+// The severities of results
+const (
+	Error      = checks.Error
+	Warning    = checks.Warning
+	Info       = checks.Info
+	Permission = checks.Permission
+	Ok         = checks.Ok
+)
+
+// The categories of results
+const (
+	Organization = checks.Organization
+	Naming       = checks.Naming
+	Security     = checks.Security
+	Performance  = checks.Performance
+	Optimization = checks.Optimization
+)
+
+// The resource types of findings
+const (
+	ProjectResource            = checks.ProjectResource
+	ProjectGroupResource       = checks.ProjectGroupResource
+	VariableResource           = checks.VariableResource
+	StepResource               = checks.StepResource
+	ActionResource             = checks.ActionResource
+	TargetResource             = checks.TargetResource
+	TenantResource             = checks.TenantResource
+	EnvironmentResource        = checks.EnvironmentResource
+	LifecycleResource          = checks.LifecycleResource
+	FeedResource               = checks.FeedResource
+	AccountResource            = checks.AccountResource
+	CertificateResource        = checks.CertificateResource
+	SubscriptionResource       = checks.SubscriptionResource
+	LibraryVariableSetResource = checks.LibraryVariableSetResource
+	WorkerPoolResource         = checks.WorkerPoolResource
+)
+
+// RegisterCheck adds a check that is run against each space along with the built-in checks. The ID must match the ID
+// returned by the check, and must not match the ID of a built-in check or rule.
+func RegisterCheck(id string, constructor OctopusCheckConstructor) error {
+	return checks.RegisterCheck(id, constructor)
+}
+
+// MustRegisterCheck is like RegisterCheck, but panics if the check can not be registered. It is intended to be called
+// from an init function.
+func MustRegisterCheck(id string, constructor OctopusCheckConstructor) {
+	if err := checks.RegisterCheck(id, constructor); err != nil {
+		panic(err)
+	}
+}
+
+// NewOctopusCheckResultImpl creates a result with no findings.
+func NewOctopusCheckResultImpl(description string, code string, link string, severity int, category string) OctopusCheckResultImpl {
+	return checks.NewOctopusCheckResultImpl(description, code, link, severity, category)
+}
+
+// NewOctopusCheckResultWithFindings creates a result that lists the resources flagged by the check.
+func NewOctopusCheckResultWithFindings(summary string, code string, link string, severity int, category string, findings []OctopusCheckFinding) OctopusCheckResultImpl {
+	return checks.NewOctopusCheckResultWithFindings(summary, code, link, severity, category, findings)
+}
+
+// NewOctopusUrlBuilder creates links to the resources in a space.
+func NewOctopusUrlBuilder(url string, space string) OctopusUrlBuilder {
+	return checks.NewOctopusUrlBuilder(url, space)
+}
+
+// Progress returns the reporter that a check uses to report its progress.
+func Progress(ctx context.Context) ProgressReporter {
+	return checks.Progress(ctx)
+}
@@ -0,0 +1,79 @@
+// Package cli runs the octolint command line interface. It allows octolint to be built with additional checks compiled
+// in, by importing the packages that register the checks alongside this package:
+//
+//	package main
+//
+//	import (
+//		"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/pkg/cli"
+//		_ "github.com/my-org/octolint-checks"
+//	)
+//
+//	func main() {
+//		cli.Main()
+//	}
+package cli
+
+import (
+	"fmt"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/args"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/checks"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/entry"
+	"github.com/OctopusSolutionsEngineering/OctopusRecommendationEngine/internal/reporters"
+	"os"
+)
+
+// Main parses the command line arguments, runs the checks, and prints the report. It exits the process with the
+// exit code described in the README.
+func Main() {
+	octolintConfig, err := args.ParseArgs(os.Args[1:])
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+		return
+	}
+
+	results, err := entry.Entry(octolintConfig)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	minSeverity, err := checks.StringToSeverity(octolintConfig.MinSeverity)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	reporter, err := reporters.NewOctopusCheckReporter(octolintConfig.Format, minSeverity)
+
+	if err != nil {
+		entry.ErrorExit(err.Error())
+	}
+
+	if octolintConfig.OctopusServiceMessages {
+		reportFile := octolintConfig.ReportFile
+		if reportFile == "" {
+			reportFile = reporters.DefaultReportFile(octolintConfig.Format)
+		}
+
+		reporter = reporters.NewOctopusServiceMessageCheckReporter(reporter, reportFile)
+	}
+
+	report, err := reporter.Generate(results)
+
+	if err != nil {
+		entry.ErrorExit("Failed to generate the report")
+	}
+
+	fmt.Println(report)
+
+	if octolintConfig.FailOnSeverity != "" {
+		failOnSeverity, err := checks.StringToSeverity(octolintConfig.FailOnSeverity)
+
+		if err != nil {
+			entry.ErrorExit(err.Error())
+		}
+
+		os.Exit(entry.ExitCode(results, failOnSeverity))
+	}
+}